@@ -1042,6 +1042,45 @@ func (ta *TestAPI) CmpXMLBody(expectedBody any) *TestAPI {
 	return ta.CmpMarshaledBody(xml.Unmarshal, expectedBody)
 }
 
+// CmpZipBody tests that the last request response body is a zip
+// archive whose entries match expectedEntries, as [td.Zip] does.
+// expectedEntries is typically a map[string][td.ArchiveEntry] or a
+// [td.TestDeep] operator.
+//
+//	ta := tdhttp.NewTestAPI(t, mux)
+//
+//	ta.Get("/export.zip").
+//	  CmpStatus(http.StatusOK).
+//	  CmpHeader(td.ContainsKey("Content-Disposition")).
+//	  CmpZipBody(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+//	    "README.md":    td.Smuggle("Content", td.HasPrefix("# ")),
+//	    "persons.json": td.Smuggle("Content",
+//	      td.Smuggle(json.RawMessage(nil), td.JSON(`[{"name": "Bob"}]`))),
+//	  }))
+//
+// It fails if no request has been sent yet.
+func (ta *TestAPI) CmpZipBody(expectedEntries any) *TestAPI {
+	ta.t.Helper()
+	return ta.CmpBody(td.Zip(expectedEntries))
+}
+
+// CmpTarBody tests that the last request response body is a tar
+// archive, optionally gzip'ed, whose entries match expectedEntries,
+// as [td.Tar] does. expectedEntries is typically a
+// map[string][td.ArchiveEntry] or a [td.TestDeep] operator.
+//
+//	ta := tdhttp.NewTestAPI(t, mux)
+//
+//	ta.Get("/export.tar.gz").
+//	  CmpStatus(http.StatusOK).
+//	  CmpTarBody(td.Keys(td.Bag("README.md", "persons.json")))
+//
+// It fails if no request has been sent yet.
+func (ta *TestAPI) CmpTarBody(expectedEntries any) *TestAPI {
+	ta.t.Helper()
+	return ta.CmpBody(td.Tar(expectedEntries))
+}
+
 // NoBody tests that the last request response body is empty.
 //
 // It fails if no request has been sent yet.
@@ -7,6 +7,9 @@
 package tdhttp_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -1100,6 +1103,44 @@ bingo%CR
 	})
 }
 
+func TestCmpArchiveBody(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/archive.zip", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		zw := zip.NewWriter(w)
+		f, _ := zw.Create("README.md")
+		io.WriteString(f, "# Title\n") //nolint: errcheck
+		zw.Close()                     //nolint: errcheck
+	})
+
+	mux.HandleFunc("/archive.tar.gz", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		gw := gzip.NewWriter(w)
+		tw := tar.NewWriter(gw)
+		tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0o644, Size: 8}) //nolint: errcheck
+		io.WriteString(tw, "# Title\n")                                      //nolint: errcheck
+		tw.Close()                                                           //nolint: errcheck
+		gw.Close()                                                           //nolint: errcheck
+	})
+
+	readme := td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+		"README.md": td.Smuggle("Content", "# Title\n"),
+	})
+
+	ta := tdhttp.NewTestAPI(tdutil.NewT("test"), mux)
+
+	td.CmpFalse(t, ta.Get("/archive.zip").CmpStatus(200).CmpZipBody(readme).Failed())
+	td.CmpFalse(t, ta.Get("/archive.tar.gz").CmpStatus(200).CmpTarBody(readme).Failed())
+
+	td.CmpTrue(t, ta.Get("/archive.zip").CmpZipBody(td.Len(2)).Failed())
+	td.CmpTrue(t, ta.Get("/archive.zip").CmpTarBody(readme).Failed())
+	td.CmpTrue(t, ta.Get("/archive.tar.gz").CmpZipBody(readme).Failed())
+
+	// No request sent yet
+	td.CmpTrue(t, tdhttp.NewTestAPI(tdutil.NewT("test"), mux).CmpZipBody(readme).Failed())
+}
+
 func TestWith(t *testing.T) {
 	mux := server()
 
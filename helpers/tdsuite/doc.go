@@ -7,7 +7,8 @@
 // Package tdsuite adds tests suite feature to [go-testdeep] in a
 // non-intrusive way, but easily and powerfully.
 //
-// A tests suite is a set of tests run sequentially that share some data.
+// A tests suite is a set of tests run sequentially that share some
+// data. Some of them can also be run in parallel, see [Parallel].
 //
 // Some hooks can be set to be automatically called before the suite
 // is run, before, after and/or between each test, and at the end of
@@ -59,6 +60,45 @@
 //
 // Test methods are run in lexicographic order.
 //
+// # Data-provider test methods
+//
+// A test method can also accept a data case as last parameter, as in:
+//
+//	func (s *MySuite) TestXxx(t *td.T, tc MyCase)
+//	func (s *MySuite) TestXxx(assert, require *td.T, tc MyCase)
+//
+// in this case, a data-provider method CasesXxx has to be declared,
+// returning a slice, an array or a map of cases assignable to MyCase:
+//
+//	func (s *MySuite) CasesXxx() []MyCase
+//	func (s *MySuite) CasesXxx() map[string]MyCase
+//
+// The data-provider method is called just before the test runs (so
+// after [Setup]), then the test method is called once per data case,
+// each time in a subtest of the test method subtest. Slice and array
+// cases are named after their index, as "#0", "#1" and so on, while
+// map cases are named after their key using [tdutil.BuildTestName],
+// in keys order. [PreTest] and [PostTest] hooks are called around each
+// data case. As for other test methods, the returned values are
+// handled the same way, discontinuing the suite also skipping the
+// remaining data cases.
+//
+//	type ParseCase struct {
+//	  In       string
+//	  Expected int
+//	}
+//
+//	func (s *MySuite) CasesParse() map[string]ParseCase {
+//	  return map[string]ParseCase{
+//	    "zero":     {In: "0", Expected: 0},
+//	    "negative": {In: "-12", Expected: -12},
+//	  }
+//	}
+//
+//	func (s *MySuite) TestParse(t *td.T, tc ParseCase) {
+//	  t.Cmp(Parse(tc.In), tc.Expected)
+//	}
+//
 // # Very simple tests suite
 //
 // Used typically to group tests and benefit from already instanciated
@@ -123,8 +163,98 @@
 //	  tdsuite.Run(t, &SuiteDB{})
 //	}
 //
-// See documentation below for other possible hooks: [PreTest], [PostTest]
-// and [BetweenTests].
+// See documentation below for other possible hooks: [PreTest], [PostTest],
+// [BetweenTests], [Parallel], [Timeout] and [Retries].
+//
+// Tests can also be tagged, to be selected or skipped using the
+// TDSUITE_TAGS environment variable, see [Tags].
+//
+// # Sub-suites
+//
+// Big suites can be split in several sub-suites, sharing the fixtures
+// of their parent suite. Each exported field of a suite implementing
+// [SubSuite] is run as a nested tests suite, once all the tests of
+// the parent suite ran:
+//
+//	type SuiteUsers struct{ DB *sql.DB }
+//
+//	func (s *SuiteUsers) SubSuite() {}
+//
+//	func (s *SuiteUsers) TestList(assert, require *td.T) {
+//	  users, err := ListUsers(s.DB)
+//	  require.CmpNoError(err)
+//	  assert.Len(users, 2)
+//	}
+//
+//	type SuiteAPI struct {
+//	  DB    *sql.DB
+//	  Users *SuiteUsers
+//	}
+//
+//	func (s *SuiteAPI) Setup(t *td.T) error {
+//	  db, err := sql.Open(driver, dataSourceName)
+//	  s.DB = db
+//	  s.Users = &SuiteUsers{DB: db}
+//	  return err
+//	}
+//
+//	func (s *SuiteAPI) Destroy(t *td.T) error {
+//	  return s.DB.Close() // called after Users sub-suite ran
+//	}
+//
+// # Fixtures injection
+//
+// Instead of storing shared resources in suite fields, a suite can
+// implement [Fixtures] to provide values that are injected in test
+// methods parameters, after the [*td.T] one(s), depending on their
+// types:
+//
+//	func (s *SuiteAPI) Fixtures() []tdsuite.Fixture {
+//	  return []tdsuite.Fixture{
+//	    tdsuite.PerSuite(func(t *td.T) (*sql.DB, error) {
+//	      return sql.Open(driver, dataSourceName)
+//	    }),
+//	    tdsuite.PerTest(func(t *td.T) *User {
+//	      return &User{Name: "Bob"}
+//	    }),
+//	  }
+//	}
+//
+//	func (s *SuiteAPI) TestCreate(assert, require *td.T, db *sql.DB, u *User) {
+//	  require.CmpNoError(CreateUser(db, u))
+//	  assert.NotZero(u.ID)
+//	}
+//
+// [PerSuite] providers are called once just after [Setup], [PerTest]
+// ones for each test needing them, just after [PreTest].
+//
+// # Benchmarks
+//
+// When [Run] is called with a [*testing.B], test methods are ignored
+// and the benchmark methods are run instead. A benchmark method name
+// starts with "Benchmark" and it accepts a [*td.T] followed by a
+// [*testing.B], plus optionally the fixtures it needs (see
+// [Fixtures]):
+//
+//	func (s *SuiteAPI) BenchmarkList(t *td.T, b *testing.B) {
+//	  for i := 0; i < b.N; i++ {
+//	    _, err := ListUsers(s.DB)
+//	    t.CmpNoError(err)
+//	  }
+//	}
+//
+//	// BenchmarkSuiteAPI is the go benchmark entry point.
+//	func BenchmarkSuiteAPI(b *testing.B) {
+//	  tdsuite.Run(b, &SuiteAPI{})
+//	}
+//
+// [Setup] and [Destroy] are shared with tests, so is the fixture
+// code. As go calls each benchmark several times with an increasing
+// b.N, [PreTest] and [PostTest] hooks as well as per-test fixtures
+// are called around each of these calls. The benchmark timer is
+// reset just before calling the benchmark method and stopped just
+// after, so these hooks are not measured.
 //
 // [go-testdeep]: https://go-testdeep.zetta.rocks/
+// [tdutil.BuildTestName]: https://pkg.go.dev/github.com/maxatome/go-testdeep/helpers/tdutil#BuildTestName
 package tdsuite
@@ -0,0 +1,154 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package tdsuite
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// Fixtures is an interface a tests suite can implement. Fixtures
+// method returns the fixtures providers of the suite, created using
+// [PerTest] or [PerSuite]. It is called once, before [Setup].
+//
+// Each provider produces a value of a given type. Test methods can
+// then declare parameters of these types after their [*td.T]
+// one(s), the values being automatically injected by [Run]:
+//
+//	func (s *MySuite) Fixtures() []tdsuite.Fixture {
+//	  return []tdsuite.Fixture{
+//	    tdsuite.PerSuite(func(t *td.T) (*sql.DB, error) {
+//	      db, err := sql.Open(driver, dataSourceName)
+//	      if err == nil {
+//	        t.Cleanup(func() { db.Close() })
+//	      }
+//	      return db, err
+//	    }),
+//	    tdsuite.PerTest(func(t *td.T) *tdhttp.TestAPI {
+//	      return tdhttp.NewTestAPI(t, s.Handler)
+//	    }),
+//	  }
+//	}
+//
+//	func (s *MySuite) TestX(t *td.T, db *sql.DB, api *tdhttp.TestAPI) {
+//	  // ...
+//	}
+//
+// If the test method is a data-provider one, the data case parameter
+// stays the last one.
+type Fixtures interface {
+	Fixtures() []Fixture
+}
+
+// Fixture is a fixture provider. See [Fixtures], [PerTest] and
+// [PerSuite].
+type Fixture struct {
+	provider reflect.Value
+	typ      reflect.Type
+	perSuite bool
+	index    int // position in Fixtures() returned slice
+	err      error
+}
+
+// PerTest returns a [Fixture] whose provider is called for each test
+// method needing it, just after [PreTest] method. provider has to be a
+// function with one of the following signatures:
+//
+//	func(t *td.T) X
+//	func(t *td.T) (X, error)
+//
+// where X is the type of the provided value. t is the [*td.T] of the
+// test, so t.Cleanup() can be used to automatically release the
+// provided value at the end of the test. If provider returns a
+// non-nil error, the test fails and the test method is not called.
+func PerTest(provider any) Fixture {
+	return newFixture("PerTest", provider, false)
+}
+
+// PerSuite returns a [Fixture] whose provider is called once, just
+// after [Setup] method. provider has the same signatures as for
+// [PerTest], but t is the [*td.T] of the whole suite, so t.Cleanup()
+// can be used to automatically release the provided value once the
+// suite ends. If provider returns a non-nil error, the tests suite
+// aborts: no tests are run.
+func PerSuite(provider any) Fixture {
+	return newFixture("PerSuite", provider, true)
+}
+
+func newFixture(name string, provider any, perSuite bool) Fixture {
+	f := Fixture{
+		provider: reflect.ValueOf(provider),
+		perSuite: perSuite,
+	}
+
+	if pt := reflect.TypeOf(provider); provider == nil ||
+		pt.Kind() != reflect.Func ||
+		pt.NumIn() != 1 || pt.In(0) != tType ||
+		pt.NumOut() == 0 || pt.NumOut() > 2 ||
+		(pt.NumOut() == 2 && pt.Out(1) != types.Error) {
+		f.err = fmt.Errorf("%s(): provider must be func(*td.T) X or func(*td.T) (X, error), not %T",
+			name, provider)
+		return f
+	}
+
+	f.typ = f.provider.Type().Out(0)
+	if f.typ == tType || f.typ == bType || f.typ == types.Error {
+		f.err = fmt.Errorf("%s(): provider cannot provide %s", name, f.typ)
+	}
+	return f
+}
+
+// provide calls the provider of f and returns the provided value.
+func (f *Fixture) provide(t *td.T) (reflect.Value, error) {
+	ret := f.provider.Call([]reflect.Value{reflect.ValueOf(t)})
+	if len(ret) == 2 {
+		if err, _ := ret[1].Interface().(error); err != nil { // nil error fails conversion
+			return reflect.Value{}, err
+		}
+	}
+	return ret[0], nil
+}
+
+// fixturesProviders returns fixtures indexed by their provided type.
+func fixturesProviders(fixtures []Fixture) (map[reflect.Type]*Fixture, error) {
+	providers := make(map[reflect.Type]*Fixture, len(fixtures))
+	for i := range fixtures {
+		f := &fixtures[i]
+		if f.err != nil {
+			return nil, f.err
+		}
+		if !f.provider.IsValid() {
+			return nil, errors.New("fixture must be created using PerTest() or PerSuite()")
+		}
+		if providers[f.typ] != nil {
+			return nil, fmt.Errorf("%s fixture provided more than once", f.typ)
+		}
+		f.index = i
+		providers[f.typ] = f
+	}
+	return providers, nil
+}
+
+// perSuiteFixtures returns the per-suite fixtures of fixtures, in
+// the order they have been returned by Fixtures method.
+func perSuiteFixtures(fixtures map[reflect.Type]*Fixture) []*Fixture {
+	var perSuite []*Fixture
+	for _, f := range fixtures {
+		if f.perSuite {
+			perSuite = append(perSuite, f)
+		}
+	}
+	sort.Slice(perSuite, func(i, j int) bool {
+		return perSuite[i].index < perSuite[j].index
+	})
+	return perSuite
+}
@@ -8,17 +8,32 @@ package tdsuite
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
 	"github.com/maxatome/go-testdeep/internal/types"
 	"github.com/maxatome/go-testdeep/td"
 )
 
-var tType = reflect.TypeOf((*td.T)(nil))
+var (
+	tType = reflect.TypeOf((*td.T)(nil))
+	bType = reflect.TypeOf((*testing.B)(nil))
+)
+
+// casesPrefix is the prefix of data-provider methods.
+const casesPrefix = "Cases"
+
+// testMethod is a test method of a suite.
+type testMethod struct {
+	index int // index of the method
+	cases int // index of the data-provider method, -1 if none
+}
 
 // Setup is an interface a tests suite can implement. When running the
 // tests suite, Setup method is called once before any test runs. If
@@ -58,6 +73,26 @@ type BetweenTests interface {
 	BetweenTests(t *td.T, previousTestName, nextTestName string) error
 }
 
+// Parallel is an interface a tests suite can implement. Parallel
+// method is called for each test before it is run. If it returns
+// true, the test is marked as runnable in parallel with the other
+// parallel tests of the suite, using [td.T.Parallel].
+//
+// As for any parallel subtest, parallel tests are run once all
+// sequential tests of the suite ran. [PreTest] and [PostTest] hooks are
+// still called, in the same subtest as the test itself. As parallel
+// tests are run concurrently, these hooks as well as the tests
+// themselves have to be thread-safe.
+//
+// [BetweenTests] method is never called before or after a parallel
+// test, and a parallel test cannot discontinue the suite.
+//
+// If at least one test is run in parallel, [Destroy] method is called
+// using t.Cleanup(), so after all parallel tests ran.
+type Parallel interface {
+	Parallel(testName string) bool
+}
+
 // Destroy is an interface a tests suite can implement. When running
 // the tests suite, Destroy method is called once after all tests
 // ran. If [Setup] interface is implemented and [Setup] method returned an
@@ -66,19 +101,182 @@ type Destroy interface {
 	Destroy(t *td.T) error
 }
 
+// Timeout is an interface a tests suite can implement. Timeout method
+// is called before each test is run. If it returns a positive
+// duration, the test method is run using [td.T.WithTimeout], so
+// t.Context() returns a context cancelled when the timeout
+// elapses. If the test method does not return in time, the test
+// fails, reporting where the test method is still running, and the
+// suite continues with the next test. [PreTest] and [PostTest] hooks
+// are not subject to this timeout.
+//
+// As go does not allow to stop a goroutine, a test method should
+// honor its context, so it stops as soon as possible after the
+// timeout.
+type Timeout interface {
+	Timeout(testName string) time.Duration
+}
+
+// Retries is an interface a tests suite can implement. Retries method
+// is called before each test is run. If it returns a positive number,
+// the test method is run using [td.T.Retry], so it is called again
+// if it fails, up to this number of times. Failures are only
+// reported if all attempts fail. [PreTest] and [PostTest] hooks are
+// called only once, whatever the number of attempts is, while
+// [Timeout] applies to each attempt.
+//
+// It is intended for tests depending on external timings, so they can
+// be retried visibly instead of being skipped.
+type Retries interface {
+	Retries(testName string) int
+}
+
+// Tags is an interface a tests suite can implement. Tags method
+// returns the tags of the suite tests, indexed by test name. It is
+// called once, after [Setup] method.
+//
+// These tags are used to select the tests to run, thanks to the
+// TDSUITE_TAGS environment variable. It contains a comma separated
+// list of tags. A tag prefixed by "!" excludes the tests having
+// this tag. Other tags are required: if at least one is listed, only
+// the tests having at least one of them are run. For example:
+//
+//	TDSUITE_TAGS=db,!slow go test ./...
+//
+// runs only tests tagged "db" but not tagged "slow". Tests not
+// selected are skipped using t.Skip(), with the reason, before
+// [PreTest] method is called. As they are not run, [BetweenTests]
+// method ignores them and is called with the tests around them. If
+// TDSUITE_TAGS is empty or not set, all tests are run.
+//
+//	func (s *MySuite) Tags() map[string][]string {
+//	  return map[string][]string{
+//	    "TestInsert":    {"db"},
+//	    "TestBigReplay": {"db", "slow"},
+//	  }
+//	}
+type Tags interface {
+	Tags() map[string][]string
+}
+
+// tagsEnv is the environment variable used to select tests by tags.
+const tagsEnv = "TDSUITE_TAGS"
+
+// tagsFilter selects tests based on their tags. See [Tags].
+type tagsFilter struct {
+	raw      string
+	required []string
+	excluded []string
+}
+
+func newTagsFilter(raw string) (f tagsFilter) {
+	f.raw = raw
+	for _, tag := range strings.Split(raw, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "!") {
+			if tag = strings.TrimSpace(tag[1:]); tag != "" {
+				f.excluded = append(f.excluded, tag)
+			}
+		} else if tag != "" {
+			f.required = append(f.required, tag)
+		}
+	}
+	return
+}
+
+// skipReason returns the reason why a test tagged with tags has to be
+// skipped, or "" if the test has to be run.
+func (f tagsFilter) skipReason(tags []string) string {
+	has := func(tag string) bool {
+		for _, t := range tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, tag := range f.excluded {
+		if has(tag) {
+			return fmt.Sprintf("tag %q excluded by %s=%s", tag, tagsEnv, f.raw)
+		}
+	}
+
+	if len(f.required) == 0 {
+		return ""
+	}
+	for _, tag := range f.required {
+		if has(tag) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("none of tags %q required by %s=%s",
+		f.required, tagsEnv, f.raw)
+}
+
+// SubSuite is an interface a tests suite can implement to be run as
+// a sub-suite of another tests suite. Each exported field of a suite
+// whose type implements SubSuite (directly or through a pointer) is
+// run as a nested tests suite, in a subtest named after the field.
+//
+// Sub-suites are run in fields order, once all the tests of the
+// parent suite ran, but before its [Destroy] method is called. So the
+// parent [Setup] method can initialize sub-suites fields, sharing
+// its own fixtures. A nil sub-suite field is ignored. If a test of
+// the parent suite discontinued the suite, sub-suites are not run.
+//
+// Each sub-suite has its own hooks and tests, called as if the
+// sub-suite was run using [Run].
+//
+//	type Users struct{ API *API }
+//
+//	func (u *Users) SubSuite() {}
+//
+//	func (u *Users) TestList(t *td.T) { ... }
+//
+//	type API struct {
+//	  Server *httptest.Server
+//	  Users  *Users
+//	}
+//
+//	func (a *API) Setup(t *td.T) error {
+//	  a.Server = httptest.NewServer(handler)
+//	  a.Users = &Users{API: a}
+//	  return nil
+//	}
+type SubSuite interface {
+	SubSuite()
+}
+
+var subSuiteType = reflect.TypeOf((*SubSuite)(nil)).Elem()
+
 func emptyPrePostTest(t *td.T, testName string) error    { return nil }
 func emptyBetweenTests(t *td.T, prev, next string) error { return nil }
+func emptyParallel(testName string) bool                 { return false }
+func emptyTimeout(testName string) time.Duration         { return 0 }
+func emptyRetries(testName string) int                   { return 0 }
 
 // isTest returns true if "name" is a valid test name.
-// Derived from go sources in cmd/go/internal/load/test.go.
 func isTest(name string) bool {
-	if !strings.HasPrefix(name, "Test") {
+	return isTestFunc(name, "Test")
+}
+
+// isBenchmark returns true if "name" is a valid benchmark name.
+func isBenchmark(name string) bool {
+	return isTestFunc(name, "Benchmark")
+}
+
+// isTestFunc returns true if "name" is prefix followed by nothing or
+// by a non-lower case rune.
+// Derived from go sources in cmd/go/internal/load/test.go.
+func isTestFunc(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
 		return false
 	}
-	if len(name) == 4 { // "Test" is ok
+	if len(name) == len(prefix) { // "Test" or "Benchmark" is ok
 		return true
 	}
-	r, _ := utf8.DecodeRuneInString(name[4:])
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
 	return !unicode.IsLower(r)
 }
 
@@ -135,7 +333,13 @@ func shouldContinue(t *td.T, testName string, ret []reflect.Value) bool {
 //	  })
 //	}
 //
-// Run returns true if all the tests succeeded, false otherwise.
+// If tb is a [*testing.B] (or a [*td.T] encapsulating it), only the
+// benchmark methods of suite are run, see "Benchmarks" section in
+// package documentation.
+//
+// Run returns true if all the tests succeeded, false otherwise. Note
+// that as parallel tests (see [Parallel]) are run after Run returns,
+// their results are not taken into account.
 //
 // Note that if suite is not an empty struct, it should be a pointer
 // if its contents has to be altered by hooks & tests methods.
@@ -162,12 +366,29 @@ func Run(tb testing.TB, suite any, config ...td.ContextConfig) bool {
 			suite, strings.Join(possibleMistakes, ", "))
 	}
 
-	var methods []int
+	var fixtures map[reflect.Type]*Fixture
+	if s, ok := suite.(Fixtures); ok {
+		var err error
+		fixtures, err = fixturesProviders(s.Fixtures())
+		if err != nil {
+			t.Fatalf("Run(): %T suite fixtures: %s", suite, err)
+			return false // only for tests
+		}
+	} else if _, exists := typ.MethodByName("Fixtures"); exists {
+		t.Errorf("%T suite has a Fixtures method but it does not match Fixtures() []tdsuite.Fixture", suite)
+	}
+
+	// Only benchmark methods are run when tb is a *testing.B
+	_, bench := t.TB.(*testing.B)
+
+	var methods []testMethod
 	for i, num := 0, typ.NumMethod(); i < num; i++ {
 		m := typ.Method(i)
 
-		if isTest(m.Name) {
+		isBench := bench && isBenchmark(m.Name)
+		if isBench || (!bench && isTest(m.Name)) {
 			mt := m.Type
+			cases := -1
 
 			if mt.IsVariadic() {
 				t.Logf("Run(): method %T.%s skipped, variadic parameters not supported",
@@ -175,43 +396,83 @@ func Run(tb testing.TB, suite any, config ...td.ContextConfig) bool {
 				continue
 			}
 
-			// Check input parameters
-			switch mt.NumIn() {
-			case 2:
-				// TestXxx(*td.T)
-				if mt.In(1) != tType {
-					t.Logf("Run(): method %T.%s skipped, unrecognized parameter type %s. Only *td.T allowed",
-						suite, m.Name, mt.In(1))
+			if isBench {
+				// Check input parameters, except the ones provided by fixtures
+				if in := inputTypes(mt, fixtures, false); len(in) != 3 || in[1] != tType || in[2] != bType {
+					t.Logf("Run(): method %T.%s skipped, only (*td.T, *testing.B) parameters allowed",
+						suite, m.Name)
 					continue
 				}
+			} else {
+				// Check input parameters, except the ones provided by fixtures
+				_, hasCases := typ.MethodByName(casesPrefix + m.Name[len("Test"):])
+				in := inputTypes(mt, fixtures, hasCases)
+				switch len(in) {
+				case 2:
+					// TestXxx(*td.T)
+					if in[1] != tType {
+						t.Logf("Run(): method %T.%s skipped, unrecognized parameter type %s. Only *td.T allowed",
+							suite, m.Name, in[1])
+						continue
+					}
+
+				case 3:
+					// TestXxx(*td.T, Case) + CasesXxx()
+					if in[1] == tType && in[2] != tType {
+						var err error
+						cases, err = casesMethod(typ, m.Name, in[2])
+						if err != nil {
+							t.Fatalf("Run(): method %T.%s %s", suite, m.Name, err)
+							return false // only for tests
+						}
+						if cases >= 0 {
+							break
+						}
+					}
 
-			case 3:
-				// TestXxx(*td.T, *td.T)
-				if mt.In(1) != tType || mt.In(2) != tType {
-					var log string
-					if mt.In(1) != tType {
-						if mt.In(2) != tType {
-							log = fmt.Sprintf("parameters types (%s, %s)", mt.In(1), mt.In(2))
+					// TestXxx(*td.T, *td.T)
+					if in[1] != tType || in[2] != tType {
+						var log string
+						if in[1] != tType {
+							if in[2] != tType {
+								log = fmt.Sprintf("parameters types (%s, %s)", in[1], in[2])
+							} else {
+								log = fmt.Sprintf("first parameter type %s", in[1])
+							}
 						} else {
-							log = fmt.Sprintf("first parameter type %s", mt.In(1))
+							log = fmt.Sprintf("second parameter type %s", in[2])
 						}
-					} else {
-						log = fmt.Sprintf("second parameter type %s", mt.In(2))
+						t.Logf("Run(): method %T.%s skipped, unrecognized %s. Only (*td.T, *td.T) allowed",
+							suite, m.Name, log)
+						continue
 					}
-					t.Logf("Run(): method %T.%s skipped, unrecognized %s. Only (*td.T, *td.T) allowed",
-						suite, m.Name, log)
-					continue
-				}
 
-			case 1:
-				t.Logf("Run(): method %T.%s skipped, no input parameters",
-					suite, m.Name)
-				continue
+				case 4:
+					// TestXxx(*td.T, *td.T, Case) + CasesXxx()
+					if in[1] == tType && in[2] == tType && in[3] != tType {
+						var err error
+						cases, err = casesMethod(typ, m.Name, in[3])
+						if err != nil {
+							t.Fatalf("Run(): method %T.%s %s", suite, m.Name, err)
+							return false // only for tests
+						}
+					}
+					if cases < 0 {
+						t.Logf("Run(): method %T.%s skipped, too many parameters",
+							suite, m.Name)
+						continue
+					}
 
-			default:
-				t.Logf("Run(): method %T.%s skipped, too many parameters",
-					suite, m.Name)
-				continue
+				case 1:
+					t.Logf("Run(): method %T.%s skipped, no input parameters",
+						suite, m.Name)
+					continue
+
+				default:
+					t.Logf("Run(): method %T.%s skipped, too many parameters",
+						suite, m.Name)
+					continue
+				}
 			}
 
 			// Check output parameters
@@ -237,21 +498,27 @@ func Run(tb testing.TB, suite any, config ...td.ContextConfig) bool {
 				return false // only for tests
 			}
 
-			methods = append(methods, i)
+			methods = append(methods, testMethod{index: i, cases: cases})
 		}
 	}
 
-	if len(methods) == 0 {
-		t.Fatalf("Run(): no test methods found for type %T", suite)
+	subSuites := subSuitesFields(typ)
+
+	if len(methods) == 0 && len(subSuites) == 0 {
+		if bench {
+			t.Fatalf("Run(): no benchmark methods found for type %T", suite)
+		} else {
+			t.Fatalf("Run(): no test methods found for type %T", suite)
+		}
 		return false // only for tests
 	}
 
-	run(t, suite, methods)
+	run(t, suite, methods, subSuites, fixtures)
 
 	return !t.Failed()
 }
 
-func run(t *td.T, suite any, methods []int) {
+func run(t *td.T, suite any, methods []testMethod, subSuites []int, fixtures map[reflect.Type]*Fixture) {
 	t.Helper()
 
 	suiteType := reflect.TypeOf(suite)
@@ -266,13 +533,46 @@ func run(t *td.T, suite any, methods []int) {
 		t.Errorf("%T suite has a Setup method but it does not match Setup(t *td.T) error", suite)
 	}
 
+	// per-suite fixtures
+	provided := map[reflect.Type]reflect.Value{}
+	for _, f := range perSuiteFixtures(fixtures) {
+		v, err := f.provide(t)
+		if err != nil {
+			t.Errorf("%T suite fixture %s error: %s", suite, f.typ, err)
+			return
+		}
+		provided[f.typ] = v
+	}
+
+	parallel := emptyParallel
+	if s, ok := suite.(Parallel); ok {
+		parallel = s.Parallel
+	} else if _, exists := suiteType.MethodByName("Parallel"); exists {
+		t.Errorf("%T suite has a Parallel method but it does not match Parallel(testName string) bool", suite)
+	}
+
+	typ := reflect.TypeOf(suite)
+
+	parallels := make([]bool, len(methods))
+	anyParallel := false
+	for i, method := range methods {
+		parallels[i] = parallel(typ.Method(method.index).Name)
+		anyParallel = anyParallel || parallels[i]
+	}
+
 	// destroy
 	if s, ok := suite.(Destroy); ok {
-		defer func() {
+		destroy := func() {
 			if err := s.Destroy(t); err != nil {
 				t.Errorf("%T suite destroy error: %s", suite, err)
 			}
-		}()
+		}
+		// Parallel tests are run after this function returns
+		if anyParallel {
+			t.Cleanup(destroy)
+		} else {
+			defer destroy()
+		}
 	} else if _, exists := suiteType.MethodByName("Destroy"); exists {
 		t.Errorf("%T suite has a Destroy method but it does not match Destroy(t *td.T) error", suite)
 	}
@@ -298,64 +598,309 @@ func run(t *td.T, suite any, methods []int) {
 		t.Errorf("%T suite has a BetweenTests method but it does not match BetweenTests(t *td.T, previousTestName, nextTestName string) error", suite)
 	}
 
+	timeout := emptyTimeout
+	if s, ok := suite.(Timeout); ok {
+		timeout = s.Timeout
+	} else if _, exists := suiteType.MethodByName("Timeout"); exists {
+		t.Errorf("%T suite has a Timeout method but it does not match Timeout(testName string) time.Duration", suite)
+	}
+
+	retries := emptyRetries
+	if s, ok := suite.(Retries); ok {
+		retries = s.Retries
+	} else if _, exists := suiteType.MethodByName("Retries"); exists {
+		t.Errorf("%T suite has a Retries method but it does not match Retries(testName string) int", suite)
+	}
+
+	var tags map[string][]string
+	if s, ok := suite.(Tags); ok {
+		tags = s.Tags()
+	} else if _, exists := suiteType.MethodByName("Tags"); exists {
+		t.Errorf("%T suite has a Tags method but it does not match Tags() map[string][]string", suite)
+	}
+	filter := newTagsFilter(os.Getenv(tagsEnv))
+
 	vs := reflect.ValueOf(suite)
-	typ := reflect.TypeOf(suite)
+
+	// prevName is the name of the previous sequential test run, empty
+	// if none or if the previous one was run in parallel
+	var prevName string
 
 	for i, method := range methods {
-		m := typ.Method(method)
+		m := typ.Method(method.index)
 		mt := m.Type
 
-		call := vs.Method(method).Call
+		call := vs.Method(method.index).Call
+		isParallel := parallels[i]
+		numT := 0
+		for k := 1; k < mt.NumIn(); k++ {
+			if mt.In(k) == tType {
+				numT++
+			}
+		}
+		twoT := numT == 2
+
+		// per-test fixtures needed by this test method
+		var perTest []reflect.Type
+		for k := 2; k < mt.NumIn(); k++ {
+			if f := fixtures[mt.In(k)]; f != nil && !f.perSuite &&
+				!(method.cases >= 0 && k == mt.NumIn()-1) {
+				perTest = append(perTest, mt.In(k))
+			}
+		}
 
-		cont := true
-		if mt.NumIn() == 2 {
-			t.Run(m.Name, func(t *td.T) {
-				if err := preTest(t, m.Name); err != nil {
-					t.Errorf("%s pre-test error: %s", m.Name, err)
-					return
+		// subtest runs fn in the subtest name of t
+		subtest := func(t *td.T, name string, fn func(assert, require *td.T)) {
+			if twoT {
+				t.RunAssertRequire(name, fn)
+			} else {
+				t.Run(name, func(t *td.T) { fn(t, t) })
+			}
+		}
+
+		// body runs the test with the optional data case and returns
+		// false if the suite should be discontinued
+		body := func(assert, require *td.T, dataCase ...reflect.Value) bool {
+			if err := preTest(assert, m.Name); err != nil {
+				assert.Errorf("%s pre-test error: %s", m.Name, err)
+				return true
+			}
+			defer func() {
+				if err := postTest(assert, m.Name); err != nil {
+					assert.Errorf("%s post-test error: %s", m.Name, err)
+				}
+			}()
+
+			values := make(map[reflect.Type]reflect.Value, len(perTest))
+			for _, ft := range perTest {
+				v, err := fixtures[ft].provide(assert)
+				if err != nil {
+					assert.Errorf("%s fixture %s error: %s", m.Name, ft, err)
+					return true
 				}
-				defer func() {
-					if err := postTest(t, m.Name); err != nil {
-						t.Errorf("%s post-test error: %s", m.Name, err)
+				values[ft] = v
+			}
+
+			// benchmark timer is reset just before calling the method
+			b, _ := assert.TB.(*testing.B)
+
+			callTest := func(assert, require *td.T) bool {
+				in := make([]reflect.Value, 0, mt.NumIn()-1)
+				ts := []*td.T{assert, require}
+				for k := 1; k < mt.NumIn(); k++ {
+					pt := mt.In(k)
+					switch {
+					case pt == tType && len(ts) > 0:
+						in = append(in, reflect.ValueOf(ts[0]))
+						ts = ts[1:]
+					case pt == bType:
+						in = append(in, reflect.ValueOf(b))
+					case len(dataCase) > 0 && k == mt.NumIn()-1:
+						in = append(in, dataCase[0])
+					case values[pt].IsValid():
+						in = append(in, values[pt])
+					default:
+						in = append(in, provided[pt])
 					}
-				}()
+				}
+				return shouldContinue(assert, m.Name, call(in))
+			}
 
-				cont = shouldContinue(t, m.Name, call([]reflect.Value{reflect.ValueOf(t)}))
-			})
-		} else {
-			t.RunAssertRequire(m.Name, func(assert, require *td.T) {
-				if err := preTest(assert, m.Name); err != nil {
-					assert.Errorf("%s pre-test error: %s", m.Name, err)
-					return
+			timedTest := func(assert, require *td.T) bool {
+				d := timeout(m.Name)
+				if d <= 0 {
+					return callTest(assert, require)
 				}
-				defer func() {
-					if err := postTest(assert, m.Name); err != nil {
-						assert.Errorf("%s post-test error: %s", m.Name, err)
-					}
-				}()
 
-				cont = shouldContinue(assert, m.Name, call([]reflect.Value{
-					reflect.ValueOf(assert),
-					reflect.ValueOf(require),
-				}))
-			})
+				c := true
+				if assert.WithTimeout(d, func(t *td.T) { c = callTest(t, t.Require()) }) {
+					return c
+				}
+				return true
+			}
+
+			if b != nil {
+				b.ResetTimer()
+				defer b.StopTimer() // before PostTest
+			}
+
+			n := retries(m.Name)
+			if n <= 0 {
+				return timedTest(assert, require)
+			}
+
+			c := true
+			assert.Retry(n, func(t *td.T) { c = timedTest(t, t.Require()) })
+			return c
 		}
 
+		cont := true
+		skipReason := filter.skipReason(tags[m.Name])
+
+		// BetweenTests is only called between 2 sequential tests really run
+		if skipReason == "" && !isParallel && prevName != "" {
+			if err := between(t, prevName, m.Name); err != nil {
+				t.Errorf("%s / %s between-tests error: %s", prevName, m.Name, err)
+				return
+			}
+		}
+
+		subtest(t, m.Name, func(assert, require *td.T) {
+			if skipReason != "" {
+				assert.Skip(skipReason)
+				return
+			}
+			if isParallel {
+				assert.Parallel()
+			}
+
+			c := true
+			if method.cases < 0 {
+				c = body(assert, require)
+			} else {
+				eachCase(vs.Method(method.cases).Call(nil)[0],
+					func(name string, dataCase reflect.Value) bool {
+						subtest(assert, name, func(assert, require *td.T) {
+							c = body(assert, require, dataCase)
+						})
+						return c
+					})
+			}
+
+			if !isParallel {
+				cont = c
+			} else if !c {
+				assert.Logf("%s required discontinuing suite tests, ignored as run in parallel", m.Name)
+			}
+		})
+
 		if !cont {
 			t.Logf("%s required discontinuing suite tests", m.Name)
-			break
+			return
+		}
+
+		switch {
+		case skipReason != "": // skipped, so keep the previous test name
+		case isParallel:
+			prevName = ""
+		default:
+			prevName = m.Name
 		}
+	}
 
-		if i != len(methods)-1 {
-			next := typ.Method(methods[i+1]).Name
-			if err := between(t, m.Name, next); err != nil {
-				t.Errorf("%s / %s between-tests error: %s", m.Name, next, err)
-				break
+	if len(subSuites) > 0 {
+		if vs.Kind() == reflect.Ptr {
+			vs = vs.Elem()
+		}
+		for _, field := range subSuites {
+			if sub := subSuite(vs.Field(field)); sub != nil {
+				t.Run(vs.Type().Field(field).Name, func(t *td.T) {
+					t.Helper()
+					Run(t, sub)
+				})
 			}
 		}
 	}
 }
 
+// subSuitesFields returns the indexes of the exported fields of typ
+// being sub-suites. See [SubSuite].
+func subSuitesFields(typ reflect.Type) []int {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []int
+	for i, num := 0, typ.NumField(); i < num; i++ {
+		f := typ.Field(i)
+		if f.PkgPath == "" && !f.Anonymous &&
+			(f.Type.Implements(subSuiteType) ||
+				reflect.PtrTo(f.Type).Implements(subSuiteType)) {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+// subSuite returns the sub-suite contained in field f, or nil if f is
+// nil.
+func subSuite(f reflect.Value) any {
+	switch f.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if f.IsNil() {
+			return nil
+		}
+	}
+	if !f.Type().Implements(subSuiteType) && f.CanAddr() {
+		return f.Addr().Interface()
+	}
+	return f.Interface()
+}
+
+// inputTypes returns the input parameters types of test method type
+// mt, except the ones provided by fixtures. If hasCases is true, the
+// last parameter is reserved to the data case, so it is always
+// returned.
+func inputTypes(mt reflect.Type, fixtures map[reflect.Type]*Fixture, hasCases bool) []reflect.Type {
+	num := mt.NumIn()
+	in := make([]reflect.Type, 0, num)
+	for k := 0; k < num; k++ {
+		pt := mt.In(k)
+		// k == 0 is the receiver, k == 1 is always the first *td.T
+		if k >= 2 && pt != tType && fixtures[pt] != nil && !(hasCases && k == num-1) {
+			continue
+		}
+		in = append(in, pt)
+	}
+	return in
+}
+
+// casesMethod returns the index of the data-provider method of test
+// method testName, -1 if none. An error is returned if the
+// data-provider method exists but does not return cases assignable
+// to caseType.
+func casesMethod(typ reflect.Type, testName string, caseType reflect.Type) (int, error) {
+	name := casesPrefix + testName[len("Test"):]
+	m, ok := typ.MethodByName(name)
+	if !ok {
+		return -1, nil
+	}
+
+	if mt := m.Type; mt.NumIn() == 1 && mt.NumOut() == 1 {
+		switch out := mt.Out(0); out.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if out.Elem().AssignableTo(caseType) {
+				return m.Index, nil
+			}
+		}
+	}
+	return -1, fmt.Errorf("data-provider %s does not match %s() []%s or %s() map[K]%s",
+		name, name, caseType, name, caseType)
+}
+
+// eachCase calls fn for each data case of cases, a slice, an array
+// or a map, until fn returns false. Map cases are called in keys
+// order.
+func eachCase(cases reflect.Value, fn func(name string, dataCase reflect.Value) bool) {
+	if cases.Kind() == reflect.Map {
+		for _, k := range tdutil.MapSortedKeys(cases) {
+			if !fn(tdutil.BuildTestName(k.Interface()), cases.MapIndex(k)) {
+				return
+			}
+		}
+		return
+	}
+
+	for i, num := 0, cases.Len(); i < num; i++ {
+		if !fn(tdutil.BuildTestName("#%d", i), cases.Index(i)) {
+			return
+		}
+	}
+}
+
 func diffWithPtrMethods(typ reflect.Type) []string {
 	if typ.Kind() == reflect.Ptr {
 		return nil
@@ -368,10 +913,11 @@ func diffWithPtrMethods(typ reflect.Type) []string {
 
 	keep := func(m reflect.Method) bool {
 		switch m.Name {
-		case "Setup", "PreTest", "PostTest", "BetweenTests", "Destroy":
+		case "Setup", "PreTest", "PostTest", "BetweenTests", "Parallel", "Timeout", "Retries", "Tags", "Fixtures", "Destroy":
 			return true
 		default:
-			return isTest(m.Name)
+			return isTest(m.Name) || isBenchmark(m.Name) ||
+				strings.HasPrefix(m.Name, casesPrefix)
 		}
 	}
 
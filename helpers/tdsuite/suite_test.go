@@ -8,9 +8,13 @@ package tdsuite_test
 
 import (
 	"errors"
+	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/maxatome/go-testdeep/helpers/tdsuite"
 	"github.com/maxatome/go-testdeep/internal/test"
@@ -21,6 +25,8 @@ type base struct {
 	calls []string
 }
 
+var recMu sync.Mutex // for parallel tests
+
 func (b *base) rec(plus ...string) {
 	pc, _, _, _ := runtime.Caller(1)
 	name := runtime.FuncForPC(pc).Name()
@@ -34,6 +40,8 @@ func (b *base) rec(plus ...string) {
 	if len(plus) > 0 {
 		name += "+" + strings.Join(plus, "+")
 	}
+	recMu.Lock()
+	defer recMu.Unlock()
 	b.calls = append(b.calls, name)
 }
 
@@ -80,7 +88,12 @@ func (*FullBrokenHooks) Setup() error                                   { return
 func (*FullBrokenHooks) PreTest(t *td.T, testName *string) error        { return nil }
 func (*FullBrokenHooks) PostTest(t *td.T, testName string)              {}
 func (*FullBrokenHooks) BetweenTests(t *td.T, prev, next *string) error { return nil }
+func (*FullBrokenHooks) Parallel() bool                                 { return true }
+func (*FullBrokenHooks) Timeout() int                                   { return 0 }
+func (*FullBrokenHooks) Retries() uint                                  { return 0 }
+func (*FullBrokenHooks) Tags() []string                                 { return nil }
 func (*FullBrokenHooks) Destroy(t *td.T)                                {}
+func (*FullBrokenHooks) Fixtures() []any                                { return nil }
 
 func (*FullBrokenHooks) Test1(_ *td.T) {}
 
@@ -552,11 +565,16 @@ func TestRunErrors(t *testing.T) {
 		td.CmpFalse(t, tb.IsFatal)
 		name := "*tdsuite_test.FullBrokenHooks"
 		td.Cmp(t, tb.Messages, []string{
+			name + " suite has a Fixtures method but it does not match Fixtures() []tdsuite.Fixture",
 			name + " suite has a Setup method but it does not match Setup(t *td.T) error",
+			name + " suite has a Parallel method but it does not match Parallel(testName string) bool",
 			name + " suite has a Destroy method but it does not match Destroy(t *td.T) error",
 			name + " suite has a PreTest method but it does not match PreTest(t *td.T, testName string) error",
 			name + " suite has a PostTest method but it does not match PostTest(t *td.T, testName string) error",
 			name + " suite has a BetweenTests method but it does not match BetweenTests(t *td.T, previousTestName, nextTestName string) error",
+			name + " suite has a Timeout method but it does not match Timeout(testName string) time.Duration",
+			name + " suite has a Retries method but it does not match Retries(testName string) int",
+			name + " suite has a Tags method but it does not match Tags() map[string][]string",
 			"++++ Test1",
 		})
 	})
@@ -824,3 +842,780 @@ func TestRunCleanup(t *testing.T) {
 		}
 	})
 }
+
+// FullParallel has tests and all possible hooks, Test2 & Test3 being
+// run in parallel, or only Test3 if onlyTest3 is true.
+type FullParallel struct {
+	base
+	onlyTest3 bool
+}
+
+func (f *FullParallel) Setup(t *td.T) error               { f.rec(); return nil }
+func (f *FullParallel) PreTest(t *td.T, tn string) error  { f.rec(tn); return nil }
+func (f *FullParallel) PostTest(t *td.T, tn string) error { f.rec(tn); return nil }
+func (f *FullParallel) BetweenTests(t *td.T, prev, next string) error {
+	f.rec(prev, next)
+	return nil
+}
+func (f *FullParallel) Parallel(tn string) bool {
+	if f.onlyTest3 {
+		return tn == "Test3"
+	}
+	return tn != "Test1"
+}
+func (f *FullParallel) Destroy(t *td.T) error { f.rec(); return nil }
+
+func (f *FullParallel) Test1(t *td.T)                     { f.rec() }
+func (f *FullParallel) Test2(assert *td.T, require *td.T) { f.rec() }
+func (f *FullParallel) Test3(t *td.T) bool                { f.rec(); return false }
+
+var _ tdsuite.Parallel = (*FullParallel)(nil)
+
+func TestRunParallel(t *testing.T) {
+	suite := FullParallel{}
+	t.Run("Full", func(t *testing.T) {
+		td.CmpTrue(t, tdsuite.Run(t, &suite))
+		// Parallel tests not run yet
+		td.Cmp(t, suite.calls, []string{
+			"Setup",
+			/**/ "PreTest+Test1",
+			/**/ "Test1",
+			/**/ "PostTest+Test1",
+		})
+	})
+
+	// Now parallel tests ran, and then Destroy
+	calls := suite.calls
+	if !td.Cmp(t, calls, td.Len(11)) {
+		t.Log(calls)
+		return
+	}
+	td.Cmp(t, calls[:4], []string{
+		"Setup",
+		/**/ "PreTest+Test1",
+		/**/ "Test1",
+		/**/ "PostTest+Test1",
+	})
+	td.Cmp(t, calls[4:10], td.Bag(
+		/**/ "PreTest+Test2",
+		/**/ "Test2",
+		/**/ "PostTest+Test2",
+		/**/ "PreTest+Test3",
+		/**/ "Test3",
+		/**/ "PostTest+Test3",
+	))
+	td.Cmp(t, calls[10], "Destroy")
+
+	t.Run("Parallel() called", func(t *testing.T) {
+		// ParallelTestingTB does not handle subtests, so only one
+		// parallel test is allowed
+		tb := test.NewParallelTestingTB("TestParallel")
+		suite := FullParallel{onlyTest3: true}
+		td.CmpTrue(t, tdsuite.Run(tb, &suite))
+		td.CmpTrue(t, tb.IsParallel)
+		td.CmpFalse(t, tb.IsFatal)
+		td.Cmp(t, tb.Messages, []string{
+			"++++ Test1",
+			"++++ Test2",
+			"++++ Test3",
+			"Test3 required discontinuing suite tests, ignored as run in parallel",
+		})
+	})
+}
+
+type parseCase struct {
+	In       string
+	Expected int
+}
+
+// DataProvider has data-provider test methods.
+type DataProvider struct{ base }
+
+func (d *DataProvider) PreTest(t *td.T, tn string) error { d.rec(tn); return nil }
+
+func (d *DataProvider) CasesSlice() []parseCase {
+	return []parseCase{{In: "a", Expected: 1}, {In: "b", Expected: 2}}
+}
+
+func (d *DataProvider) TestSlice(t *td.T, tc parseCase) {
+	d.rec(tc.In)
+	t.Cmp(int(tc.In[0]-'a'+1), tc.Expected)
+}
+
+func (d *DataProvider) CasesXMap() map[string]parseCase {
+	return map[string]parseCase{
+		"zzz": {In: "z"},
+		"aaa": {In: "a"},
+	}
+}
+
+func (d *DataProvider) TestXMap(assert, require *td.T, tc parseCase) bool {
+	d.rec(tc.In)
+	return tc.In != "a" // discontinue the suite after "aaa"
+}
+
+func (d *DataProvider) TestZ(t *td.T) { d.rec() }
+
+// DataProviderMissing has a data-provider test method without
+// data-provider.
+type DataProviderMissing struct{}
+
+func (d DataProviderMissing) TestA(t *td.T, tc int)                  {}
+func (d DataProviderMissing) TestB(assert, require *td.T, tc string) {}
+func (d DataProviderMissing) TestOK(t *td.T)                         {}
+
+// DataProviderBadType has a data-provider returning a bad type.
+type DataProviderBadType struct{}
+
+func (d DataProviderBadType) CasesA() []string      { return nil }
+func (d DataProviderBadType) TestA(t *td.T, tc int) {}
+
+// DataProviderBadParams has a data-provider with parameters.
+type DataProviderBadParams struct{}
+
+func (d DataProviderBadParams) CasesA(i int) []int                  { return nil }
+func (d DataProviderBadParams) TestA(assert, require *td.T, tc int) {}
+
+func TestRunDataProvider(t *testing.T) {
+	expected := []string{
+		"PreTest+TestSlice",
+		/**/ "TestSlice+a",
+		"PreTest+TestSlice",
+		/**/ "TestSlice+b",
+		"PreTest+TestXMap",
+		/**/ "TestXMap+a",
+		// "zzz" case & TestZ not run as TestXMap discontinued the suite
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		tb := test.NewTestingTB("TestDataProvider")
+		suite := DataProvider{}
+		td.CmpTrue(t, tdsuite.Run(tb, &suite))
+		td.CmpFalse(t, tb.IsFatal)
+		td.Cmp(t, suite.calls, expected)
+		td.Cmp(t, tb.Messages, []string{
+			"++++ TestSlice",
+			"++++ #0",
+			"++++ #1",
+			"++++ TestXMap",
+			"++++ aaa",
+			"TestXMap required discontinuing suite tests",
+		})
+	})
+
+	t.Run("testing.T", func(t *testing.T) {
+		suite := DataProvider{}
+		td.CmpTrue(t, tdsuite.Run(t, &suite))
+		td.Cmp(t, suite.calls, expected)
+	})
+
+	t.Run("Missing data-provider", func(t *testing.T) {
+		tb := test.NewTestingTB("TestDataProvider")
+		td.CmpTrue(t, tdsuite.Run(tb, DataProviderMissing{}))
+		td.Cmp(t, tb.Messages, []string{
+			"Run(): method tdsuite_test.DataProviderMissing.TestA skipped, unrecognized second parameter type int. Only (*td.T, *td.T) allowed",
+			"Run(): method tdsuite_test.DataProviderMissing.TestB skipped, too many parameters",
+			"++++ TestOK",
+		})
+	})
+
+	t.Run("Bad data-provider", func(t *testing.T) {
+		tb := test.NewTestingTB("TestDataProvider")
+		tb.CatchFatal(func() { tdsuite.Run(tb, DataProviderBadType{}) })
+		td.CmpTrue(t, tb.IsFatal)
+		td.Cmp(t, tb.LastMessage(),
+			"Run(): method tdsuite_test.DataProviderBadType.TestA data-provider CasesA does not match CasesA() []int or CasesA() map[K]int")
+
+		tb = test.NewTestingTB("TestDataProvider")
+		tb.CatchFatal(func() { tdsuite.Run(tb, DataProviderBadParams{}) })
+		td.CmpTrue(t, tb.IsFatal)
+		td.Cmp(t, tb.LastMessage(),
+			"Run(): method tdsuite_test.DataProviderBadParams.TestA data-provider CasesA does not match CasesA() []int or CasesA() map[K]int")
+	})
+}
+
+// SubUsers is a sub-suite.
+type SubUsers struct{ trace *base }
+
+func (s *SubUsers) SubSuite() {}
+
+func (s *SubUsers) Setup(t *td.T) error   { s.trace.rec(); return nil }
+func (s *SubUsers) Destroy(t *td.T) error { s.trace.rec(); return nil }
+func (s *SubUsers) Test1(t *td.T)         { s.trace.rec() }
+
+// SubOrders is a sub-suite without tests, but with a sub-suite.
+type SubOrders struct {
+	Users *SubUsers
+}
+
+func (s SubOrders) SubSuite() {}
+
+// Parent has tests and sub-suites.
+type Parent struct {
+	base
+	Users    *SubUsers
+	Orders   SubOrders
+	Nil      *SubUsers // ignored as nil
+	NotSub   *base     // not a sub-suite
+	private  *SubUsers // not exported
+	Embedded           // anonymous fields are ignored
+	discont  bool
+}
+
+type Embedded struct{}
+
+func (Embedded) SubSuite() {}
+
+func (p *Parent) Setup(t *td.T) error {
+	p.rec()
+	p.Users = &SubUsers{trace: &p.base}
+	p.Orders.Users = &SubUsers{trace: &p.base}
+	p.private = &SubUsers{trace: &p.base} // never run
+	return nil
+}
+func (p *Parent) Destroy(t *td.T) error { p.rec(); return nil }
+func (p *Parent) Test1(t *td.T) bool    { p.rec(); return !p.discont }
+
+func TestRunSubSuites(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		suite := Parent{}
+		td.CmpTrue(t, tdsuite.Run(t, &suite))
+		td.Cmp(t, suite.calls, []string{
+			"Setup",
+			/**/ "Test1",
+			/**/ "Setup", // Users
+			/**/ /**/ "Test1",
+			/**/ "Destroy",
+			/**/ /**/ "Setup", // Orders.Users
+			/**/ /**/ /**/ "Test1",
+			/**/ /**/ "Destroy",
+			"Destroy",
+		})
+	})
+
+	t.Run("Discontinued", func(t *testing.T) {
+		suite := Parent{discont: true}
+		tb := test.NewTestingTB("TestSubSuites")
+		td.CmpTrue(t, tdsuite.Run(tb, &suite))
+		td.Cmp(t, suite.calls, []string{"Setup", "Test1", "Destroy"})
+	})
+
+	t.Run("Messages", func(t *testing.T) {
+		suite := Parent{}
+		tb := test.NewTestingTB("TestSubSuites")
+		td.CmpTrue(t, tdsuite.Run(tb, &suite))
+		td.Cmp(t, tb.Messages, []string{
+			"++++ Test1",
+			"++++ Users",
+			"++++ Test1",
+			"++++ Orders",
+			"++++ Users",
+			"++++ Test1",
+		})
+	})
+
+	t.Run("Not a pointer", func(t *testing.T) {
+		suite := SubOrders{Users: &SubUsers{trace: &base{}}}
+		td.CmpTrue(t, tdsuite.Run(t, suite))
+		td.Cmp(t, suite.Users.trace.calls, []string{"Setup", "Test1", "Destroy"})
+	})
+}
+
+// Tagged has tagged tests.
+type Tagged struct{ base }
+
+func (s *Tagged) Tags() map[string][]string {
+	return map[string][]string{
+		"TestDB":     {"db"},
+		"TestDBSlow": {"db", "slow"},
+		"TestSlow":   {"slow"},
+	}
+}
+
+func (s *Tagged) PreTest(t *td.T, tn string) error { s.rec(tn); return nil }
+func (s *Tagged) BetweenTests(t *td.T, prev, next string) error {
+	s.rec(prev, next)
+	return nil
+}
+
+func (s *Tagged) TestDB(t *td.T)     { s.rec() }
+func (s *Tagged) TestDBSlow(t *td.T) { s.rec() }
+func (s *Tagged) TestNone(t *td.T)   { s.rec() }
+func (s *Tagged) TestSlow(t *td.T)   { s.rec() }
+
+var _ tdsuite.Tags = (*Tagged)(nil)
+
+// skipTB records skip reasons.
+type skipTB struct {
+	*test.TestingTB
+	skips []string
+}
+
+func (s *skipTB) Skip(args ...any) {
+	s.skips = append(s.skips, fmt.Sprint(args...))
+}
+
+func TestRunTags(t *testing.T) {
+	for _, tc := range []struct {
+		tags     string
+		expected []string
+		skips    []string
+	}{
+		{
+			tags: "",
+			expected: []string{
+				"PreTest+TestDB", "TestDB",
+				"BetweenTests+TestDB+TestDBSlow",
+				"PreTest+TestDBSlow", "TestDBSlow",
+				"BetweenTests+TestDBSlow+TestNone",
+				"PreTest+TestNone", "TestNone",
+				"BetweenTests+TestNone+TestSlow",
+				"PreTest+TestSlow", "TestSlow",
+			},
+		},
+		{
+			tags: "db",
+			expected: []string{
+				"PreTest+TestDB", "TestDB",
+				"BetweenTests+TestDB+TestDBSlow",
+				"PreTest+TestDBSlow", "TestDBSlow",
+			},
+			skips: []string{
+				`none of tags ["db"] required by TDSUITE_TAGS=db`,
+				`none of tags ["db"] required by TDSUITE_TAGS=db`,
+			},
+		},
+		{
+			tags: " !slow ",
+			// TestDBSlow, in the middle, is skipped
+			expected: []string{
+				"PreTest+TestDB", "TestDB",
+				"BetweenTests+TestDB+TestNone",
+				"PreTest+TestNone", "TestNone",
+			},
+			skips: []string{
+				`tag "slow" excluded by TDSUITE_TAGS= !slow `,
+				`tag "slow" excluded by TDSUITE_TAGS= !slow `,
+			},
+		},
+		{
+			tags:     "db,!slow,,!",
+			expected: []string{"PreTest+TestDB", "TestDB"},
+			skips: []string{
+				`tag "slow" excluded by TDSUITE_TAGS=db,!slow,,!`,
+				`none of tags ["db"] required by TDSUITE_TAGS=db,!slow,,!`,
+				`tag "slow" excluded by TDSUITE_TAGS=db,!slow,,!`,
+			},
+		},
+		{
+			tags: "slow,none",
+			// TestNone, in the middle, is skipped
+			expected: []string{
+				"PreTest+TestDBSlow", "TestDBSlow",
+				"BetweenTests+TestDBSlow+TestSlow",
+				"PreTest+TestSlow", "TestSlow",
+			},
+			skips: []string{
+				`none of tags ["slow" "none"] required by TDSUITE_TAGS=slow,none`,
+				`none of tags ["slow" "none"] required by TDSUITE_TAGS=slow,none`,
+			},
+		},
+	} {
+		t.Run(tc.tags, func(t *testing.T) {
+			t.Setenv("TDSUITE_TAGS", tc.tags)
+
+			tb := &skipTB{TestingTB: test.NewTestingTB("TestTags")}
+			suite := Tagged{}
+			td.CmpTrue(t, tdsuite.Run(tb, &suite))
+			td.Cmp(t, suite.calls, tc.expected)
+			td.Cmp(t, tb.skips, tc.skips)
+		})
+	}
+
+	t.Run("testing.T", func(t *testing.T) {
+		t.Setenv("TDSUITE_TAGS", "!slow")
+
+		suite := Tagged{}
+		t.Run("suite", func(t *testing.T) {
+			tdsuite.Run(t, &suite)
+		})
+		td.Cmp(t, suite.calls, []string{
+			"PreTest+TestDB", "TestDB",
+			"BetweenTests+TestDB+TestNone",
+			"PreTest+TestNone", "TestNone",
+		})
+	})
+}
+
+// Timeouts has tests with a timeout.
+type Timeouts struct {
+	base
+	unblock  chan struct{}
+	deadline bool
+}
+
+func (s *Timeouts) Timeout(tn string) time.Duration {
+	if tn == "TestFast" {
+		return 0
+	}
+	return 10 * time.Millisecond
+}
+
+func (s *Timeouts) PostTest(t *td.T, tn string) error { s.rec(tn); return nil }
+
+func (s *Timeouts) TestFast(t *td.T) {
+	s.rec()
+	_, s.deadline = t.Context().Deadline()
+}
+
+func (s *Timeouts) TestSlow(assert, require *td.T) {
+	s.rec()
+	<-s.unblock
+}
+
+func (s *Timeouts) TestWithin(assert, require *td.T) bool {
+	_, deadline := require.Context().Deadline()
+	s.rec()
+	return !deadline // discontinue the suite if a deadline is set
+}
+
+func (s *Timeouts) TestZ(t *td.T) { s.rec() }
+
+var _ tdsuite.Timeout = (*Timeouts)(nil)
+
+func TestRunTimeout(t *testing.T) {
+	tb := test.NewTestingTB("TestTimeout")
+	suite := Timeouts{unblock: make(chan struct{})}
+	defer close(suite.unblock)
+
+	td.CmpFalse(t, tdsuite.Run(tb, &suite))
+	td.CmpFalse(t, suite.deadline)
+	td.Cmp(t, suite.calls, []string{
+		"TestFast",
+		"PostTest+TestFast",
+		"TestSlow",
+		"PostTest+TestSlow",
+		"TestWithin",
+		"PostTest+TestWithin",
+	})
+	td.Cmp(t, tb.Messages, td.Slice([]string{}, td.ArrayEntries{
+		0: "++++ TestFast",
+		1: "++++ TestSlow",
+		2: td.Re(`Timeout\S*: fn did not return within 10ms, still running at:\n\t\(\*Timeouts\)\.TestSlow\(\) +helpers/tdsuite/suite_test\.go:\d+\z`),
+		3: "++++ TestWithin",
+		4: "TestWithin required discontinuing suite tests",
+	}))
+}
+
+type (
+	fixtureDB   struct{ name string }
+	fixtureUser struct{ name string }
+)
+
+// Fixtured has tests using fixtures.
+type Fixtured struct {
+	base
+	dbs, users int
+	userErr    error
+}
+
+func (s *Fixtured) Setup(t *td.T) error { s.rec(); return nil }
+
+func (s *Fixtured) Fixtures() []tdsuite.Fixture {
+	return []tdsuite.Fixture{
+		tdsuite.PerSuite(s.newDB),
+		tdsuite.PerTest(s.newUser),
+	}
+}
+
+func (s *Fixtured) newDB(t *td.T) *fixtureDB {
+	s.rec()
+	s.dbs++
+	return &fixtureDB{name: "db"}
+}
+
+func (s *Fixtured) newUser(t *td.T) (*fixtureUser, error) {
+	s.rec()
+	s.users++
+	return &fixtureUser{name: "bob"}, s.userErr
+}
+
+func (s *Fixtured) PreTest(t *td.T, tn string) error { s.rec(tn); return nil }
+
+func (s *Fixtured) TestA(t *td.T, db *fixtureDB) {
+	s.rec(db.name)
+}
+
+func (s *Fixtured) TestB(assert, require *td.T, db *fixtureDB, u *fixtureUser) {
+	s.rec(db.name, u.name)
+}
+
+func (s *Fixtured) CasesC() []string { return []string{"x", "y"} }
+
+func (s *Fixtured) TestC(t *td.T, u *fixtureUser, tc string) {
+	s.rec(u.name, tc)
+}
+
+func (s *Fixtured) TestD(t *td.T) { s.rec() }
+
+var _ tdsuite.Fixtures = (*Fixtured)(nil)
+
+// FixturedDuplicate provides the same type twice.
+type FixturedDuplicate struct{}
+
+func (FixturedDuplicate) Fixtures() []tdsuite.Fixture {
+	provider := func(t *td.T) int { return 0 }
+	return []tdsuite.Fixture{tdsuite.PerTest(provider), tdsuite.PerSuite(provider)}
+}
+
+func (FixturedDuplicate) Test(t *td.T, i int) {}
+
+// FixturedBad returns fixtures set by the field.
+type FixturedBad struct{ fixtures []tdsuite.Fixture }
+
+func (f FixturedBad) Fixtures() []tdsuite.Fixture { return f.fixtures }
+func (FixturedBad) Test(t *td.T)                  {}
+
+// FixturedSuiteErr has a per-suite fixture returning an error.
+type FixturedSuiteErr struct{ base }
+
+func (s *FixturedSuiteErr) Fixtures() []tdsuite.Fixture {
+	return []tdsuite.Fixture{
+		tdsuite.PerSuite(func(t *td.T) (int, error) {
+			return 0, errors.New("boom")
+		}),
+	}
+}
+
+func (s *FixturedSuiteErr) Test(t *td.T, i int) { s.rec() }
+
+func TestRunFixtures(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		tb := test.NewTestingTB("TestFixtures")
+		suite := Fixtured{}
+		td.CmpTrue(t, tdsuite.Run(tb, &suite))
+		td.CmpFalse(t, tb.Failed())
+		td.Cmp(t, suite.calls, []string{
+			"Setup",
+			"newDB",
+			"PreTest+TestA",
+			/**/ "TestA+db",
+			"PreTest+TestB",
+			/**/ "newUser",
+			/**/ "TestB+db+bob",
+			"PreTest+TestC",
+			/**/ "newUser",
+			/**/ "TestC+bob+x",
+			"PreTest+TestC",
+			/**/ "newUser",
+			/**/ "TestC+bob+y",
+			"PreTest+TestD",
+			/**/ "TestD",
+		})
+		td.Cmp(t, suite.dbs, 1)
+		td.Cmp(t, suite.users, 3)
+	})
+
+	t.Run("Per-test error", func(t *testing.T) {
+		tb := test.NewTestingTB("TestFixtures")
+		suite := Fixtured{userErr: errors.New("no user")}
+		td.CmpFalse(t, tdsuite.Run(tb, &suite))
+		td.CmpFalse(t, tb.IsFatal)
+		td.Cmp(t, tb.Messages, []string{
+			"++++ TestA",
+			"++++ TestB",
+			"TestB fixture *tdsuite_test.fixtureUser error: no user",
+			"++++ TestC",
+			"++++ #0",
+			"TestC fixture *tdsuite_test.fixtureUser error: no user",
+			"++++ #1",
+			"TestC fixture *tdsuite_test.fixtureUser error: no user",
+			"++++ TestD",
+		})
+		td.Cmp(t, suite.calls, []string{
+			"Setup",
+			"newDB",
+			"PreTest+TestA",
+			/**/ "TestA+db",
+			"PreTest+TestB",
+			/**/ "newUser",
+			"PreTest+TestC",
+			/**/ "newUser",
+			"PreTest+TestC",
+			/**/ "newUser",
+			"PreTest+TestD",
+			/**/ "TestD",
+		})
+	})
+
+	t.Run("Per-suite error", func(t *testing.T) {
+		tb := test.NewTestingTB("TestFixtures")
+		suite := FixturedSuiteErr{}
+		td.CmpFalse(t, tdsuite.Run(tb, &suite))
+		td.CmpFalse(t, tb.IsFatal)
+		td.Cmp(t, tb.Messages, []string{
+			"*tdsuite_test.FixturedSuiteErr suite fixture int error: boom",
+		})
+		td.Cmp(t, suite.calls, td.Nil())
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		tb := test.NewTestingTB("TestFixtures")
+		tb.CatchFatal(func() { tdsuite.Run(tb, FixturedDuplicate{}) })
+		td.CmpTrue(t, tb.IsFatal)
+		td.Cmp(t, tb.LastMessage(),
+			"Run(): tdsuite_test.FixturedDuplicate suite fixtures: int fixture provided more than once")
+	})
+
+	t.Run("Bad providers", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			fixture tdsuite.Fixture
+			err     string
+		}{
+			"zero": {
+				err: "fixture must be created using PerTest() or PerSuite()",
+			},
+			"nil": {
+				fixture: tdsuite.PerTest(nil),
+				err:     "PerTest(): provider must be func(*td.T) X or func(*td.T) (X, error), not <nil>",
+			},
+			"no func": {
+				fixture: tdsuite.PerSuite(42),
+				err:     "PerSuite(): provider must be func(*td.T) X or func(*td.T) (X, error), not int",
+			},
+			"bad input": {
+				fixture: tdsuite.PerTest(func(t *testing.T) int { return 0 }),
+				err:     "PerTest(): provider must be func(*td.T) X or func(*td.T) (X, error), not func(*testing.T) int",
+			},
+			"bad 2nd output": {
+				fixture: tdsuite.PerTest(func(t *td.T) (int, bool) { return 0, false }),
+				err:     "PerTest(): provider must be func(*td.T) X or func(*td.T) (X, error), not func(*td.T) (int, bool)",
+			},
+			"*td.T output": {
+				fixture: tdsuite.PerTest(func(t *td.T) *td.T { return t }),
+				err:     "PerTest(): provider cannot provide *td.T",
+			},
+		} {
+			tb := test.NewTestingTB("TestFixtures")
+			tb.CatchFatal(func() {
+				tdsuite.Run(tb, FixturedBad{fixtures: []tdsuite.Fixture{tc.fixture}})
+			})
+			td.CmpTrue(t, tb.IsFatal, name)
+			td.Cmp(t, tb.LastMessage(),
+				"Run(): tdsuite_test.FixturedBad suite fixtures: "+tc.err, name)
+		}
+	})
+}
+
+// Retried has flaky tests.
+type Retried struct {
+	base
+	attempts map[string]int
+}
+
+func (s *Retried) Retries(tn string) int {
+	if tn == "TestNoRetry" {
+		return 0
+	}
+	return 2
+}
+
+func (s *Retried) PreTest(t *td.T, tn string) error { s.rec(tn); return nil }
+
+func (s *Retried) TestFlaky(assert, require *td.T) {
+	s.attempts["Flaky"]++
+	s.rec(strconv.Itoa(s.attempts["Flaky"]))
+	require.Cmp(s.attempts["Flaky"], 2)
+}
+
+func (s *Retried) TestKO(t *td.T) (bool, error) {
+	s.attempts["KO"]++
+	s.rec(strconv.Itoa(s.attempts["KO"]))
+	return true, errors.New("always failing")
+}
+
+func (s *Retried) TestNoRetry(t *td.T) {
+	s.rec()
+	t.Error("no retry")
+}
+
+var _ tdsuite.Retries = (*Retried)(nil)
+
+func TestRunRetries(t *testing.T) {
+	tb := test.NewTestingTB("TestRetries")
+	suite := Retried{attempts: map[string]int{}}
+
+	td.CmpFalse(t, tdsuite.Run(tb, &suite))
+	td.CmpFalse(t, tb.IsFatal)
+	td.Cmp(t, suite.calls, []string{
+		"PreTest+TestFlaky",
+		/**/ "TestFlaky+1",
+		/**/ "TestFlaky+2",
+		"PreTest+TestKO",
+		/**/ "TestKO+1",
+		/**/ "TestKO+2",
+		/**/ "TestKO+3",
+		"PreTest+TestNoRetry",
+		/**/ "TestNoRetry",
+	})
+	td.Cmp(t, tb.Messages, td.Slice([]string{}, td.ArrayEntries{
+		0: "++++ TestFlaky",
+		1: "Retry: attempt #2/3 succeeded, 1 failed attempt ignored",
+		2: "++++ TestKO",
+		3: td.Re(`\A\S*Failed retry\S*: all 3 attempts failed, last one was:\n#1 \S+: TestKO error: always failing\n1 failure in attempt\z`),
+		4: "++++ TestNoRetry",
+		5: "no retry",
+	}))
+}
+
+// Benched has benchmarks.
+type Benched struct {
+	base
+	preTest, postTest int
+	benchNs           []int
+}
+
+func (s *Benched) Setup(t *td.T) error { s.rec(); return nil }
+
+func (s *Benched) PreTest(t *td.T, tn string) error {
+	s.preTest++
+	return nil
+}
+
+func (s *Benched) PostTest(t *td.T, tn string) error {
+	s.postTest++
+	return nil
+}
+
+func (s *Benched) Destroy(t *td.T) error { s.rec(); return nil }
+
+func (s *Benched) TestIgnored(t *td.T) { s.rec() }
+
+func (s *Benched) BenchmarkA(t *td.T, b *testing.B) {
+	s.benchNs = append(s.benchNs, b.N)
+	t.Cmp(b.N, td.Gt(0))
+	if b.N == 1 {
+		s.rec()
+	}
+}
+
+func (s *Benched) BenchmarkBadParams(t *td.T) { s.rec() }
+
+func TestRunBenchmarks(t *testing.T) {
+	suite := Benched{}
+	testing.Benchmark(func(b *testing.B) {
+		tdsuite.Run(b, &suite)
+	})
+
+	td.Cmp(t, suite.calls, []string{"Setup", "BenchmarkA", "Destroy"})
+	td.Cmp(t, suite.benchNs, td.All(td.NotEmpty(), td.Contains(1)))
+	td.Cmp(t, suite.preTest, len(suite.benchNs))
+	td.Cmp(t, suite.postTest, len(suite.benchNs))
+
+	// When not a *testing.B, benchmarks are ignored
+	tb := test.NewTestingTB("TestBenchmarks")
+	suite = Benched{}
+	td.CmpTrue(t, tdsuite.Run(tb, &suite))
+	td.Cmp(t, suite.calls, []string{"Setup", "TestIgnored", "Destroy"})
+	td.CmpNil(t, suite.benchNs)
+}
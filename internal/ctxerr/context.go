@@ -7,6 +7,7 @@
 package ctxerr
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/maxatome/go-testdeep/internal/anchors"
@@ -31,7 +32,13 @@ type Context struct {
 	Errors     *[]*Error
 	Anchors    *anchors.Info
 	Hooks      *hooks.Info
-	OriginalTB testing.TB // only used by Code operator
+	OriginalTB testing.TB // only used by Code and Image operators
+	// Root is the got value of the whole comparison, only used by Ref
+	// operator.
+	Root reflect.Value
+	// Parent is the nearest struct, map, array or slice containing the
+	// currently compared value, if any. Only used by Ref operator.
+	Parent reflect.Value
 	// If true, the contents of the returned *Error will not be
 	// checked. Can be used to avoid filling Error{} with expensive
 	// computations.
@@ -46,6 +53,10 @@ type Context struct {
 	IgnoreUnexported bool
 	// See ContextConfig.TestDeepInGotOK for details.
 	TestDeepInGotOK bool
+	// See ContextConfig.NilEqualsEmpty for details.
+	NilEqualsEmpty bool
+	// See ContextConfig.TimeEqual for details.
+	TimeEqual bool
 }
 
 // InitErrors initializes [Context] *Errors slice, if MaxErrors < 0 or
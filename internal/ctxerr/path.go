@@ -15,29 +15,32 @@ import (
 
 // Path defines a structure depth path, typically used to mark a
 // position during a deep traversal in case of error.
-type Path []pathLevel
+type Path []PathLevel
 
-type pathLevelKind uint8
+// PathLevelKind is the kind of a [PathLevel].
+type PathLevelKind uint8
 
-type pathLevel struct {
+// PathLevel is a level of a [Path].
+type PathLevel struct {
 	Content  string
 	Pointers int
-	Kind     pathLevelKind
+	Kind     PathLevelKind
 }
 
+// Kinds of [PathLevel].
 const (
-	levelStruct pathLevelKind = iota
-	levelArray
-	levelMap
-	levelFunc
-	levelCustom
+	LevelStruct PathLevelKind = iota
+	LevelArray
+	LevelMap
+	LevelFunc
+	LevelCustom
 )
 
 // NewPath returns a new [Path] initialized with root root node.
 func NewPath(root string) Path {
 	return Path{
 		{
-			Kind:    levelCustom,
+			Kind:    LevelCustom,
 			Content: root,
 		},
 	}
@@ -61,7 +64,7 @@ func (p Path) Equal(o Path) bool {
 	return true
 }
 
-func (p Path) addLevel(level pathLevel) Path {
+func (p Path) addLevel(level PathLevel) Path {
 	np := make(Path, len(p), len(p)+1)
 	copy(np, p)
 	return append(np, level)
@@ -84,8 +87,8 @@ func (p Path) AddField(field string) Path {
 		return nil
 	}
 
-	np := p.addLevel(pathLevel{
-		Kind:    levelStruct,
+	np := p.addLevel(PathLevel{
+		Kind:    LevelStruct,
 		Content: field,
 	})
 
@@ -102,8 +105,8 @@ func (p Path) AddArrayIndex(index int) Path {
 		return nil
 	}
 
-	return p.addLevel(pathLevel{
-		Kind:    levelArray,
+	return p.addLevel(PathLevel{
+		Kind:    LevelArray,
 		Content: strconv.Itoa(index),
 	})
 }
@@ -114,8 +117,8 @@ func (p Path) AddMapKey(key any) Path {
 		return nil
 	}
 
-	return p.addLevel(pathLevel{
-		Kind:    levelMap,
+	return p.addLevel(PathLevel{
+		Kind:    LevelMap,
 		Content: util.ToString(key),
 	})
 }
@@ -138,8 +141,8 @@ func (p Path) AddFunctionCall(fn string) Path {
 		return nil
 	}
 
-	return p.addLevel(pathLevel{
-		Kind:    levelFunc,
+	return p.addLevel(PathLevel{
+		Kind:    LevelFunc,
 		Content: fn,
 	})
 }
@@ -150,8 +153,8 @@ func (p Path) AddCustomLevel(custom string) Path {
 		return nil
 	}
 
-	return p.addLevel(pathLevel{
-		Kind:    levelCustom,
+	return p.addLevel(PathLevel{
+		Kind:    LevelCustom,
 		Content: custom,
 	})
 }
@@ -169,7 +172,7 @@ func (p Path) String() string {
 			ptrs = strings.Repeat("*", level.Pointers)
 		}
 
-		if level.Kind == levelFunc {
+		if level.Kind == LevelFunc {
 			str = ptrs + level.Content + "(" + str + ")"
 		} else {
 			if i > 0 && p[i-1].Pointers > 0 {
@@ -180,9 +183,9 @@ func (p Path) String() string {
 			}
 
 			switch level.Kind {
-			case levelStruct:
+			case LevelStruct:
 				str += "." + level.Content
-			case levelArray, levelMap:
+			case LevelArray, LevelMap:
 				str += "[" + level.Content + "]"
 			default:
 				str += level.Content
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/maxatome/go-testdeep/internal/types"
@@ -20,6 +21,10 @@ type properties struct {
 	smuggle          reflect.Value
 	ignoreUnexported bool
 	useEqual         bool
+	nilEqualsEmpty   bool
+	ignoredFields    map[string]bool
+	// fieldsMappings is indexed by expected type
+	fieldsMappings map[reflect.Type]map[string]string
 }
 
 // Info gathers all hooks information.
@@ -307,3 +312,204 @@ func (i *Info) IgnoreUnexported(t reflect.Type) bool {
 	defer i.Unlock()
 	return i.props[t].ignoreUnexported
 }
+
+// AddNilEqualsEmpty records types of values contained in ts as
+// considering nil and empty values as equal. ts can also contain
+// [reflect.Type] instances. Only slice and map types are accepted.
+func (i *Info) AddNilEqualsEmpty(ts []any) error {
+	if len(ts) == 0 {
+		return nil
+	}
+	for n, typ := range ts {
+		t, ok := typ.(reflect.Type)
+		if !ok {
+			t = reflect.TypeOf(typ)
+			ts[n] = t
+		}
+
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Map {
+			return fmt.Errorf("expects type %s be a slice or a map, not a %s (@%d)", t, t.Kind(), n)
+		}
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	for _, typ := range ts {
+		t := typ.(reflect.Type)
+		prop := i.props[t]
+		prop.nilEqualsEmpty = true
+		i.props[t] = prop
+	}
+	return nil
+}
+
+// NilEqualsEmpty returns true if nil and empty values of the type t
+// have to be considered equal.
+func (i *Info) NilEqualsEmpty(t reflect.Type) bool {
+	if i == nil {
+		return false
+	}
+
+	i.Lock()
+	defer i.Unlock()
+	return i.props[t].nilEqualsEmpty
+}
+
+// AddIgnoreFields records fields of struct type typ as ignored
+// during comparisons. typ can also be a [reflect.Type] instance.
+//
+// It returns an error if typ is not a struct type or if one of fields
+// is not a field of it.
+func (i *Info) AddIgnoreFields(typ any, fields []string) error {
+	t, ok := typ.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(typ)
+	}
+
+	if t == nil {
+		return errors.New("expects a struct type, not nil")
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("expects type %s be a struct, not a %s", t, t.Kind())
+	}
+
+	for n, field := range fields {
+		if _, ok := t.FieldByName(field); !ok {
+			return fmt.Errorf("expects field %q exists in type %s (@%d)", field, t, n)
+		}
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	prop := i.props[t]
+	// Copy on write, as props can be shared with other Info instances
+	ignored := make(map[string]bool, len(prop.ignoredFields)+len(fields))
+	for field := range prop.ignoredFields {
+		ignored[field] = true
+	}
+	for _, field := range fields {
+		ignored[field] = true
+	}
+	prop.ignoredFields = ignored
+	i.props[t] = prop
+	return nil
+}
+
+// IgnoredFields returns the set of fields of the struct type t that
+// have to be ignored. It returns nil if no fields are ignored.
+func (i *Info) IgnoredFields(t reflect.Type) map[string]bool {
+	if i == nil {
+		return nil
+	}
+
+	i.Lock()
+	defer i.Unlock()
+	return i.props[t].ignoredFields
+}
+
+// AddFieldsMapping records that struct type got can be compared to
+// struct type expected, field by field. By default, each field of
+// got is compared to the field with the same name in
+// expected. mapping allows to change this behavior: each key is the
+// name of a field of got, and its value the name of the
+// corresponding field of expected. got and expected can also be
+// [reflect.Type] instances.
+//
+// It returns an error if got or expected are not struct types, if
+// they are the same type, if a mapping key is not a direct (so not
+// promoted) field of got type, if a mapping value is not a field of
+// expected type, or if two fields of got would be compared to the
+// same field of expected.
+func (i *Info) AddFieldsMapping(got, expected any, mapping map[string]string) error {
+	var ts [2]reflect.Type
+	for n, typ := range [2]any{got, expected} {
+		t, ok := typ.(reflect.Type)
+		if !ok {
+			t = reflect.TypeOf(typ)
+		}
+		if t == nil {
+			return fmt.Errorf("expects a struct type, not nil (@%d)", n)
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("expects type %s be a struct, not a %s (@%d)", t, t.Kind(), n)
+		}
+		ts[n] = t
+	}
+
+	if ts[0] == ts[1] {
+		return fmt.Errorf("expects 2 different struct types, not twice %s", ts[0])
+	}
+
+	gotFields := make([]string, 0, len(mapping))
+	for gotField := range mapping {
+		gotFields = append(gotFields, gotField)
+	}
+	sort.Strings(gotFields)
+
+	newMapping := make(map[string]string, len(mapping))
+	targets := make(map[string]string, len(mapping))
+	for _, gotField := range gotFields {
+		expectedField := mapping[gotField]
+
+		field, ok := ts[0].FieldByName(gotField)
+		if !ok {
+			return fmt.Errorf("expects field %q exists in type %s", gotField, ts[0])
+		}
+		// Only direct fields of got are walked during comparisons
+		if len(field.Index) != 1 {
+			return fmt.Errorf("expects field %q be a direct field of type %s, not a promoted one",
+				gotField, ts[0])
+		}
+		if _, ok := ts[1].FieldByName(expectedField); !ok {
+			return fmt.Errorf("expects field %q exists in type %s", expectedField, ts[1])
+		}
+		if other, ok := targets[expectedField]; ok {
+			return fmt.Errorf("expects only one field of type %s mapped to field %q, not %q and %q",
+				ts[0], expectedField, other, gotField)
+		}
+		targets[expectedField] = gotField
+		newMapping[gotField] = expectedField
+	}
+
+	// Not mapped fields of got are compared to the same name fields
+	for n := 0; n < ts[0].NumField(); n++ {
+		name := ts[0].Field(n).Name
+		if _, mapped := mapping[name]; mapped {
+			continue
+		}
+		if other, ok := targets[name]; ok {
+			return fmt.Errorf("expects only one field of type %s mapped to field %q, not %q and %q",
+				ts[0], name, other, name)
+		}
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	prop := i.props[ts[0]]
+	// Copy on write, as props can be shared with other Info instances
+	mappings := make(map[reflect.Type]map[string]string, len(prop.fieldsMappings)+1)
+	for t, m := range prop.fieldsMappings {
+		mappings[t] = m
+	}
+	mappings[ts[1]] = newMapping
+	prop.fieldsMappings = mappings
+	i.props[ts[0]] = prop
+	return nil
+}
+
+// FieldsMapping returns the fields mapping to use to compare struct
+// types got and expected, and true if such mapping exists. See
+// [Info.AddFieldsMapping] for details.
+func (i *Info) FieldsMapping(got, expected reflect.Type) (map[string]string, bool) {
+	if i == nil {
+		return nil, false
+	}
+
+	i.Lock()
+	defer i.Unlock()
+	mapping, ok := i.props[got].fieldsMappings[expected]
+	return mapping, ok
+}
@@ -330,6 +330,183 @@ func TestAddIgnoreUnexported(t *testing.T) {
 	}
 }
 
+func TestNilEqualsEmpty(t *testing.T) {
+	var i *hooks.Info
+
+	test.IsFalse(t, i.NilEqualsEmpty(reflect.TypeOf([]int{})))
+
+	i = hooks.NewInfo()
+	test.IsFalse(t, i.NilEqualsEmpty(reflect.TypeOf([]int{})))
+
+	test.NoError(t, i.AddNilEqualsEmpty([]any{}))
+
+	test.NoError(t, i.AddNilEqualsEmpty([]any{[]int{}, reflect.TypeOf(map[string]bool{})}))
+	test.IsTrue(t, i.NilEqualsEmpty(reflect.TypeOf([]int{})))
+	test.IsTrue(t, i.NilEqualsEmpty(reflect.TypeOf(map[string]bool{})))
+	test.IsFalse(t, i.NilEqualsEmpty(reflect.TypeOf([]string{})))
+}
+
+func TestAddNilEqualsEmpty(t *testing.T) {
+	i := hooks.NewInfo()
+
+	err := i.AddNilEqualsEmpty([]any{[]int{}, 0})
+	if test.Error(t, err) {
+		test.EqualStr(t, err.Error(), "expects type int be a slice or a map, not a int (@1)")
+	}
+}
+
+func TestIgnoreFields(t *testing.T) {
+	type MyStruct struct {
+		ID        int
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	var i *hooks.Info
+
+	test.IsTrue(t, i.IgnoredFields(reflect.TypeOf(MyStruct{})) == nil)
+
+	i = hooks.NewInfo()
+	test.IsTrue(t, i.IgnoredFields(reflect.TypeOf(MyStruct{})) == nil)
+
+	test.NoError(t, i.AddIgnoreFields(MyStruct{}, []string{"CreatedAt"}))
+	test.NoError(t, i.AddIgnoreFields(reflect.TypeOf(MyStruct{}), []string{"UpdatedAt"}))
+	ignored := i.IgnoredFields(reflect.TypeOf(MyStruct{}))
+	test.EqualInt(t, len(ignored), 2)
+	test.IsTrue(t, ignored["CreatedAt"])
+	test.IsTrue(t, ignored["UpdatedAt"])
+	test.IsFalse(t, ignored["ID"])
+
+	// Copy does not share ignored fields
+	ni := i.Copy()
+	test.NoError(t, ni.AddIgnoreFields(MyStruct{}, []string{"ID"}))
+	test.IsTrue(t, ni.IgnoredFields(reflect.TypeOf(MyStruct{}))["ID"])
+	test.IsFalse(t, i.IgnoredFields(reflect.TypeOf(MyStruct{}))["ID"])
+
+	err := i.AddIgnoreFields(42, []string{"ID"})
+	if test.Error(t, err) {
+		test.EqualStr(t, err.Error(), "expects type int be a struct, not a int")
+	}
+
+	err = i.AddIgnoreFields(nil, []string{"ID"})
+	if test.Error(t, err) {
+		test.EqualStr(t, err.Error(), "expects a struct type, not nil")
+	}
+
+	err = i.AddIgnoreFields(MyStruct{}, []string{"ID", "Unknown"})
+	if test.Error(t, err) {
+		test.EqualStr(t, err.Error(),
+			`expects field "Unknown" exists in type hooks_test.MyStruct (@1)`)
+	}
+}
+
+func TestFieldsMapping(t *testing.T) {
+	type Entity struct {
+		UserID int
+		Email  string
+	}
+	type DTO struct {
+		ID    int
+		Email string
+	}
+	type Base struct {
+		ID int
+	}
+	type EmbeddedDTO struct {
+		Base
+		Email string
+	}
+
+	var i *hooks.Info
+
+	_, ok := i.FieldsMapping(reflect.TypeOf(DTO{}), reflect.TypeOf(Entity{}))
+	test.IsFalse(t, ok)
+
+	i = hooks.NewInfo()
+	_, ok = i.FieldsMapping(reflect.TypeOf(DTO{}), reflect.TypeOf(Entity{}))
+	test.IsFalse(t, ok)
+
+	test.NoError(t, i.AddFieldsMapping(DTO{}, reflect.TypeOf(Entity{}),
+		map[string]string{"ID": "UserID"}))
+	mapping, ok := i.FieldsMapping(reflect.TypeOf(DTO{}), reflect.TypeOf(Entity{}))
+	if test.IsTrue(t, ok) {
+		test.EqualInt(t, len(mapping), 1)
+		test.EqualStr(t, mapping["ID"], "UserID")
+	}
+
+	// Not symmetric
+	_, ok = i.FieldsMapping(reflect.TypeOf(Entity{}), reflect.TypeOf(DTO{}))
+	test.IsFalse(t, ok)
+
+	// Swapped fields are OK
+	test.NoError(t, i.AddFieldsMapping(DTO{}, struct{ ID, Email string }{},
+		map[string]string{"ID": "Email", "Email": "ID"}))
+
+	// nil mapping
+	test.NoError(t, i.AddFieldsMapping(Entity{}, DTO{}, nil))
+	mapping, ok = i.FieldsMapping(reflect.TypeOf(Entity{}), reflect.TypeOf(DTO{}))
+	if test.IsTrue(t, ok) {
+		test.EqualInt(t, len(mapping), 0)
+	}
+
+	for _, tst := range []struct {
+		got, expected any
+		mapping       map[string]string
+		err           string
+	}{
+		{
+			got:      42,
+			expected: Entity{},
+			err:      "expects type int be a struct, not a int (@0)",
+		},
+		{
+			got:      DTO{},
+			expected: nil,
+			err:      "expects a struct type, not nil (@1)",
+		},
+		{
+			got:      DTO{},
+			expected: DTO{},
+			err:      "expects 2 different struct types, not twice hooks_test.DTO",
+		},
+		{
+			got:      DTO{},
+			expected: Entity{},
+			mapping:  map[string]string{"Unknown": "UserID"},
+			err:      `expects field "Unknown" exists in type hooks_test.DTO`,
+		},
+		{
+			got:      DTO{},
+			expected: Entity{},
+			mapping:  map[string]string{"ID": "Unknown"},
+			err:      `expects field "Unknown" exists in type hooks_test.Entity`,
+		},
+		{
+			got:      EmbeddedDTO{},
+			expected: Entity{},
+			mapping:  map[string]string{"ID": "UserID"},
+			err:      `expects field "ID" be a direct field of type hooks_test.EmbeddedDTO, not a promoted one`,
+		},
+		{
+			got:      DTO{},
+			expected: Entity{},
+			mapping:  map[string]string{"ID": "UserID", "Email": "UserID"},
+			err:      `expects only one field of type hooks_test.DTO mapped to field "UserID", not "Email" and "ID"`,
+		},
+		{
+			got:      DTO{},
+			expected: Entity{},
+			mapping:  map[string]string{"ID": "Email"},
+			err:      `expects only one field of type hooks_test.DTO mapped to field "Email", not "ID" and "Email"`,
+		},
+	} {
+		err := i.AddFieldsMapping(tst.got, tst.expected, tst.mapping)
+		if test.Error(t, err) {
+			test.EqualStr(t, err.Error(), tst.err)
+		}
+	}
+}
+
 func TestCopy(t *testing.T) {
 	var orig *hooks.Info
 
@@ -427,6 +427,16 @@ func (j *json) analyzeStringContent(s string, strPos Position, lval *yySymType)
 		return STRING
 	}
 
+	// Reference to got value as "$.path" or "$[key]", see Ref operator
+	if s[1] == '.' || s[1] == '[' {
+		op, err := j.getOperator(Operator{Name: "Ref", Params: []any{s}}, strPos)
+		if err != nil {
+			j.error(err.Error(), strPos)
+		}
+		lval.value = op
+		return PLACEHOLDER
+	}
+
 	// Check for placeholder ($1 or $name) or operator call as $^Empty
 	// or $^Re(q<\d+>)
 	token, value := j.parseDollarToken(s[1:], strPos, true)
@@ -341,6 +341,31 @@ func TestJSON(t *testing.T) {
 		}
 	})
 
+	t.Run("Reference", func(t *testing.T) {
+		opts := json.ParseOpts{
+			OpFn: func(op json.Operator, pos json.Position) (any, error) {
+				if op.Name == "Ref" && len(op.Params) == 1 {
+					return op.Params[0], nil
+				}
+				return nil, fmt.Errorf("hmm weird operator %q", op.Name)
+			},
+		}
+		for _, js := range []string{
+			`"$.foo"`,
+			`"$[foo]"`,
+		} {
+			got, err := json.Parse([]byte(js), opts)
+			if test.NoError(t, err, "json.Parse OK", js) {
+				test.EqualStr(t, got.(string), js[1:len(js)-1])
+			}
+		}
+
+		_, err := json.Parse([]byte(`"$.foo"`))
+		if test.Error(t, err, "json.Parse fails") {
+			test.EqualStr(t, err.Error(), `unknown operator "Ref" at line 1:1 (pos 1)`)
+		}
+	})
+
 	t.Run("Reentrant parser", func(t *testing.T) {
 		opts := json.ParseOpts{
 			OpFn: func(op json.Operator, pos json.Position) (any, error) {
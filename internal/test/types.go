@@ -7,6 +7,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
@@ -130,7 +131,14 @@ func (t *TestingTB) Cleanup(fn func()) {
 		}
 		fn()
 	}
-	runtime.SetFinalizer(t, func(t *TestingTB) { t.cleanup() })
+	if old == nil {
+		runtime.SetFinalizer(t, func(t *TestingTB) { t.cleanup() })
+	}
+}
+
+// Context mocks [testing.T.Context] method.
+func (t *TestingTB) Context() context.Context {
+	return context.Background()
 }
 
 // Fatal mocks [testing.T.Error] method.
@@ -0,0 +1,96 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const thisPkg = "github.com/maxatome/go-testdeep/internal/trace"
+
+// GoroutineID returns the ID of the current goroutine.
+func GoroutineID() uint64 {
+	var buf [64]byte
+	// "goroutine 123 [running]:\n..."
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if end := bytes.IndexByte(b, ' '); end > 0 {
+		id, _ := strconv.ParseUint(string(b[:end]), 10, 64)
+		return id
+	}
+	return 0
+}
+
+// allStacks returns the stack traces of all goroutines, as
+// [runtime.Stack] formats them.
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// RetrieveGoroutine retrieves the trace of goroutine id and returns
+// it. Top levels belonging to runtime, trace and ignored packages
+// are skipped. It returns nil if the goroutine does not exist anymore.
+func RetrieveGoroutine(id uint64) Stack {
+	header := "goroutine " + strconv.FormatUint(id, 10) + " ["
+
+	for _, block := range strings.Split(string(allStacks()), "\n\n") {
+		if !strings.HasPrefix(block, header) {
+			continue
+		}
+
+		var trace Stack
+		lines := strings.Split(block, "\n")
+		// lines[0] is the header, then pairs of function / file:line lines
+		for i := 1; i+1 < len(lines); i += 2 {
+			fn := lines[i]
+			if strings.HasPrefix(fn, "created by ") ||
+				strings.HasPrefix(fn, "...") { // ...additional frames elided...
+				break
+			}
+			if pos := strings.LastIndexByte(fn, '('); pos > 0 {
+				fn = fn[:pos]
+			}
+
+			pkg, fn := SplitPackageFunc(fn)
+			if len(trace) == 0 &&
+				(pkg == "runtime" || pkg == thisPkg || IsIgnoredPackage(pkg)) {
+				continue
+			}
+
+			level := Level{
+				Package: pkg,
+				Func:    fn,
+			}
+
+			// "\t/path/to/file.go:12 +0x1d"
+			fileLine := strings.TrimPrefix(lines[i+1], "\t")
+			if pos := strings.LastIndex(fileLine, " +0x"); pos > 0 {
+				fileLine = fileLine[:pos]
+			}
+			if pos := strings.LastIndexByte(fileLine, ':'); pos > 0 {
+				if line, err := strconv.Atoi(fileLine[pos+1:]); err == nil {
+					fileLine = fmt.Sprintf("%s:%d", shortFile(fileLine[:pos]), line)
+				}
+			}
+			level.FileLine = fileLine
+
+			trace = append(trace, level)
+		}
+		return trace
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package trace_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/internal/trace"
+)
+
+func blockedGoroutine(id chan<- uint64, unblock <-chan struct{}) {
+	id <- trace.GoroutineID()
+	<-unblock
+}
+
+func TestRetrieveGoroutine(t *testing.T) {
+	trace.Reset()
+	trace.Init()
+
+	myID := trace.GoroutineID()
+	test.IsTrue(t, myID > 0)
+
+	id := make(chan uint64)
+	unblock := make(chan struct{})
+	go blockedGoroutine(id, unblock)
+
+	otherID := <-id
+	test.IsTrue(t, otherID != myID)
+
+	s := trace.RetrieveGoroutine(otherID)
+	if test.EqualInt(t, len(s), 1) {
+		test.EqualStr(t, s[0].Package, "github.com/maxatome/go-testdeep/internal/trace_test")
+		test.EqualStr(t, s[0].Func, "blockedGoroutine")
+		test.IsTrue(t, strings.HasPrefix(s[0].FileLine, "internal/trace/goroutine_test.go:"),
+			s[0].FileLine)
+	}
+
+	close(unblock)
+
+	s = trace.RetrieveGoroutine(myID)
+	if test.IsTrue(t, len(s) > 0) {
+		test.EqualStr(t, s[0].Func, "TestRetrieveGoroutine")
+	}
+
+	test.IsTrue(t, trace.RetrieveGoroutine(0) == nil)
+}
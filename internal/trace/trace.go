@@ -176,28 +176,11 @@ func Retrieve(skip int, endFunction string) Stack {
 				checkIgnore = false
 			}
 
-			file := strings.TrimPrefix(frame.File, goModDir)
-			if file == frame.File {
-				for _, dir := range goPaths {
-					file = strings.TrimPrefix(frame.File, dir)
-					if file != frame.File {
-						break
-					}
-				}
-
-				if file == frame.File {
-					file = strings.TrimPrefix(frame.File, build.Default.GOROOT)
-					if file != frame.File {
-						file = filepath.Join("$GOROOT", file)
-					}
-				}
-			}
-
 			level := Level{
 				Package: pkg,
 				Func:    fn,
 			}
-			if file != "" {
+			if file := shortFile(frame.File); file != "" {
 				level.FileLine = fmt.Sprintf("%s:%d", file, frame.Line)
 			}
 
@@ -210,6 +193,26 @@ func Retrieve(skip int, endFunction string) Stack {
 	return trace
 }
 
+// shortFile returns file relative to the go.mod directory, a GOPATH
+// directory or GOROOT.
+func shortFile(file string) string {
+	short := strings.TrimPrefix(file, goModDir)
+	if short != file {
+		return short
+	}
+
+	for _, dir := range goPaths {
+		if short = strings.TrimPrefix(file, dir); short != file {
+			return short
+		}
+	}
+
+	if short = strings.TrimPrefix(file, build.Default.GOROOT); short != file {
+		return filepath.Join("$GOROOT", short)
+	}
+	return file
+}
+
 // SplitPackageFunc splits a fully qualified function name into its
 // package and function parts:
 //
@@ -9,6 +9,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
 	"time"
@@ -21,6 +22,7 @@ var (
 	FmtStringer     = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
 	Error           = reflect.TypeOf((*error)(nil)).Elem()
 	JsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem() //nolint: revive
+	Reader          = reflect.TypeOf((*io.Reader)(nil)).Elem()
 	Time            = reflect.TypeOf(time.Time{})
 	Int             = reflect.TypeOf(int(0))
 	Uint8           = reflect.TypeOf(uint8(0))
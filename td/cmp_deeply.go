@@ -22,6 +22,12 @@ func init() {
 	trace.IgnorePackage()
 }
 
+const (
+	tdPkg      = "github.com/maxatome/go-testdeep/td"
+	tdhttpPkg  = "github.com/maxatome/go-testdeep/helpers/tdhttp"
+	tdsuitePkg = "github.com/maxatome/go-testdeep/helpers/tdsuite"
+)
+
 // stripTrace removes go-testdeep useless calls in a trace returned by
 // trace.Retrieve() to make it clearer for the reader.
 func stripTrace(s trace.Stack) trace.Stack {
@@ -29,12 +35,6 @@ func stripTrace(s trace.Stack) trace.Stack {
 		return s
 	}
 
-	const (
-		tdPkg      = "github.com/maxatome/go-testdeep/td"
-		tdhttpPkg  = "github.com/maxatome/go-testdeep/helpers/tdhttp"
-		tdsuitePkg = "github.com/maxatome/go-testdeep/helpers/tdsuite"
-	)
-
 	// Remove useless possible (*T).Run() or (*T).RunAssertRequire() first call
 	if s.Match(-1, tdPkg, "(*T).Run.func1", "(*T).RunAssertRequire.func1") {
 		// Remove useless tdhttp (*TestAPI).Run() call
@@ -51,6 +51,7 @@ func stripTrace(s trace.Stack) trace.Stack {
 		// ✓ xxx       Suite.TestSuite
 		// ✗ reflect   Value.call
 		// ✗ reflect   Value.Call
+		// ✗ …/tdsuite run.func2.2
 		// ✗ …/tdsuite run.func2
 		// ✗ …/td      (*T).Run.func1() or (*T).RunAssertRequire.func1()
 		//
@@ -60,15 +61,8 @@ func stripTrace(s trace.Stack) trace.Stack {
 		// ✗ …/tdsuite run.func2
 		// ✗ …/td      (*T).Run.func1() or (*T).RunAssertRequire.func1()
 		if s.Match(-2, tdsuitePkg, "run.func*") {
-			// PostTest
-			if s.Match(-3, tdsuitePkg, "run.func*") &&
-				len(s) > 4 &&
-				strings.HasSuffix(s[len(s)-4].Func, ".PostTest") {
-				return s[:len(s)-3]
-			}
-
 			for i := len(s) - 3; i >= 1; i-- {
-				if !s.Match(i, "reflect") {
+				if !s.Match(i, tdsuitePkg) && !s.Match(i, "reflect") {
 					return s[:i+1]
 				}
 			}
@@ -132,10 +126,13 @@ func formatError(t TestingT, isFatal bool, err *ctxerr.Error, args ...any) {
 
 	err.Append(&buf, "", true)
 
-	// Stask trace
-	if s := stripTrace(trace.Retrieve(0, "testing.tRunner")); s.IsRelevant() {
-		buf.WriteString("\nThis is how we got here:\n")
-		s.Dump(&buf)
+	// Stask trace, except in a batch as it records each failure
+	// location on its own
+	if _, inBatch := t.(*batchTB); !inBatch {
+		if s := stripTrace(trace.Retrieve(0, "testing.tRunner")); s.IsRelevant() {
+			buf.WriteString("\nThis is how we got here:\n")
+			s.Dump(&buf)
+		}
 	}
 
 	if isFatal {
@@ -148,8 +145,8 @@ func formatError(t TestingT, isFatal bool, err *ctxerr.Error, args ...any) {
 func cmpDeeply(ctx ctxerr.Context, t TestingT, got, expected any,
 	args ...any,
 ) bool {
-	err := deepValueEqualFinal(ctx,
-		reflect.ValueOf(got), reflect.ValueOf(expected))
+	ctx.Root = reflect.ValueOf(got)
+	err := deepValueEqualFinal(ctx, ctx.Root, reflect.ValueOf(expected))
 	if err == nil {
 		return true
 	}
@@ -103,6 +103,20 @@ func TestStripTrace(t *testing.T) {
 	}
 	check(s, s[:2])
 
+	// tdsuite.Run() call → TestSuite(*td.T, Case) with timeout
+	s = trace.Stack{
+		{Package: "test", Func: "A"},
+		{Package: "test", Func: "Suite.TestSuite"},
+		{Package: "reflect", Func: "Value.call"},
+		{Package: "reflect", Func: "Value.Call"},
+		{Package: "github.com/maxatome/go-testdeep/helpers/tdsuite", Func: "run.func3.2"},
+		{Package: "github.com/maxatome/go-testdeep/helpers/tdsuite", Func: "run.func3.3"},
+		{Package: "github.com/maxatome/go-testdeep/helpers/tdsuite", Func: "run.func3"},
+		{Package: "github.com/maxatome/go-testdeep/helpers/tdsuite", Func: "run.func4"},
+		{Package: "github.com/maxatome/go-testdeep/td", Func: "(*T).Run.func1"},
+	}
+	check(s, s[:2])
+
 	// tdsuite.Run() call → Suite.Setup()
 	s = trace.Stack{
 		{Package: "test", Func: "A"},
@@ -12,76 +12,107 @@ import (
 	"time"
 )
 
-// allOperators lists the 67 operators.
+// allOperators lists the 98 operators.
 // nil means not usable in JSON().
 var allOperators = map[string]any{
-	"All":          All,
-	"Any":          Any,
-	"Array":        nil,
-	"ArrayEach":    ArrayEach,
-	"Bag":          Bag,
-	"Between":      Between,
-	"Cap":          nil,
-	"Catch":        nil,
-	"Code":         nil,
-	"Contains":     Contains,
-	"ContainsKey":  ContainsKey,
-	"Delay":        nil,
-	"Empty":        Empty,
-	"ErrorIs":      nil,
-	"First":        First,
-	"Grep":         Grep,
-	"Gt":           Gt,
-	"Gte":          Gte,
-	"HasPrefix":    HasPrefix,
-	"HasSuffix":    HasSuffix,
-	"Ignore":       Ignore,
-	"Isa":          nil,
-	"JSON":         nil,
-	"JSONPointer":  JSONPointer,
-	"Keys":         Keys,
-	"Last":         Last,
-	"Lax":          nil,
-	"Len":          Len,
-	"Lt":           Lt,
-	"Lte":          Lte,
-	"Map":          nil,
-	"MapEach":      MapEach,
-	"N":            N,
-	"NaN":          NaN,
-	"Nil":          Nil,
-	"None":         None,
-	"Not":          Not,
-	"NotAny":       NotAny,
-	"NotEmpty":     NotEmpty,
-	"NotNaN":       NotNaN,
-	"NotNil":       NotNil,
-	"NotZero":      NotZero,
-	"PPtr":         nil,
-	"Ptr":          nil,
-	"Re":           Re,
-	"ReAll":        ReAll,
-	"Recv":         nil,
-	"SStruct":      nil,
-	"Set":          Set,
-	"Shallow":      nil,
-	"Slice":        nil,
-	"Smuggle":      nil,
-	"String":       nil,
-	"Struct":       nil,
-	"SubBagOf":     SubBagOf,
-	"SubJSONOf":    nil,
-	"SubMapOf":     SubMapOf,
-	"SubSetOf":     SubSetOf,
-	"SuperBagOf":   SuperBagOf,
-	"SuperJSONOf":  nil,
-	"SuperMapOf":   SuperMapOf,
-	"SuperSetOf":   SuperSetOf,
-	"SuperSliceOf": nil,
-	"Tag":          nil,
-	"TruncTime":    nil,
-	"Values":       Values,
-	"Zero":         Zero,
+	"All":            All,
+	"Any":            Any,
+	"Array":          nil,
+	"ArrayEach":      ArrayEach,
+	"Avg":            Avg,
+	"Bag":            Bag,
+	"Between":        Between,
+	"Cap":            nil,
+	"Catch":          nil,
+	"Code":           nil,
+	"Contains":       Contains,
+	"ContainsKey":    ContainsKey,
+	"ContainsNorm":   nil,
+	"ContainsSeq":    ContainsSeq,
+	"Count":          Count,
+	"Delay":          nil,
+	"Empty":          Empty,
+	"ErrorIs":        nil,
+	"FS":             nil,
+	"File":           nil,
+	"First":          First,
+	"Grep":           Grep,
+	"Gt":             Gt,
+	"Gte":            Gte,
+	"HasPrefix":      HasPrefix,
+	"HasPrefixNorm":  nil,
+	"HasSuffix":      HasSuffix,
+	"HasSuffixNorm":  nil,
+	"If":             If,
+	"Ignore":         Ignore,
+	"Image":          nil,
+	"Isa":            nil,
+	"JSON":           nil,
+	"JSONPointer":    JSONPointer,
+	"Keys":           Keys,
+	"Last":           Last,
+	"Lax":            nil,
+	"Len":            Len,
+	"Lt":             Lt,
+	"Lte":            Lte,
+	"Map":            nil,
+	"MapEach":        MapEach,
+	"Max":            Max,
+	"Min":            Min,
+	"N":              N,
+	"NaN":            NaN,
+	"Nil":            Nil,
+	"None":           None,
+	"Not":            Not,
+	"NotAny":         NotAny,
+	"NotEmpty":       NotEmpty,
+	"NotNaN":         NotNaN,
+	"NotNil":         NotNil,
+	"NotZero":        NotZero,
+	"PPtr":           nil,
+	"Ptr":            nil,
+	"Re":             Re,
+	"ReAll":          ReAll,
+	"ReaderContent":  nil,
+	"Recent":         nil,
+	"Recv":           nil,
+	"RecvAll":        nil,
+	"RecvN":          nil,
+	"RecvWithin":     nil,
+	"Ref":            Ref,
+	"SStruct":        nil,
+	"SameInstant":    nil,
+	"Set":            Set,
+	"Shallow":        nil,
+	"Slice":          nil,
+	"SliceHasPrefix": SliceHasPrefix,
+	"SliceHasSuffix": SliceHasSuffix,
+	"Smuggle":        nil,
+	"String":         nil,
+	"StringFold":     StringFold,
+	"StringNorm":     nil,
+	"Struct":         nil,
+	"SubBagOf":       SubBagOf,
+	"SubJSONOf":      nil,
+	"SubMapOf":       SubMapOf,
+	"SubSetOf":       SubSetOf,
+	"Subsequence":    Subsequence,
+	"Sum":            Sum,
+	"SuperBagOf":     SuperBagOf,
+	"SuperJSONOf":    nil,
+	"SuperMapOf":     SuperMapOf,
+	"SuperSetOf":     SuperSetOf,
+	"SuperSliceOf":   nil,
+	"Switch":         nil,
+	"Tag":            nil,
+	"Tar":            nil,
+	"TruncTime":      nil,
+	"Unique":         Unique,
+	"UniqueBy":       UniqueBy,
+	"Values":         Values,
+	"WithinDuration": nil,
+	"Zero":           Zero,
+	"Zip":            nil,
 }
 
 // CmpAll is a shortcut for:
@@ -168,6 +199,27 @@ func CmpArrayEach(t TestingT, got, expectedValue any, args ...any) bool {
 	return Cmp(t, got, ArrayEach(expectedValue), args...)
 }
 
+// CmpAvg is a shortcut for:
+//
+//	td.Cmp(t, got, td.Avg(fieldsPath, expectedValue), args...)
+//
+// See [Avg] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpAvg(t TestingT, got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Avg(fieldsPath, expectedValue), args...)
+}
+
 // CmpBag is a shortcut for:
 //
 //	td.Cmp(t, got, td.Bag(expectedItems...), args...)
@@ -298,6 +350,69 @@ func CmpContainsKey(t TestingT, got, expectedValue any, args ...any) bool {
 	return Cmp(t, got, ContainsKey(expectedValue), args...)
 }
 
+// CmpContainsNorm is a shortcut for:
+//
+//	td.Cmp(t, got, td.ContainsNorm(expected, normalizers...), args...)
+//
+// See [ContainsNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpContainsNorm(t TestingT, got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, ContainsNorm(expected, normalizers...), args...)
+}
+
+// CmpContainsSeq is a shortcut for:
+//
+//	td.Cmp(t, got, td.ContainsSeq(expectedItems...), args...)
+//
+// See [ContainsSeq] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpContainsSeq(t TestingT, got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, ContainsSeq(expectedItems...), args...)
+}
+
+// CmpCount is a shortcut for:
+//
+//	td.Cmp(t, got, td.Count(filter, expectedValue), args...)
+//
+// See [Count] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpCount(t TestingT, got, filter, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Count(filter, expectedValue), args...)
+}
+
 // CmpEmpty is a shortcut for:
 //
 //	td.Cmp(t, got, td.Empty(), args...)
@@ -340,6 +455,27 @@ func CmpErrorIs(t TestingT, got, expectedError any, args ...any) bool {
 	return Cmp(t, got, ErrorIs(expectedError), args...)
 }
 
+// CmpFile is a shortcut for:
+//
+//	td.Cmp(t, got, td.File(path, expectedValue), args...)
+//
+// See [File] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpFile(t TestingT, got any, path string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, File(path, expectedValue), args...)
+}
+
 // CmpFirst is a shortcut for:
 //
 //	td.Cmp(t, got, td.First(filter, expectedValue), args...)
@@ -361,6 +497,27 @@ func CmpFirst(t TestingT, got, filter, expectedValue any, args ...any) bool {
 	return Cmp(t, got, First(filter, expectedValue), args...)
 }
 
+// CmpFS is a shortcut for:
+//
+//	td.Cmp(t, got, td.FS(expectedFiles), args...)
+//
+// See [FS] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpFS(t TestingT, got any, expectedFiles map[string]any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, FS(expectedFiles), args...)
+}
+
 // CmpGrep is a shortcut for:
 //
 //	td.Cmp(t, got, td.Grep(filter, expectedValue), args...)
@@ -445,6 +602,27 @@ func CmpHasPrefix(t TestingT, got any, expected string, args ...any) bool {
 	return Cmp(t, got, HasPrefix(expected), args...)
 }
 
+// CmpHasPrefixNorm is a shortcut for:
+//
+//	td.Cmp(t, got, td.HasPrefixNorm(expected, normalizers...), args...)
+//
+// See [HasPrefixNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpHasPrefixNorm(t TestingT, got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, HasPrefixNorm(expected, normalizers...), args...)
+}
+
 // CmpHasSuffix is a shortcut for:
 //
 //	td.Cmp(t, got, td.HasSuffix(expected), args...)
@@ -466,6 +644,73 @@ func CmpHasSuffix(t TestingT, got any, expected string, args ...any) bool {
 	return Cmp(t, got, HasSuffix(expected), args...)
 }
 
+// CmpHasSuffixNorm is a shortcut for:
+//
+//	td.Cmp(t, got, td.HasSuffixNorm(expected, normalizers...), args...)
+//
+// See [HasSuffixNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpHasSuffixNorm(t TestingT, got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, HasSuffixNorm(expected, normalizers...), args...)
+}
+
+// CmpIf is a shortcut for:
+//
+//	td.Cmp(t, got, td.If(condition, thenValue, elseValue), args...)
+//
+// See [If] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpIf(t TestingT, got, condition, thenValue, elseValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, If(condition, thenValue, elseValue), args...)
+}
+
+// CmpImage is a shortcut for:
+//
+//	td.Cmp(t, got, td.Image(expectedImage, opts), args...)
+//
+// See [Image] for details.
+//
+// [Image] optional parameter opts is here mandatory.
+// td.ImageOptions{} value should be passed to mimic its absence in
+// original [Image] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpImage(t TestingT, got, expectedImage any, opts ImageOptions, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Image(expectedImage, opts), args...)
+}
+
 // CmpIsa is a shortcut for:
 //
 //	td.Cmp(t, got, td.Isa(model), args...)
@@ -697,6 +942,48 @@ func CmpMapEach(t TestingT, got, expectedValue any, args ...any) bool {
 	return Cmp(t, got, MapEach(expectedValue), args...)
 }
 
+// CmpMax is a shortcut for:
+//
+//	td.Cmp(t, got, td.Max(fieldsPath, expectedValue), args...)
+//
+// See [Max] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpMax(t TestingT, got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Max(fieldsPath, expectedValue), args...)
+}
+
+// CmpMin is a shortcut for:
+//
+//	td.Cmp(t, got, td.Min(fieldsPath, expectedValue), args...)
+//
+// See [Min] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpMin(t TestingT, got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Min(fieldsPath, expectedValue), args...)
+}
+
 // CmpN is a shortcut for:
 //
 //	td.Cmp(t, got, td.N(num, tolerance), args...)
@@ -978,6 +1265,27 @@ func CmpRe(t TestingT, got, reg, capture any, args ...any) bool {
 	return Cmp(t, got, Re(reg, capture), args...)
 }
 
+// CmpReaderContent is a shortcut for:
+//
+//	td.Cmp(t, got, td.ReaderContent(expectedValue), args...)
+//
+// See [ReaderContent] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpReaderContent(t TestingT, got, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, ReaderContent(expectedValue), args...)
+}
+
 // CmpReAll is a shortcut for:
 //
 //	td.Cmp(t, got, td.ReAll(reg, capture), args...)
@@ -999,6 +1307,31 @@ func CmpReAll(t TestingT, got, reg, capture any, args ...any) bool {
 	return Cmp(t, got, ReAll(reg, capture), args...)
 }
 
+// CmpRecent is a shortcut for:
+//
+//	td.Cmp(t, got, td.Recent(delta, now), args...)
+//
+// See [Recent] for details.
+//
+// [Recent] optional parameter now is here mandatory.
+// nil value should be passed to mimic its absence in
+// original [Recent] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpRecent(t TestingT, got any, delta time.Duration, now func() time.Time, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Recent(delta, now), args...)
+}
+
 // CmpRecv is a shortcut for:
 //
 //	td.Cmp(t, got, td.Recv(expectedValue, timeout), args...)
@@ -1024,6 +1357,102 @@ func CmpRecv(t TestingT, got, expectedValue any, timeout time.Duration, args ...
 	return Cmp(t, got, Recv(expectedValue, timeout), args...)
 }
 
+// CmpRecvAll is a shortcut for:
+//
+//	td.Cmp(t, got, td.RecvAll(expectedValue, timeout), args...)
+//
+// See [RecvAll] for details.
+//
+// [RecvAll] optional parameter timeout is here mandatory.
+// 0 value should be passed to mimic its absence in
+// original [RecvAll] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpRecvAll(t TestingT, got, expectedValue any, timeout time.Duration, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, RecvAll(expectedValue, timeout), args...)
+}
+
+// CmpRecvN is a shortcut for:
+//
+//	td.Cmp(t, got, td.RecvN(n, expectedValue, timeout), args...)
+//
+// See [RecvN] for details.
+//
+// [RecvN] optional parameter timeout is here mandatory.
+// 0 value should be passed to mimic its absence in
+// original [RecvN] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpRecvN(t TestingT, got any, n int, expectedValue any, timeout time.Duration, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, RecvN(n, expectedValue, timeout), args...)
+}
+
+// CmpRecvWithin is a shortcut for:
+//
+//	td.Cmp(t, got, td.RecvWithin(min, max, expectedValue), args...)
+//
+// See [RecvWithin] for details.
+//
+// [RecvWithin] optional parameter expectedValue is here mandatory.
+// td.Ignore() value should be passed to mimic its absence in
+// original [RecvWithin] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpRecvWithin(t TestingT, got any, min, max time.Duration, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, RecvWithin(min, max, expectedValue), args...)
+}
+
+// CmpSameInstant is a shortcut for:
+//
+//	td.Cmp(t, got, td.SameInstant(expectedTime), args...)
+//
+// See [SameInstant] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSameInstant(t TestingT, got, expectedTime any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, SameInstant(expectedTime), args...)
+}
+
 // CmpSet is a shortcut for:
 //
 //	td.Cmp(t, got, td.Set(expectedItems...), args...)
@@ -1087,6 +1516,48 @@ func CmpSlice(t TestingT, got, model any, expectedEntries ArrayEntries, args ...
 	return Cmp(t, got, Slice(model, expectedEntries), args...)
 }
 
+// CmpSliceHasPrefix is a shortcut for:
+//
+//	td.Cmp(t, got, td.SliceHasPrefix(expectedItems...), args...)
+//
+// See [SliceHasPrefix] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSliceHasPrefix(t TestingT, got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, SliceHasPrefix(expectedItems...), args...)
+}
+
+// CmpSliceHasSuffix is a shortcut for:
+//
+//	td.Cmp(t, got, td.SliceHasSuffix(expectedItems...), args...)
+//
+// See [SliceHasSuffix] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSliceHasSuffix(t TestingT, got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, SliceHasSuffix(expectedItems...), args...)
+}
+
 // CmpSmuggle is a shortcut for:
 //
 //	td.Cmp(t, got, td.Smuggle(fn, expectedValue), args...)
@@ -1154,6 +1625,48 @@ func CmpString(t TestingT, got any, expected string, args ...any) bool {
 	return Cmp(t, got, String(expected), args...)
 }
 
+// CmpStringFold is a shortcut for:
+//
+//	td.Cmp(t, got, td.StringFold(expected), args...)
+//
+// See [StringFold] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpStringFold(t TestingT, got any, expected string, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, StringFold(expected), args...)
+}
+
+// CmpStringNorm is a shortcut for:
+//
+//	td.Cmp(t, got, td.StringNorm(expected, normalizers...), args...)
+//
+// See [StringNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpStringNorm(t TestingT, got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, StringNorm(expected, normalizers...), args...)
+}
+
 // CmpStruct is a shortcut for:
 //
 //	td.Cmp(t, got, td.Struct(model, expectedFields), args...)
@@ -1242,6 +1755,27 @@ func CmpSubMapOf(t TestingT, got, model any, expectedEntries MapEntries, args ..
 	return Cmp(t, got, SubMapOf(model, expectedEntries), args...)
 }
 
+// CmpSubsequence is a shortcut for:
+//
+//	td.Cmp(t, got, td.Subsequence(expectedItems...), args...)
+//
+// See [Subsequence] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSubsequence(t TestingT, got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Subsequence(expectedItems...), args...)
+}
+
 // CmpSubSetOf is a shortcut for:
 //
 //	td.Cmp(t, got, td.SubSetOf(expectedItems...), args...)
@@ -1263,6 +1797,27 @@ func CmpSubSetOf(t TestingT, got any, expectedItems []any, args ...any) bool {
 	return Cmp(t, got, SubSetOf(expectedItems...), args...)
 }
 
+// CmpSum is a shortcut for:
+//
+//	td.Cmp(t, got, td.Sum(fieldsPath, expectedValue), args...)
+//
+// See [Sum] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSum(t TestingT, got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Sum(fieldsPath, expectedValue), args...)
+}
+
 // CmpSuperBagOf is a shortcut for:
 //
 //	td.Cmp(t, got, td.SuperBagOf(expectedItems...), args...)
@@ -1368,6 +1923,48 @@ func CmpSuperSliceOf(t TestingT, got, model any, expectedEntries ArrayEntries, a
 	return Cmp(t, got, SuperSliceOf(model, expectedEntries), args...)
 }
 
+// CmpSwitch is a shortcut for:
+//
+//	td.Cmp(t, got, td.Switch(cases...), args...)
+//
+// See [Switch] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpSwitch(t TestingT, got any, cases []SwitchCase, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Switch(cases...), args...)
+}
+
+// CmpTar is a shortcut for:
+//
+//	td.Cmp(t, got, td.Tar(expectedValue), args...)
+//
+// See [Tar] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpTar(t TestingT, got, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Tar(expectedValue), args...)
+}
+
 // CmpTruncTime is a shortcut for:
 //
 //	td.Cmp(t, got, td.TruncTime(expectedTime, trunc), args...)
@@ -1393,6 +1990,48 @@ func CmpTruncTime(t TestingT, got, expectedTime any, trunc time.Duration, args .
 	return Cmp(t, got, TruncTime(expectedTime, trunc), args...)
 }
 
+// CmpUnique is a shortcut for:
+//
+//	td.Cmp(t, got, td.Unique(), args...)
+//
+// See [Unique] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpUnique(t TestingT, got any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Unique(), args...)
+}
+
+// CmpUniqueBy is a shortcut for:
+//
+//	td.Cmp(t, got, td.UniqueBy(keyFn), args...)
+//
+// See [UniqueBy] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpUniqueBy(t TestingT, got, keyFn any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, UniqueBy(keyFn), args...)
+}
+
 // CmpValues is a shortcut for:
 //
 //	td.Cmp(t, got, td.Values(val), args...)
@@ -1414,6 +2053,27 @@ func CmpValues(t TestingT, got, val any, args ...any) bool {
 	return Cmp(t, got, Values(val), args...)
 }
 
+// CmpWithinDuration is a shortcut for:
+//
+//	td.Cmp(t, got, td.WithinDuration(expectedTime, delta), args...)
+//
+// See [WithinDuration] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpWithinDuration(t TestingT, got, expectedTime any, delta time.Duration, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, WithinDuration(expectedTime, delta), args...)
+}
+
 // CmpZero is a shortcut for:
 //
 //	td.Cmp(t, got, td.Zero(), args...)
@@ -1434,3 +2094,24 @@ func CmpZero(t TestingT, got any, args ...any) bool {
 	t.Helper()
 	return Cmp(t, got, Zero(), args...)
 }
+
+// CmpZip is a shortcut for:
+//
+//	td.Cmp(t, got, td.Zip(expectedValue), args...)
+//
+// See [Zip] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// If t is a [*T] then its Config field is inherited.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func CmpZip(t TestingT, got, expectedValue any, args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, Zip(expectedValue), args...)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build go1.18
+// +build go1.18
+
+package td
+
+// Expectation is a typed expectation of a value of type X. It is
+// used by [CmpOf] so that the compiler can check that got and
+// expected are compatible.
+//
+// Expectations are built using [Expect], [ExpectOp] or typed
+// constructors like [StructOf], [SliceOf] or [MapOf]. The zero value
+// expects the zero value of X.
+type Expectation[X any] struct {
+	expected any
+	set      bool
+}
+
+// Expected returns the value or the [TestDeep] operator wrapped by
+// e, so it can be used in non-typed expectations:
+//
+//	td.Cmp(t, got, td.Struct(Order{}, td.StructFields{
+//	  "User": td.StructOf(User{Name: "Bob"}).Expected(),
+//	}))
+func (e Expectation[X]) Expected() any {
+	if !e.set {
+		var zero X
+		return zero
+	}
+	return e.expected
+}
+
+// Expect returns an [Expectation] of an X value, expecting to be
+// deeply equal to expected.
+//
+//	td.CmpOf(t, got, td.Expect(User{Name: "Bob"}))
+func Expect[X any](expected X) Expectation[X] {
+	return Expectation[X]{expected: expected, set: true}
+}
+
+// ExpectOp returns an [Expectation] of an X value, using operator
+// to check it. As operators are not typed, the compatibility between
+// X and operator cannot be checked at compile time.
+//
+//	td.CmpOf(t, got, td.ExpectOp[int](td.Between(1, 10)))
+func ExpectOp[X any](operator TestDeep) Expectation[X] {
+	return Expectation[X]{expected: operator, set: true}
+}
+
+// typedOperator returns an [Expectation] wrapping op whose location
+// is fixed to report name as operator name, instead of the
+// underlying operator one.
+func typedOperator[X any](name string, op TestDeep) Expectation[X] {
+	loc := op.GetLocation()
+	if loc.IsInitialized() {
+		loc.Func = name
+		loc.BehindCmp = false
+		op.replaceLocation(loc)
+	}
+	return Expectation[X]{expected: op, set: true}
+}
+
+// CmpOf is a typed version of [Cmp]: got and expected types are
+// checked at compile time.
+//
+//	type User struct {
+//	  Name string
+//	  Age  int
+//	}
+//	td.CmpOf(t, user, td.StructOf(User{Name: "Bob"}, td.StructFields{
+//	  "Age": td.Between(40, 45),
+//	}))
+//	td.CmpOf(t, ages, td.SliceOf([]int{12, 34}, nil))
+//	td.CmpOf(t, name, td.Expect("Bob"))
+//
+// As t can be a [*T], its Config field is inherited.
+//
+// args... are optional and allow to name the test, see [Cmp] for
+// details.
+func CmpOf[X any](t TestingT, got X, expected Expectation[X], args ...any) bool {
+	t.Helper()
+	return Cmp(t, got, expected.Expected(), args...)
+}
+
+// StructOf is a typed version of the [Struct] operator.
+func StructOf[X any](model X, expectedFields ...StructFields) Expectation[X] {
+	return typedOperator[X]("StructOf", Struct(model, expectedFields...))
+}
+
+// SStructOf is a typed version of the [SStruct] operator.
+func SStructOf[X any](model X, expectedFields ...StructFields) Expectation[X] {
+	return typedOperator[X]("SStructOf", SStruct(model, expectedFields...))
+}
+
+// SliceOf is a typed version of the [Slice] operator.
+func SliceOf[X any](model []X, expectedEntries ArrayEntries) Expectation[[]X] {
+	return typedOperator[[]X]("SliceOf", Slice(model, expectedEntries))
+}
+
+// BagOf is a typed version of the [Bag] operator. As items are
+// typed, they cannot be operators. Use [ExpectOp] with [Bag] in
+// this case.
+func BagOf[X any](expectedItems ...X) Expectation[[]X] {
+	return typedOperator[[]X]("BagOf", Bag(toAnySlice(expectedItems)...))
+}
+
+// SetOf is a typed version of the [Set] operator. As items are
+// typed, they cannot be operators. Use [ExpectOp] with [Set] in
+// this case.
+func SetOf[X any](expectedItems ...X) Expectation[[]X] {
+	return typedOperator[[]X]("SetOf", Set(toAnySlice(expectedItems)...))
+}
+
+// MapOf is a typed version of the [Map] operator.
+func MapOf[K comparable, V any](model map[K]V, expectedEntries MapEntries) Expectation[map[K]V] {
+	return typedOperator[map[K]V]("MapOf", Map(model, expectedEntries))
+}
+
+func toAnySlice[X any](items []X) []any {
+	anys := make([]any, len(items))
+	for i, item := range items {
+		anys[i] = item
+	}
+	return anys
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build go1.18
+// +build go1.18
+
+package td_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestCmpOf(tt *testing.T) {
+	ttt := test.NewTestingTB(tt.Name())
+
+	type User struct {
+		Name string
+		Age  int
+	}
+
+	t := td.NewT(ttt)
+
+	test.IsTrue(tt, td.CmpOf(t, 42, td.Expect(42)))
+	test.IsFalse(tt, td.CmpOf(t, 42, td.Expect(43)))
+	test.IsTrue(tt, td.CmpOf(t, 42, td.ExpectOp[int](td.Between(40, 45))))
+	test.IsTrue(tt, td.CmpOf(ttt, "", td.Expectation[string]{}))
+	test.IsTrue(tt, td.CmpOf[error](t, nil, td.Expectation[error]{}))
+
+	user := User{Name: "Bob", Age: 42}
+	test.IsTrue(tt, td.CmpOf(t, user, td.Expect(User{Name: "Bob", Age: 42})))
+	test.IsTrue(tt, td.CmpOf(t, user,
+		td.StructOf(User{Name: "Bob"}, td.StructFields{"Age": td.Between(40, 45)})))
+	test.IsTrue(tt, td.CmpOf(t, &user,
+		td.StructOf(&User{Name: "Bob"}, td.StructFields{"Age": td.Gt(40)})))
+	test.IsTrue(tt, td.CmpOf(t, user,
+		td.SStructOf(User{Name: "Bob"}, td.StructFields{"Age": 42})))
+
+	test.IsTrue(tt, td.CmpOf(t, []int{1, 2, 3},
+		td.SliceOf([]int{1}, td.ArrayEntries{1: 2, 2: td.Gt(2)})))
+	test.IsTrue(tt, td.CmpOf(t, []int{1, 2, 3}, td.BagOf(3, 2, 1)))
+	test.IsTrue(tt, td.CmpOf(t, []int{1, 2, 3, 3}, td.SetOf(3, 2, 1)))
+
+	test.IsTrue(tt, td.CmpOf(t, map[string]int{"a": 1, "b": 2},
+		td.MapOf(map[string]int{"a": 1}, td.MapEntries{"b": td.Gt(1)})))
+
+	// Expected allows to reuse typed expectations in non-typed ones
+	test.IsTrue(tt, t.Cmp([]User{user}, td.Bag(td.StructOf(User{Name: "Bob", Age: 42}).Expected())))
+	test.IsTrue(tt, t.Cmp(0, td.Expectation[int]{}.Expected()))
+
+	// Error reports the typed operator and its location
+	ttt.ResetMessages()
+	test.IsFalse(tt, td.CmpOf(t, user,
+		td.StructOf(User{Name: "Bob"}, td.StructFields{"Age": td.Gt(50)})))
+	msg := ttt.LastMessage()
+	test.IsTrue(tt, strings.Contains(msg, "[under operator Gt at cmp_of_118_test.go:"), msg)
+
+	ttt.ResetMessages()
+	test.IsFalse(tt, td.CmpOf(t, []int{1, 2}, td.BagOf(1, 3)))
+	msg = ttt.LastMessage()
+	test.IsTrue(tt, strings.Contains(msg, "[under operator BagOf at cmp_of_118_test.go:"), msg)
+}
@@ -80,6 +80,23 @@ type ContextConfig struct {
 	// most of the time it is a mistake to compare (expected, got)
 	// instead of official (got, expected).
 	TestDeepInGotOK bool
+	// NilEqualsEmpty allows to consider nil and empty slices as equal,
+	// as well as nil and empty maps. It is typically useful when
+	// comparing data decoded from JSON or returned by constructors
+	// that do not care about this distinction. Only typed nil values
+	// are concerned: an untyped nil is never equal to an empty slice
+	// or map.
+	//
+	// See (*T).NilEqualsEmpty method to only apply this property to
+	// some specific types.
+	NilEqualsEmpty bool
+	// TimeEqual allows to compare time.Time values using their Equal
+	// method, so location and monotonic clock reading are ignored,
+	// without enabling UseEqual for all the other types.
+	//
+	// See (*T).TimeEqual method to set this property without
+	// providing a specific configuration.
+	TimeEqual bool
 }
 
 // Equal returns true if both c and o are equal. Only public fields
@@ -91,7 +108,9 @@ func (c ContextConfig) Equal(o ContextConfig) bool {
 		c.UseEqual == o.UseEqual &&
 		c.BeLax == o.BeLax &&
 		c.IgnoreUnexported == o.IgnoreUnexported &&
-		c.TestDeepInGotOK == o.TestDeepInGotOK
+		c.TestDeepInGotOK == o.TestDeepInGotOK &&
+		c.NilEqualsEmpty == o.NilEqualsEmpty &&
+		c.TimeEqual == o.TimeEqual
 }
 
 // OriginalPath returns the current path when the [ContextConfig] has
@@ -133,6 +152,8 @@ var DefaultContextConfig = ContextConfig{
 	BeLax:            false,
 	IgnoreUnexported: false,
 	TestDeepInGotOK:  false,
+	NilEqualsEmpty:   false,
+	TimeEqual:        false,
 }
 
 func (c *ContextConfig) sanitize() {
@@ -171,6 +192,8 @@ func newContextWithConfig(tb testing.TB, config ContextConfig) (ctx ctxerr.Conte
 		BeLax:            config.BeLax,
 		IgnoreUnexported: config.IgnoreUnexported,
 		TestDeepInGotOK:  config.TestDeepInGotOK,
+		NilEqualsEmpty:   config.NilEqualsEmpty,
+		TimeEqual:        config.TimeEqual,
 	}
 
 	ctx.InitErrors()
@@ -186,5 +209,7 @@ func newBooleanContext() ctxerr.Context {
 		BeLax:            DefaultContextConfig.BeLax,
 		IgnoreUnexported: DefaultContextConfig.IgnoreUnexported,
 		TestDeepInGotOK:  DefaultContextConfig.TestDeepInGotOK,
+		NilEqualsEmpty:   DefaultContextConfig.NilEqualsEmpty,
+		TimeEqual:        DefaultContextConfig.TimeEqual,
 	}
 }
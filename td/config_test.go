@@ -29,12 +29,13 @@ func TestContext(t *testing.T) {
 		}
 	}
 
-	nctx = newContext(Require(t).UseEqual().TestDeepInGotOK())
+	nctx = newContext(Require(t).UseEqual().TestDeepInGotOK().TimeEqual())
 	_, ok := nctx.OriginalTB.(*T)
 	test.IsTrue(t, ok)
 	test.IsTrue(t, nctx.FailureIsFatal)
 	test.IsTrue(t, nctx.UseEqual)
 	test.IsTrue(t, nctx.TestDeepInGotOK)
+	test.IsTrue(t, nctx.TimeEqual)
 	test.EqualStr(t, nctx.Path.String(), "DATA")
 
 	nctx = newBooleanContext()
@@ -13,6 +13,7 @@ package td
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/maxatome/go-testdeep/helpers/tdutil"
 	"github.com/maxatome/go-testdeep/internal/color"
@@ -28,6 +29,43 @@ func isNilStr(isNil bool) types.RawString {
 	return "not nil"
 }
 
+// nilEqualsEmpty returns true if nil and empty values of type typ
+// have to be considered as equal.
+func nilEqualsEmpty(ctx ctxerr.Context, typ reflect.Type) bool {
+	return ctx.NilEqualsEmpty || ctx.Hooks.NilEqualsEmpty(typ)
+}
+
+// nilVsEmptyError returns the error raised when got and expected
+// slices or maps differ because one is nil and not the other. If
+// both are empty, the error tells that the NilEqualsEmpty feature
+// would make them equal.
+func nilVsEmptyError(ctx ctxerr.Context, kind string, got, expected reflect.Value) *ctxerr.Error {
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+
+	err := ctxerr.Error{
+		Message: "nil " + kind,
+	}
+	if got.Len() != 0 || expected.Len() != 0 {
+		err.Got = isNilStr(got.IsNil())
+		err.Expected = isNilStr(expected.IsNil())
+	} else {
+		err.Summary = ctxerr.ErrorSummaryItems{
+			{
+				Label: "got",
+				Value: string(isNilStr(got.IsNil())),
+			},
+			{
+				Label:       "expected",
+				Value:       string(isNilStr(expected.IsNil())),
+				Explanation: "both are empty, NilEqualsEmpty would consider them equal",
+			},
+		}
+	}
+	return ctx.CollectError(&err)
+}
+
 func deepValueEqualFinal(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxerr.Error) {
 	err = deepValueEqual(ctx, got, expected)
 	if err == nil {
@@ -172,6 +210,25 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 		})
 	}
 
+	// time.Time values compared using their Equal() method
+	if ctx.TimeEqual && got.Type() == types.Time && expected.Type() == types.Time {
+		gotTime, gotOK := dark.GetInterface(got, true)
+		expectedTime, expectedOK := dark.GetInterface(expected, true)
+		if gotOK && expectedOK {
+			if gotTime.(time.Time).Equal(expectedTime.(time.Time)) {
+				return
+			}
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return ctx.CollectError(&ctxerr.Error{
+				Message:  "got.Equal(expected) failed",
+				Got:      got,
+				Expected: expected,
+			})
+		}
+	}
+
 	// Look for an Equal() method
 	if ctx.UseEqual || ctx.Hooks.UseEqual(got.Type()) {
 		hasEqual, isEqual := isCustomEqual(got, expected)
@@ -222,6 +279,11 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 			})
 		}
 
+		// Different struct types can be compared field by field
+		if mapping, ok := fieldsMapping(ctx, got.Type(), expected.Type()); ok {
+			return deepStructMappedEqual(ctx, got, expected, mapping)
+		}
+
 		if ctx.BeLax && types.IsConvertible(expected, got.Type()) {
 			return deepValueEqual(ctx, got, expected.Convert(got.Type()))
 		}
@@ -249,6 +311,7 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 
 	switch got.Kind() {
 	case reflect.Array:
+		ctx.Parent = got
 		for i, l := 0, got.Len(); i < l; i++ {
 			err = deepValueEqual(ctx.AddArrayIndex(i),
 				got.Index(i), expected.Index(i))
@@ -259,15 +322,8 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 		return
 
 	case reflect.Slice:
-		if got.IsNil() != expected.IsNil() {
-			if ctx.BooleanError {
-				return ctxerr.BooleanError
-			}
-			return ctx.CollectError(&ctxerr.Error{
-				Message:  "nil slice",
-				Got:      isNilStr(got.IsNil()),
-				Expected: isNilStr(expected.IsNil()),
-			})
+		if got.IsNil() != expected.IsNil() && !nilEqualsEmpty(ctx, got.Type()) {
+			return nilVsEmptyError(ctx, "slice", got, expected)
 		}
 
 		var (
@@ -300,6 +356,7 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 			ctx = ctx.ResetPath("TUPLE")
 		}
 
+		ctx.Parent = got
 		for i := 0; i < maxLen; i++ {
 			err = deepValueEqual(ctx.AddArrayIndex(i),
 				got.Index(i), expected.Index(i))
@@ -345,9 +402,11 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 	case reflect.Struct:
 		sType := got.Type()
 		ignoreUnexported := ctx.IgnoreUnexported || ctx.Hooks.IgnoreUnexported(sType)
+		ignoredFields := ctx.Hooks.IgnoredFields(sType)
+		ctx.Parent = got
 		for i, n := 0, got.NumField(); i < n; i++ {
 			field := sType.Field(i)
-			if ignoreUnexported && field.PkgPath != "" {
+			if (ignoreUnexported && field.PkgPath != "") || ignoredFields[field.Name] {
 				continue
 			}
 			err = deepValueEqual(ctx.AddField(field.Name),
@@ -359,15 +418,8 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 		return
 
 	case reflect.Map:
-		if got.IsNil() != expected.IsNil() {
-			if ctx.BooleanError {
-				return ctxerr.BooleanError
-			}
-			return ctx.CollectError(&ctxerr.Error{
-				Message:  "nil map",
-				Got:      isNilStr(got.IsNil()),
-				Expected: isNilStr(expected.IsNil()),
-			})
+		if got.IsNil() != expected.IsNil() && !nilEqualsEmpty(ctx, got.Type()) {
+			return nilVsEmptyError(ctx, "map", got, expected)
 		}
 
 		// Shortcut in boolean context
@@ -382,6 +434,7 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 		var notFoundKeys []reflect.Value
 		foundKeys := map[any]bool{}
 
+		ctx.Parent = got
 		for _, vkey := range tdutil.MapSortedKeys(expected) {
 			gotValue := got.MapIndex(vkey)
 			if !gotValue.IsValid() {
@@ -463,8 +516,105 @@ func deepValueEqual(ctx ctxerr.Context, got, expected reflect.Value) (err *ctxer
 	}
 }
 
+// fieldsMapping returns the fields mapping to use to compare got and
+// expected types, and true if such mapping exists. got and expected
+// types can be both structs or both pointers on structs.
+func fieldsMapping(ctx ctxerr.Context, got, expected reflect.Type) (map[string]string, bool) {
+	if got.Kind() == reflect.Ptr && expected.Kind() == reflect.Ptr {
+		got, expected = got.Elem(), expected.Elem()
+	}
+	if got.Kind() != reflect.Struct || expected.Kind() != reflect.Struct {
+		return nil, false
+	}
+	return ctx.Hooks.FieldsMapping(got, expected)
+}
+
+// deepStructMappedEqual compares 2 structs (or pointers on structs)
+// of different types, field by field, using mapping to find the
+// expected field corresponding to each got field. Fields values are
+// compared as if BeLax feature was enabled.
+func deepStructMappedEqual(ctx ctxerr.Context, got, expected reflect.Value, mapping map[string]string) *ctxerr.Error {
+	if got.Kind() == reflect.Ptr {
+		if got.IsNil() || expected.IsNil() {
+			if got.IsNil() == expected.IsNil() {
+				return nil
+			}
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return ctx.CollectError(&ctxerr.Error{
+				Message:  "values differ",
+				Got:      got,
+				Expected: expected,
+			})
+		}
+		return deepStructMappedEqual(ctx.AddPtr(1), got.Elem(), expected.Elem(), mapping)
+	}
+
+	ctx.BeLax = true
+
+	gotType, expectedType := got.Type(), expected.Type()
+
+	ignoreUnexported := ctx.IgnoreUnexported || ctx.Hooks.IgnoreUnexported(gotType)
+	ignoredFields := ctx.Hooks.IgnoredFields(gotType)
+
+	res := tdSetResult{Kind: fieldsSetResult, Sort: true}
+	seen := map[string]bool{}
+
+	ctx.Parent = got
+	for i, n := 0, got.NumField(); i < n; i++ {
+		field := gotType.Field(i)
+		if (ignoreUnexported && field.PkgPath != "") || ignoredFields[field.Name] {
+			continue
+		}
+
+		name := field.Name
+		if mappedName, ok := mapping[name]; ok {
+			name = mappedName
+		}
+
+		expectedField, ok := expectedType.FieldByName(name)
+		if !ok {
+			res.Extra = append(res.Extra, reflect.ValueOf(field.Name))
+			continue
+		}
+		seen[expectedField.Name] = true
+
+		err := deepValueEqual(ctx.AddField(field.Name),
+			got.Field(i), expected.FieldByIndex(expectedField.Index))
+		if err != nil {
+			return err
+		}
+	}
+
+	ignoreUnexported = ctx.IgnoreUnexported || ctx.Hooks.IgnoreUnexported(expectedType)
+	ignoredFields = ctx.Hooks.IgnoredFields(expectedType)
+
+	for i, n := 0, expected.NumField(); i < n; i++ {
+		field := expectedType.Field(i)
+		if seen[field.Name] ||
+			(ignoreUnexported && field.PkgPath != "") || ignoredFields[field.Name] {
+			continue
+		}
+		res.Missing = append(res.Missing, reflect.ValueOf(field.Name))
+	}
+
+	if res.IsEmpty() {
+		return nil
+	}
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message: fmt.Sprintf("comparing %s and %s fields", gotType, expectedType),
+		Summary: res.Summary(),
+	})
+}
+
 func deepValueEqualOK(got, expected reflect.Value) bool {
-	return deepValueEqualFinal(newBooleanContext(), got, expected) == nil
+	ctx := newBooleanContext()
+	ctx.Root = got
+	return deepValueEqualFinal(ctx, got, expected) == nil
 }
 
 // EqDeeply returns true if got matches expected. expected can
@@ -489,9 +639,12 @@ func EqDeeply(got, expected any) bool {
 //	if err := td.EqDeeplyError(got, td.HasPrefix("foo")); err != nil {
 //	  // …
 //	}
+//
+// See [Diff] to get a structured view of all mismatches.
 func EqDeeplyError(got, expected any) error {
-	err := deepValueEqualFinal(newContext(nil),
-		reflect.ValueOf(got), reflect.ValueOf(expected))
+	ctx := newContext(nil)
+	ctx.Root = reflect.ValueOf(got)
+	err := deepValueEqualFinal(ctx, ctx.Root, reflect.ValueOf(expected))
 	if err == nil {
 		return nil
 	}
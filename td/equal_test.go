@@ -215,6 +215,15 @@ func TestEqualSlice(t *testing.T) {
 			Expected: mustBe("not nil"),
 		})
 
+	checkError(t, []int{}, ([]int)(nil),
+		expectedError{
+			Message: mustBe("nil slice"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`     got: not nil
+expected: nil
+both are empty, NilEqualsEmpty would consider them equal`),
+		})
+
 	checkError(t, []int{1, 2}, []int{1, 3},
 		expectedError{
 			Message:  mustBe("values differ"),
@@ -365,6 +374,15 @@ func TestEqualMap(t *testing.T) {
 			Expected: mustBe("not nil"),
 		})
 
+	checkError(t, (map[string]int)(nil), map[string]int{},
+		expectedError{
+			Message: mustBe("nil map"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`     got: nil
+expected: not nil
+both are empty, NilEqualsEmpty would consider them equal`),
+		})
+
 	checkError(t, map[string]int{"foo": 1, "bar": 4},
 		map[string]int{"foo": 1, "bar": 5},
 		expectedError{
@@ -860,6 +878,68 @@ func TestUseEqualGlobalVsAnchor(t *testing.T) {
 			}))
 }
 
+func TestNilEqualsEmptyGlobal(t *testing.T) {
+	defer func() { td.DefaultContextConfig.NilEqualsEmpty = false }()
+	td.DefaultContextConfig.NilEqualsEmpty = true
+
+	checkOK(t, []int{}, ([]int)(nil))
+	checkOK(t, ([]int)(nil), []int{})
+	checkOK(t, map[string]int{}, (map[string]int)(nil))
+	checkOK(t, (map[string]int)(nil), map[string]int{})
+
+	// Only typed nil values are concerned, untyped nil is still only
+	// equal to typed nil values when BeLax is enabled
+	checkError(t, []int{}, nil,
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("([]int) {\n}"),
+			Expected: mustBe("nil"),
+		})
+	checkError(t, nil, []int{},
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("([]int) {\n}"),
+		})
+	checkError(t, map[string]int{}, nil,
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(map[string]int) {\n}"),
+			Expected: mustBe("nil"),
+		})
+	checkError(t, ([]int)(nil), nil,
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("([]int) <nil>"),
+			Expected: mustBe("nil"),
+		})
+
+	type MyStruct struct {
+		List []string
+		Map  map[string]int
+	}
+	checkOK(t, MyStruct{}, MyStruct{List: []string{}, Map: map[string]int{}})
+
+	checkError(t, ([]int)(nil), []int{1, 2},
+		expectedError{
+			Message: mustBe("comparing slices, from index #0"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 2 items: (1,
+                  2)`),
+		})
+
+	checkError(t, map[string]int{"foo": 1}, (map[string]int)(nil),
+		expectedError{
+			Message: mustBe("comparing map"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Extra key: ("foo")`),
+		})
+}
+
 func TestBeLaxGlobalt(t *testing.T) {
 	defer func() { td.DefaultContextConfig.BeLax = false }()
 	td.DefaultContextConfig.BeLax = true
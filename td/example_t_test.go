@@ -9,16 +9,23 @@
 package td_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
 	"math"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/maxatome/go-testdeep/td"
@@ -205,6 +212,34 @@ func ExampleT_ArrayEach_typedSlice() {
 	// true
 }
 
+func ExampleT_Avg() {
+	t := td.NewT(&testing.T{})
+
+	type Player struct {
+		Name  string
+		Score int
+	}
+
+	got := []Player{
+		{Name: "Bob", Score: 12},
+		{Name: "Alice", Score: 15},
+	}
+
+	ok := t.Avg(got, "Score", 13.5)
+	fmt.Println("average score is 13.5:", ok)
+
+	ok = t.Avg(got, "Score", td.Between(13.0, 14.0))
+	fmt.Println("average score is between 13 and 14:", ok)
+
+	ok = t.Avg([]int{}, "", td.Ignore())
+	fmt.Println("average of an empty slice:", ok)
+
+	// Output:
+	// average score is 13.5: true
+	// average score is between 13 and 14: true
+	// average of an empty slice: false
+}
+
 func ExampleT_Bag() {
 	t := td.NewT(&testing.T{})
 
@@ -643,6 +678,87 @@ func ExampleT_ContainsKey_nil() {
 	// map contains *byte nil key: false
 }
 
+func ExampleT_ContainsNorm() {
+	t := td.NewT(&testing.T{})
+
+	got := "<p>\n  Hello\n  World!\n</p>\n"
+
+	ok := t.ContainsNorm(got, "hello world", []td.StringNormalizer{td.CollapseSpace, td.FoldCase},
+		"checks %q contains hello world, ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	ok = t.ContainsNorm(got, "Hello World", []td.StringNormalizer{td.CollapseSpace},
+		"checks %q contains Hello World, ignoring spaces", got)
+	fmt.Println(ok)
+
+	ok = t.ContainsNorm(got, "hello world", []td.StringNormalizer{td.CollapseSpace},
+		"checks %q contains hello world, ignoring spaces", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleT_ContainsSeq() {
+	t := td.NewT(&testing.T{})
+
+	got := []string{"start", "login", "read", "write", "logout", "stop"}
+
+	ok := t.ContainsSeq(got, []any{"read", "write"},
+		"checks read is immediately followed by write")
+	fmt.Println(ok)
+
+	ok = t.ContainsSeq(got, []any{td.HasPrefix("log"), "read"},
+		"checks read immediately follows a log* event")
+	fmt.Println(ok)
+
+	ok = t.ContainsSeq(got, []any{"login", "write"},
+		"checks write is immediately following login")
+	fmt.Println(ok)
+
+	// When expected is already a non-[]any slice, it cannot be
+	// flattened directly using expected... without copying it to a new
+	// []any slice, then use td.Flatten!
+	expected := []string{"logout", "stop"}
+	ok = t.ContainsSeq(got, []any{td.Flatten(expected)},
+		"checks got contains logout and stop contiguously")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+	// true
+}
+
+func ExampleT_Count() {
+	t := td.NewT(&testing.T{})
+
+	got := []int{-3, -2, -1, 0, 1, 2, 3}
+
+	ok := t.Count(got, td.Gt(0), 3)
+	fmt.Println("3 positive numbers:", ok)
+
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	ok = t.Count(got, isEven, td.Gte(3))
+	fmt.Println("at least 3 even numbers:", ok)
+
+	ok = t.Count(got, td.Gt(10), 0)
+	fmt.Println("no numbers > 10:", ok)
+
+	ok = t.Count(map[string]int{"a": 1, "b": 12}, td.Gt(10), 1)
+	fmt.Println("1 map value > 10:", ok)
+
+	// Output:
+	// 3 positive numbers: true
+	// at least 3 even numbers: true
+	// no numbers > 10: true
+	// 1 map value > 10: true
+}
+
 func ExampleT_Empty() {
 	t := td.NewT(&testing.T{})
 
@@ -749,6 +865,34 @@ func ExampleT_CmpErrorIs() {
 	// err1 is err: false
 }
 
+func ExampleT_File() {
+	t := td.NewT(&testing.T{})
+
+	// A directory path (string) or any fs.FS can be compared
+	got := fstest.MapFS{
+		"go.mod":      {Data: []byte("module example.com/foo\n")},
+		"cmd/main.go": {Data: []byte("package main\n"), Mode: 0o755},
+	}
+
+	ok := t.File(got, "go.mod", "module example.com/foo\n")
+	fmt.Println("go.mod content:", ok)
+
+	ok = t.File(got, "go.mod", td.HasPrefix("module "))
+	fmt.Println("go.mod content starts with module:", ok)
+
+	ok = t.File(got, "cmd/main.go", td.FileEntry{
+		Content: td.Contains("package main"),
+		Mode:    0o755,
+		Size:    td.Lt(100),
+	})
+	fmt.Println("cmd/main.go content, mode & size:", ok)
+
+	// Output:
+	// go.mod content: true
+	// go.mod content starts with module: true
+	// cmd/main.go content, mode & size: true
+}
+
 func ExampleT_First_classic() {
 	t := td.NewT(&testing.T{})
 
@@ -831,6 +975,43 @@ func ExampleT_First_struct() {
 	// first person.Age > 30 → Bob, using JSONPointer: true
 }
 
+func ExampleT_FS() {
+	t := td.NewT(&testing.T{})
+
+	// A directory path (string) or any fs.FS can be compared
+	got := fstest.MapFS{
+		"README.md":   {Data: []byte("# Foo\n")},
+		"go.mod":      {Data: []byte("module example.com/foo\n")},
+		"cmd/main.go": {Data: []byte("package main\n"), Mode: 0o755},
+	}
+
+	ok := t.FS(got, map[string]any{
+		"README.md":   td.HasPrefix("# "),
+		"go.mod":      "module example.com/foo\n",
+		"cmd/main.go": td.FileEntry{Content: td.Contains("package main"), Mode: 0o755},
+	})
+	fmt.Println("whole tree:", ok)
+
+	ok = t.FS(got, map[string]any{
+		"README.md": td.Ignore(),
+		"go.mod":    td.Ignore(),
+	})
+	fmt.Println("cmd/main.go is unexpected:", !ok)
+
+	ok = t.FS(got, map[string]any{
+		"README.md":   td.Ignore(),
+		"go.mod":      td.Ignore(),
+		"cmd/main.go": td.Ignore(),
+		"LICENSE":     td.Ignore(),
+	})
+	fmt.Println("LICENSE is missing:", !ok)
+
+	// Output:
+	// whole tree: true
+	// cmd/main.go is unexpected: true
+	// LICENSE is missing: true
+}
+
 func ExampleT_Grep_classic() {
 	t := td.NewT(&testing.T{})
 
@@ -1026,6 +1207,24 @@ func ExampleT_HasPrefix_error() {
 	// true
 }
 
+func ExampleT_HasPrefixNorm() {
+	t := td.NewT(&testing.T{})
+
+	got := "HELLO  World!"
+
+	ok := t.HasPrefixNorm(got, "hello w", []td.StringNormalizer{td.CollapseSpace, td.FoldCase},
+		"checks %q starts with hello w, ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	ok = t.HasPrefixNorm(got, "hello w", []td.StringNormalizer{td.FoldCase},
+		"checks %q starts with hello w, ignoring case", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_HasSuffix() {
 	t := td.NewT(&testing.T{})
 
@@ -1067,6 +1266,107 @@ func ExampleT_HasSuffix_error() {
 	// true
 }
 
+func ExampleT_HasSuffixNorm() {
+	t := td.NewT(&testing.T{})
+
+	got := "Hello  WORLD!\n"
+
+	ok := t.HasSuffixNorm(got, "o world!", []td.StringNormalizer{td.CollapseSpace, td.FoldCase},
+		"checks %q ends with o world!, ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	ok = t.HasSuffixNorm(got, "o world!", []td.StringNormalizer{td.FoldCase},
+		"checks %q ends with o world!, ignoring case", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_If() {
+	t := td.NewT(&testing.T{})
+
+	type Response struct {
+		Status string
+		Error  string
+	}
+
+	check := td.If(
+		td.Smuggle("Status", "error"),
+		td.Smuggle("Error", td.NotEmpty()),
+		td.Smuggle("Error", td.Empty()),
+	)
+
+	got := Response{Status: "error", Error: "not found"}
+	ok := t.Cmp(got, check, "checks error response")
+	fmt.Println(ok)
+
+	got = Response{Status: "ok"}
+	ok = t.Cmp(got, check, "checks success response")
+	fmt.Println(ok)
+
+	got = Response{Status: "error"}
+	ok = t.Cmp(got, check, "checks error response without error")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleT_Image() {
+	t := td.NewT(&testing.T{})
+
+	expected := image.NewGray(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			expected.SetGray(x, y, color.Gray{Y: uint8(x * 20)})
+		}
+	}
+
+	// got can be an image.Image, an encoded image, an io.Reader or a
+	// file path
+	gotImg := image.NewGray(expected.Bounds())
+	copy(gotImg.Pix, expected.Pix)
+	gotImg.SetGray(0, 0, color.Gray{Y: 3})   // slightly different
+	gotImg.SetGray(9, 9, color.Gray{Y: 255}) // completely different
+	var got bytes.Buffer
+	png.Encode(&got, gotImg) //nolint: errcheck
+
+	// On failure, a diff image is written in DiffDir, defaulting to
+	// t.TempDir()
+	diffDir, err := os.MkdirTemp("", "example")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(diffDir)
+
+	ok := t.Image(got.Bytes(), expected, td.ImageOptions{
+		DiffDir: diffDir,
+	})
+	fmt.Println("strictly equal:", ok)
+
+	ok = t.Image(got.Bytes(), expected, td.ImageOptions{
+		Tolerance: 5,
+		DiffDir:   diffDir,
+	})
+	fmt.Println("equal with a tolerance of 5:", ok)
+
+	ok = t.Image(got.Bytes(), expected, td.ImageOptions{
+		Tolerance:    5,
+		MaxDiffRatio: 0.01,
+		DiffDir:      diffDir,
+	})
+	fmt.Println("at most 1% of pixels differ:", ok)
+
+	// Output:
+	// strictly equal: false
+	// equal with a tolerance of 5: false
+	// at most 1% of pixels differ: true
+}
+
 func ExampleT_Isa() {
 	t := td.NewT(&testing.T{})
 
@@ -1955,6 +2255,62 @@ func ExampleT_MapEach_typedMap() {
 	// true
 }
 
+func ExampleT_Max() {
+	t := td.NewT(&testing.T{})
+
+	type Player struct {
+		Name  string
+		Score int
+	}
+
+	got := []Player{
+		{Name: "Bob", Score: 42},
+		{Name: "Alice", Score: 100},
+	}
+
+	ok := t.Max(got, "Score", 100)
+	fmt.Println("best score is 100:", ok)
+
+	ok = t.Max(got, "Name", "Bob")
+	fmt.Println("last name is Bob:", ok)
+
+	ok = t.Max([]float64{1.5, -2, 3.25}, "", td.Lt(3.0))
+	fmt.Println("max is < 3:", ok)
+
+	// Output:
+	// best score is 100: true
+	// last name is Bob: true
+	// max is < 3: false
+}
+
+func ExampleT_Min() {
+	t := td.NewT(&testing.T{})
+
+	type Player struct {
+		Name  string
+		Score int
+	}
+
+	got := []Player{
+		{Name: "Bob", Score: 42},
+		{Name: "Alice", Score: 100},
+	}
+
+	ok := t.Min(got, "Score", 42)
+	fmt.Println("worst score is 42:", ok)
+
+	ok = t.Min(got, "Name", "Alice")
+	fmt.Println("first name is Alice:", ok)
+
+	ok = t.Min(map[string]int{"a": 3, "b": -1}, "", td.Gte(0))
+	fmt.Println("min map value is ≥ 0:", ok)
+
+	// Output:
+	// worst score is 42: true
+	// first name is Alice: true
+	// min map value is ≥ 0: false
+}
+
 func ExampleT_N() {
 	t := td.NewT(&testing.T{})
 
@@ -2479,6 +2835,24 @@ func ExampleT_Re_compiledCapture() {
 	// false
 }
 
+func ExampleT_ReaderContent() {
+	t := td.NewT(&testing.T{})
+
+	ok := t.ReaderContent(strings.NewReader("foobar"), "foobar")
+	fmt.Println("content is foobar:", ok)
+
+	ok = t.ReaderContent(strings.NewReader("foobar"), td.HasPrefix("foo"))
+	fmt.Println("content starts with foo:", ok)
+
+	ok = t.ReaderContent(bytes.NewBufferString(`{"id":12,"name":"Bob"}`), td.JSON(`{"id": NotZero(), "name": "Bob"}`))
+	fmt.Println("JSON content:", ok)
+
+	// Output:
+	// content is foobar: true
+	// content starts with foo: true
+	// JSON content: true
+}
+
 func ExampleT_ReAll_capture() {
 	t := td.NewT(&testing.T{})
 
@@ -2569,6 +2943,35 @@ func ExampleT_ReAll_compiledCaptureComplex() {
 	// false
 }
 
+func ExampleT_Recent() {
+	t := td.NewT(&testing.T{})
+
+	got := time.Now().Add(-2 * time.Second)
+
+	ok := t.Recent(got, 5*time.Second, nil,
+		"checks %v is less than 5 seconds away from now", got)
+	fmt.Println(ok)
+
+	ok = t.Recent(got, time.Second, nil,
+		"checks %v is less than 1 second away from now", got)
+	fmt.Println(ok)
+
+	// Use a fake clock
+	clock := func() time.Time {
+		return time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+	}
+	got = time.Date(2023, time.March, 9, 1, 2, 0, 0, time.UTC)
+
+	ok = t.Recent(got, 5*time.Second, clock,
+		"checks %v is less than 5 seconds away from clock", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// true
+}
+
 func ExampleT_Recv_basic() {
 	t := td.NewT(&testing.T{})
 
@@ -2716,6 +3119,110 @@ func ExampleT_Recv_nilChannel() {
 	// is a nil channel closed: false
 }
 
+func ExampleT_RecvAll() {
+	t := td.NewT(&testing.T{})
+
+	got := make(chan int, 3)
+	got <- 3
+	got <- 1
+	got <- 2
+
+	ok := t.RecvAll(got, td.Bag(1, 2, 3), 0)
+	fmt.Println("all received but not closed:", ok)
+
+	got <- 4
+	close(got)
+
+	ok = t.RecvAll(got, []int{4}, 0)
+	fmt.Println("4 then closed:", ok)
+
+	ok = t.RecvAll(got, td.Empty(), 0)
+	fmt.Println("still closed:", ok)
+
+	// Output:
+	// all received but not closed: false
+	// 4 then closed: true
+	// still closed: true
+}
+
+func ExampleT_RecvN() {
+	t := td.NewT(&testing.T{})
+
+	got := make(chan int, 5)
+	got <- 3
+	got <- 1
+	got <- 2
+
+	ok := t.RecvN(got, 2, []int{3, 1}, 0)
+	fmt.Println("3 then 1 received:", ok)
+
+	ok = t.RecvN(got, 2, td.Bag(1, 2), 0)
+	fmt.Println("2 values received:", ok)
+
+	go func() {
+		got <- 5
+		got <- 4
+	}()
+
+	ok = t.RecvN(got, 2, td.Bag(4, 5), time.Second)
+	fmt.Println("4 and 5 received w/1s timeout:", ok)
+
+	// Output:
+	// 3 then 1 received: true
+	// 2 values received: false
+	// 4 and 5 received w/1s timeout: true
+}
+
+func ExampleT_RecvWithin() {
+	t := td.NewT(&testing.T{})
+
+	got := make(chan int, 1)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		got <- 42
+	}()
+
+	ok := t.RecvWithin(got, 10*time.Millisecond, time.Second, 42)
+	fmt.Println("42 received within [10ms, 1s]:", ok)
+
+	got <- 42
+	ok = t.RecvWithin(got, 10*time.Millisecond, time.Second, td.Ignore())
+	fmt.Println("received within [10ms, 1s]:", ok)
+
+	// Output:
+	// 42 received within [10ms, 1s]: true
+	// received within [10ms, 1s]: false
+}
+
+func ExampleT_SameInstant() {
+	t := td.NewT(&testing.T{})
+
+	got := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+
+	// Location does not matter
+	expected := got.In(time.FixedZone("Paris", 3600))
+	ok := t.SameInstant(got, expected,
+		"checks %v is the same instant as %v", got, expected)
+	fmt.Println(ok)
+
+	// Monotonic part does not matter
+	now := time.Now()
+	ok = t.SameInstant(now.Round(0), now,
+		"checks %v ignoring monotonic part", now)
+	fmt.Println(ok)
+
+	expected = got.Add(time.Nanosecond)
+	ok = t.SameInstant(got, expected,
+		"checks %v is the same instant as %v", got, expected)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleT_Set() {
 	t := td.NewT(&testing.T{})
 
@@ -2864,6 +3371,52 @@ func ExampleT_Slice_typedSlice() {
 	// true
 }
 
+func ExampleT_SliceHasPrefix() {
+	t := td.NewT(&testing.T{})
+
+	got := []string{"start", "login", "read", "logout", "stop"}
+
+	ok := t.SliceHasPrefix(got, []any{"start", "login"},
+		"checks got starts with start and login")
+	fmt.Println(ok)
+
+	ok = t.SliceHasPrefix(got, []any{"start", td.Re(`^log(in|out)$`)},
+		"checks got starts with start then a log* event")
+	fmt.Println(ok)
+
+	ok = t.SliceHasPrefix(got, []any{"login"},
+		"checks got starts with login")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleT_SliceHasSuffix() {
+	t := td.NewT(&testing.T{})
+
+	got := []string{"start", "login", "read", "logout", "stop"}
+
+	ok := t.SliceHasSuffix(got, []any{"logout", "stop"},
+		"checks got ends with logout and stop")
+	fmt.Println(ok)
+
+	ok = t.SliceHasSuffix(got, []any{td.HasPrefix("log"), "stop"},
+		"checks got ends with a log* event then stop")
+	fmt.Println(ok)
+
+	ok = t.SliceHasSuffix(got, []any{"logout"},
+		"checks got ends with logout")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleT_Smuggle_convert() {
 	t := td.NewT(&testing.T{})
 
@@ -3318,6 +3871,61 @@ func ExampleT_String_error() {
 	// true
 }
 
+func ExampleT_StringFold() {
+	t := td.NewT(&testing.T{})
+
+	got := "Hello World!"
+
+	ok := t.StringFold(got, "hello WORLD!",
+		"checks %q ignoring case", got)
+	fmt.Println(ok)
+
+	// Also works with []byte, error and fmt.Stringer
+	err := errors.New("Access Denied")
+	ok = t.StringFold(err, "access denied",
+		"checks error %q ignoring case", err)
+	fmt.Println(ok)
+
+	ok = t.StringFold(got, "hello world",
+		"checks %q ignoring case", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleT_StringNorm() {
+	t := td.NewT(&testing.T{})
+
+	got := "  Hello \n  World!  "
+
+	ok := t.StringNorm(got, "Hello World!", []td.StringNormalizer{td.CollapseSpace},
+		"checks %q ignoring spaces", got)
+	fmt.Println(ok)
+
+	ok = t.StringNorm(got, "hello world!", []td.StringNormalizer{td.CollapseSpace, td.FoldCase},
+		"checks %q ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	// Any func(string) string function can be used
+	noDash := strings.NewReplacer("-", "").Replace
+	ok = t.StringNorm("2023-03-09", "20230309", []td.StringNormalizer{noDash},
+		"checks date ignoring dashes")
+	fmt.Println(ok)
+
+	ok = t.StringNorm(got, "hello world!", []td.StringNormalizer{td.CollapseSpace},
+		"checks %q ignoring spaces", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// true
+	// false
+}
+
 func ExampleT_Struct() {
 	t := td.NewT(&testing.T{})
 
@@ -3678,6 +4286,38 @@ func ExampleT_SubMapOf_typedMap() {
 	// true
 }
 
+func ExampleT_Subsequence() {
+	t := td.NewT(&testing.T{})
+
+	got := []string{"start", "login", "read", "write", "logout", "stop"}
+
+	ok := t.Subsequence(got, []any{"login", "write", "logout"},
+		"checks login, write and logout appear in this order")
+	fmt.Println(ok)
+
+	ok = t.Subsequence(got, []any{"login", td.HasPrefix("wr"), "stop"},
+		"checks login, a wr* event and stop appear in this order")
+	fmt.Println(ok)
+
+	ok = t.Subsequence(got, []any{"logout", "login"},
+		"checks logout and login appear in this order")
+	fmt.Println(ok)
+
+	// When expected is already a non-[]any slice, it cannot be
+	// flattened directly using expected... without copying it to a new
+	// []any slice, then use td.Flatten!
+	expected := []string{"start", "stop"}
+	ok = t.Subsequence(got, []any{td.Flatten(expected)},
+		"checks start and stop appear in this order")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+	// true
+}
+
 func ExampleT_SubSetOf() {
 	t := td.NewT(&testing.T{})
 
@@ -3708,6 +4348,39 @@ func ExampleT_SubSetOf() {
 	// true
 }
 
+func ExampleT_Sum() {
+	t := td.NewT(&testing.T{})
+
+	type Line struct {
+		Label string
+		Price float64
+		Qty   int
+	}
+
+	got := []Line{
+		{Label: "pen", Price: 12.5, Qty: 2},
+		{Label: "book", Price: 87.4, Qty: 1},
+	}
+
+	ok := t.Sum(got, "Price", td.N(99.9, 0.01))
+	fmt.Println("total price is 99.9:", ok)
+
+	ok = t.Sum(got, "Qty", 3)
+	fmt.Println("total quantity is 3:", ok)
+
+	ok = t.Sum([]int{1, 2, 3}, "", 6)
+	fmt.Println("sum of ints is 6:", ok)
+
+	ok = t.Sum([]Line{}, "Qty", 0)
+	fmt.Println("sum of an empty slice is 0:", ok)
+
+	// Output:
+	// total price is 99.9: true
+	// total quantity is 3: true
+	// sum of ints is 6: true
+	// sum of an empty slice is 0: true
+}
+
 func ExampleT_SuperBagOf() {
 	t := td.NewT(&testing.T{})
 
@@ -4047,6 +4720,69 @@ func ExampleT_SuperSliceOf_typedSlice() {
 	// Only check items #0 & #3 of a slice pointer, using nil model: true
 }
 
+func ExampleT_Switch() {
+	t := td.NewT(&testing.T{})
+
+	cases := []td.SwitchCase{
+		td.Case("", td.HasPrefix("id-")),
+		td.Case(0, td.Between(1, 1000)),
+		td.Case(td.Gt(100.0), td.Lt(200.0)),
+		td.Default(td.Nil()),
+	}
+
+	for _, got := range []any{"id-12", 42, 150.0, nil, 42.0} {
+		ok := t.Switch(got, cases,
+			"checks %v is a valid ID", got)
+		fmt.Printf("%v: %t\n", got, ok)
+	}
+
+	// Output:
+	// id-12: true
+	// 42: true
+	// 150: true
+	// <nil>: true
+	// 42: false
+}
+
+func ExampleT_Tar() {
+	t := td.NewT(&testing.T{})
+
+	// Build a tar archive in memory
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range []struct {
+		name, content string
+		mode          int64
+	}{
+		{name: "bin/tool", content: "#!/bin/sh\n", mode: 0o755},
+		{name: "README.md", content: "# Tool\nUsage: tool\n", mode: 0o644},
+	} {
+		tw.WriteHeader(&tar.Header{ //nolint: errcheck
+			Name: file.name,
+			Mode: file.mode,
+			Size: int64(len(file.content)),
+		})
+		tw.Write([]byte(file.content)) //nolint: errcheck
+	}
+	tw.Close() //nolint: errcheck
+
+	// got can be a []byte, an io.ReaderAt, an io.Reader or a file path
+	got := buf.Bytes()
+
+	ok := t.Tar(got, td.Keys(td.Bag("README.md", "bin/tool")))
+	fmt.Println("contains README.md & bin/tool:", ok)
+
+	ok = t.Tar(got, td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+		"bin/tool":  td.Smuggle("Mode", td.Code(func(m fs.FileMode) bool { return m&0o111 != 0 })),
+		"README.md": td.Smuggle("Content", td.Contains("Usage")),
+	}))
+	fmt.Println("bin/tool is executable & README.md documents usage:", ok)
+
+	// Output:
+	// contains README.md & bin/tool: true
+	// bin/tool is executable & README.md documents usage: true
+}
+
 func ExampleT_TruncTime() {
 	t := td.NewT(&testing.T{})
 
@@ -4084,6 +4820,58 @@ func ExampleT_TruncTime() {
 	// true
 }
 
+func ExampleT_Unique() {
+	t := td.NewT(&testing.T{})
+
+	got := []int{1, 2, 3, 4}
+	ok := t.Unique(got, "checks %v has no duplicates", got)
+	fmt.Println(ok)
+
+	got = []int{1, 2, 1, 3, 2}
+	ok = t.Unique(got, "checks %v has no duplicates", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_UniqueBy() {
+	t := td.NewT(&testing.T{})
+
+	type Profile struct {
+		Email string
+	}
+	type User struct {
+		ID      int
+		Profile Profile
+	}
+
+	got := []User{
+		{ID: 1, Profile: Profile{Email: "bob@example.com"}},
+		{ID: 2, Profile: Profile{Email: "alice@example.com"}},
+		{ID: 3, Profile: Profile{Email: "Bob@example.com"}},
+	}
+
+	ok := t.UniqueBy(got, "ID", "checks IDs are unique")
+	fmt.Println(ok)
+
+	ok = t.UniqueBy(got, "Profile.Email",
+		"checks emails are unique")
+	fmt.Println(ok)
+
+	ok = t.UniqueBy(got, func(u User) string {
+		return strings.ToLower(u.Profile.Email)
+	},
+		"checks emails are unique, ignoring case")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleT_Values() {
 	t := td.NewT(&testing.T{})
 
@@ -4112,6 +4900,25 @@ func ExampleT_Values() {
 	// Each value is between 1 and 3: true
 }
 
+func ExampleT_WithinDuration() {
+	t := td.NewT(&testing.T{})
+
+	got := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+
+	expected := time.Date(2023, time.March, 9, 1, 2, 0, 0, time.UTC)
+	ok := t.WithinDuration(got, expected, 5*time.Second,
+		"checks %v is less than 5 seconds away from %v", got, expected)
+	fmt.Println(ok)
+
+	ok = t.WithinDuration(got, expected, time.Second,
+		"checks %v is less than 1 second away from %v", got, expected)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_Zero() {
 	t := td.NewT(&testing.T{})
 
@@ -4165,3 +4972,36 @@ func ExampleT_Zero() {
 	// false
 	// true
 }
+
+func ExampleT_Zip() {
+	t := td.NewT(&testing.T{})
+
+	// Build a zip archive in memory
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"README.md": "# Foo\n",
+		"data.json": `{"id": 42, "name": "Bob"}`,
+	} {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content)) //nolint: errcheck
+	}
+	zw.Close() //nolint: errcheck
+
+	// got can be a []byte, an io.ReaderAt, an io.Reader or a file path
+	got := bytes.NewReader(buf.Bytes())
+
+	ok := t.Zip(got, td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+		"README.md": td.Smuggle("Content", td.HasPrefix("# ")),
+		"data.json": td.Smuggle("Content",
+			td.Smuggle(json.RawMessage(nil), td.JSON(`{"id": NotZero(), "name": "Bob"}`))),
+	}))
+	fmt.Println("README.md & data.json are OK:", ok)
+
+	ok = t.Zip(got, td.Len(3))
+	fmt.Println("contains 3 entries:", ok)
+
+	// Output:
+	// README.md & data.json are OK: true
+	// contains 3 entries: false
+}
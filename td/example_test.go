@@ -7,16 +7,23 @@
 package td_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
 	"math"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/maxatome/go-testdeep/td"
@@ -335,6 +342,34 @@ func ExampleArrayEach_typedSlice() {
 	// true
 }
 
+func ExampleAvg() {
+	t := &testing.T{}
+
+	type Player struct {
+		Name  string
+		Score int
+	}
+
+	got := []Player{
+		{Name: "Bob", Score: 12},
+		{Name: "Alice", Score: 15},
+	}
+
+	ok := td.Cmp(t, got, td.Avg("Score", 13.5))
+	fmt.Println("average score is 13.5:", ok)
+
+	ok = td.Cmp(t, got, td.Avg("Score", td.Between(13.0, 14.0)))
+	fmt.Println("average score is between 13 and 14:", ok)
+
+	ok = td.Cmp(t, []int{}, td.Avg("", td.Ignore()))
+	fmt.Println("average of an empty slice:", ok)
+
+	// Output:
+	// average score is 13.5: true
+	// average score is between 13 and 14: true
+	// average of an empty slice: false
+}
+
 func ExampleBag() {
 	t := &testing.T{}
 
@@ -807,6 +842,87 @@ func ExampleContainsKey_nil() {
 	// map contains *byte nil key: false
 }
 
+func ExampleContainsSeq() {
+	t := &testing.T{}
+
+	got := []string{"start", "login", "read", "write", "logout", "stop"}
+
+	ok := td.Cmp(t, got, td.ContainsSeq("read", "write"),
+		"checks read is immediately followed by write")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.ContainsSeq(td.HasPrefix("log"), "read"),
+		"checks read immediately follows a log* event")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.ContainsSeq("login", "write"),
+		"checks write is immediately following login")
+	fmt.Println(ok)
+
+	// When expected is already a non-[]any slice, it cannot be
+	// flattened directly using expected... without copying it to a new
+	// []any slice, then use td.Flatten!
+	expected := []string{"logout", "stop"}
+	ok = td.Cmp(t, got, td.ContainsSeq(td.Flatten(expected)),
+		"checks got contains logout and stop contiguously")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+	// true
+}
+
+func ExampleContainsNorm() {
+	t := &testing.T{}
+
+	got := "<p>\n  Hello\n  World!\n</p>\n"
+
+	ok := td.Cmp(t, got, td.ContainsNorm("hello world", td.CollapseSpace, td.FoldCase),
+		"checks %q contains hello world, ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.ContainsNorm("Hello World", td.CollapseSpace),
+		"checks %q contains Hello World, ignoring spaces", got)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.ContainsNorm("hello world", td.CollapseSpace),
+		"checks %q contains hello world, ignoring spaces", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleCount() {
+	t := &testing.T{}
+
+	got := []int{-3, -2, -1, 0, 1, 2, 3}
+
+	ok := td.Cmp(t, got, td.Count(td.Gt(0), 3))
+	fmt.Println("3 positive numbers:", ok)
+
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	ok = td.Cmp(t, got, td.Count(isEven, td.Gte(3)))
+	fmt.Println("at least 3 even numbers:", ok)
+
+	ok = td.Cmp(t, got, td.Count(td.Gt(10), 0))
+	fmt.Println("no numbers > 10:", ok)
+
+	ok = td.Cmp(t, map[string]int{"a": 1, "b": 12}, td.Count(td.Gt(10), 1))
+	fmt.Println("1 map value > 10:", ok)
+
+	// Output:
+	// 3 positive numbers: true
+	// at least 3 even numbers: true
+	// no numbers > 10: true
+	// 1 map value > 10: true
+}
+
 func ExampleDelay() {
 	t := &testing.T{}
 
@@ -936,6 +1052,71 @@ func ExampleErrorIs() {
 	// err1 is err: false
 }
 
+func ExampleFS() {
+	t := &testing.T{}
+
+	// A directory path (string) or any fs.FS can be compared
+	got := fstest.MapFS{
+		"README.md":   {Data: []byte("# Foo\n")},
+		"go.mod":      {Data: []byte("module example.com/foo\n")},
+		"cmd/main.go": {Data: []byte("package main\n"), Mode: 0o755},
+	}
+
+	ok := td.Cmp(t, got, td.FS(map[string]any{
+		"README.md":   td.HasPrefix("# "),
+		"go.mod":      "module example.com/foo\n",
+		"cmd/main.go": td.FileEntry{Content: td.Contains("package main"), Mode: 0o755},
+	}))
+	fmt.Println("whole tree:", ok)
+
+	ok = td.Cmp(t, got, td.FS(map[string]any{
+		"README.md": td.Ignore(),
+		"go.mod":    td.Ignore(),
+	}))
+	fmt.Println("cmd/main.go is unexpected:", !ok)
+
+	ok = td.Cmp(t, got, td.FS(map[string]any{
+		"README.md":   td.Ignore(),
+		"go.mod":      td.Ignore(),
+		"cmd/main.go": td.Ignore(),
+		"LICENSE":     td.Ignore(),
+	}))
+	fmt.Println("LICENSE is missing:", !ok)
+
+	// Output:
+	// whole tree: true
+	// cmd/main.go is unexpected: true
+	// LICENSE is missing: true
+}
+
+func ExampleFile() {
+	t := &testing.T{}
+
+	// A directory path (string) or any fs.FS can be compared
+	got := fstest.MapFS{
+		"go.mod":      {Data: []byte("module example.com/foo\n")},
+		"cmd/main.go": {Data: []byte("package main\n"), Mode: 0o755},
+	}
+
+	ok := td.Cmp(t, got, td.File("go.mod", "module example.com/foo\n"))
+	fmt.Println("go.mod content:", ok)
+
+	ok = td.Cmp(t, got, td.File("go.mod", td.HasPrefix("module ")))
+	fmt.Println("go.mod content starts with module:", ok)
+
+	ok = td.Cmp(t, got, td.File("cmd/main.go", td.FileEntry{
+		Content: td.Contains("package main"),
+		Mode:    0o755,
+		Size:    td.Lt(100),
+	}))
+	fmt.Println("cmd/main.go content, mode & size:", ok)
+
+	// Output:
+	// go.mod content: true
+	// go.mod content starts with module: true
+	// cmd/main.go content, mode & size: true
+}
+
 func ExampleFirst_classic() {
 	t := &testing.T{}
 
@@ -1250,6 +1431,125 @@ func ExampleGte_string() {
 	// false
 }
 
+func ExampleHasPrefixNorm() {
+	t := &testing.T{}
+
+	got := "HELLO  World!"
+
+	ok := td.Cmp(t, got, td.HasPrefixNorm("hello w", td.CollapseSpace, td.FoldCase),
+		"checks %q starts with hello w, ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.HasPrefixNorm("hello w", td.FoldCase),
+		"checks %q starts with hello w, ignoring case", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleHasSuffixNorm() {
+	t := &testing.T{}
+
+	got := "Hello  WORLD!\n"
+
+	ok := td.Cmp(t, got, td.HasSuffixNorm("o world!", td.CollapseSpace, td.FoldCase),
+		"checks %q ends with o world!, ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.HasSuffixNorm("o world!", td.FoldCase),
+		"checks %q ends with o world!, ignoring case", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleIf() {
+	t := &testing.T{}
+
+	type Response struct {
+		Status string
+		Error  string
+	}
+
+	check := td.If(
+		td.Smuggle("Status", "error"),
+		td.Smuggle("Error", td.NotEmpty()),
+		td.Smuggle("Error", td.Empty()),
+	)
+
+	got := Response{Status: "error", Error: "not found"}
+	ok := td.Cmp(t, got, check, "checks error response")
+	fmt.Println(ok)
+
+	got = Response{Status: "ok"}
+	ok = td.Cmp(t, got, check, "checks success response")
+	fmt.Println(ok)
+
+	got = Response{Status: "error"}
+	ok = td.Cmp(t, got, check, "checks error response without error")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleImage() {
+	t := &testing.T{}
+
+	expected := image.NewGray(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			expected.SetGray(x, y, color.Gray{Y: uint8(x * 20)})
+		}
+	}
+
+	// got can be an image.Image, an encoded image, an io.Reader or a
+	// file path
+	gotImg := image.NewGray(expected.Bounds())
+	copy(gotImg.Pix, expected.Pix)
+	gotImg.SetGray(0, 0, color.Gray{Y: 3})   // slightly different
+	gotImg.SetGray(9, 9, color.Gray{Y: 255}) // completely different
+	var got bytes.Buffer
+	png.Encode(&got, gotImg) //nolint: errcheck
+
+	// On failure, a diff image is written in DiffDir, defaulting to
+	// t.TempDir()
+	diffDir, err := os.MkdirTemp("", "example")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(diffDir)
+
+	ok := td.Cmp(t, got.Bytes(), td.Image(expected, td.ImageOptions{
+		DiffDir: diffDir,
+	}))
+	fmt.Println("strictly equal:", ok)
+
+	ok = td.Cmp(t, got.Bytes(), td.Image(expected, td.ImageOptions{
+		Tolerance: 5,
+		DiffDir:   diffDir,
+	}))
+	fmt.Println("equal with a tolerance of 5:", ok)
+
+	ok = td.Cmp(t, got.Bytes(), td.Image(expected, td.ImageOptions{
+		Tolerance:    5,
+		MaxDiffRatio: 0.01,
+		DiffDir:      diffDir,
+	}))
+	fmt.Println("at most 1% of pixels differ:", ok)
+
+	// Output:
+	// strictly equal: false
+	// equal with a tolerance of 5: false
+	// at most 1% of pixels differ: true
+}
+
 func ExampleIsa() {
 	t := &testing.T{}
 
@@ -2222,6 +2522,62 @@ func ExampleMapEach_typedMap() {
 	// true
 }
 
+func ExampleMax() {
+	t := &testing.T{}
+
+	type Player struct {
+		Name  string
+		Score int
+	}
+
+	got := []Player{
+		{Name: "Bob", Score: 42},
+		{Name: "Alice", Score: 100},
+	}
+
+	ok := td.Cmp(t, got, td.Max("Score", 100))
+	fmt.Println("best score is 100:", ok)
+
+	ok = td.Cmp(t, got, td.Max("Name", "Bob"))
+	fmt.Println("last name is Bob:", ok)
+
+	ok = td.Cmp(t, []float64{1.5, -2, 3.25}, td.Max("", td.Lt(3.0)))
+	fmt.Println("max is < 3:", ok)
+
+	// Output:
+	// best score is 100: true
+	// last name is Bob: true
+	// max is < 3: false
+}
+
+func ExampleMin() {
+	t := &testing.T{}
+
+	type Player struct {
+		Name  string
+		Score int
+	}
+
+	got := []Player{
+		{Name: "Bob", Score: 42},
+		{Name: "Alice", Score: 100},
+	}
+
+	ok := td.Cmp(t, got, td.Min("Score", 42))
+	fmt.Println("worst score is 42:", ok)
+
+	ok = td.Cmp(t, got, td.Min("Name", "Alice"))
+	fmt.Println("first name is Alice:", ok)
+
+	ok = td.Cmp(t, map[string]int{"a": 3, "b": -1}, td.Min("", td.Gte(0)))
+	fmt.Println("min map value is ≥ 0:", ok)
+
+	// Output:
+	// worst score is 42: true
+	// first name is Alice: true
+	// min map value is ≥ 0: false
+}
+
 func ExampleN() {
 	t := &testing.T{}
 
@@ -2840,6 +3196,54 @@ func ExampleReAll_compiledCaptureComplex() {
 	// false
 }
 
+func ExampleReaderContent() {
+	t := &testing.T{}
+
+	ok := td.Cmp(t, strings.NewReader("foobar"), td.ReaderContent("foobar"))
+	fmt.Println("content is foobar:", ok)
+
+	ok = td.Cmp(t, strings.NewReader("foobar"), td.ReaderContent(td.HasPrefix("foo")))
+	fmt.Println("content starts with foo:", ok)
+
+	ok = td.Cmp(t, bytes.NewBufferString(`{"id":12,"name":"Bob"}`),
+		td.ReaderContent(td.JSON(`{"id": NotZero(), "name": "Bob"}`)))
+	fmt.Println("JSON content:", ok)
+
+	// Output:
+	// content is foobar: true
+	// content starts with foo: true
+	// JSON content: true
+}
+
+func ExampleRecent() {
+	t := &testing.T{}
+
+	got := time.Now().Add(-2 * time.Second)
+
+	ok := td.Cmp(t, got, td.Recent(5*time.Second),
+		"checks %v is less than 5 seconds away from now", got)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.Recent(time.Second),
+		"checks %v is less than 1 second away from now", got)
+	fmt.Println(ok)
+
+	// Use a fake clock
+	clock := func() time.Time {
+		return time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+	}
+	got = time.Date(2023, time.March, 9, 1, 2, 0, 0, time.UTC)
+
+	ok = td.Cmp(t, got, td.Recent(5*time.Second, clock),
+		"checks %v is less than 5 seconds away from clock", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// true
+}
+
 func ExampleRecv_basic() {
 	t := &testing.T{}
 
@@ -2987,6 +3391,160 @@ func ExampleRecv_nilChannel() {
 	// is a nil channel closed: false
 }
 
+func ExampleRecvAll() {
+	t := &testing.T{}
+
+	got := make(chan int, 3)
+	got <- 3
+	got <- 1
+	got <- 2
+
+	ok := td.Cmp(t, got, td.RecvAll(td.Bag(1, 2, 3)))
+	fmt.Println("all received but not closed:", ok)
+
+	got <- 4
+	close(got)
+
+	ok = td.Cmp(t, got, td.RecvAll([]int{4}))
+	fmt.Println("4 then closed:", ok)
+
+	ok = td.Cmp(t, got, td.RecvAll(td.Empty()))
+	fmt.Println("still closed:", ok)
+
+	// Output:
+	// all received but not closed: false
+	// 4 then closed: true
+	// still closed: true
+}
+
+func ExampleRecvN() {
+	t := &testing.T{}
+
+	got := make(chan int, 5)
+	got <- 3
+	got <- 1
+	got <- 2
+
+	ok := td.Cmp(t, got, td.RecvN(2, []int{3, 1}))
+	fmt.Println("3 then 1 received:", ok)
+
+	ok = td.Cmp(t, got, td.RecvN(2, td.Bag(1, 2)))
+	fmt.Println("2 values received:", ok)
+
+	go func() {
+		got <- 5
+		got <- 4
+	}()
+
+	ok = td.Cmp(t, got, td.RecvN(2, td.Bag(4, 5), time.Second))
+	fmt.Println("4 and 5 received w/1s timeout:", ok)
+
+	// Output:
+	// 3 then 1 received: true
+	// 2 values received: false
+	// 4 and 5 received w/1s timeout: true
+}
+
+func ExampleRecvWithin() {
+	t := &testing.T{}
+
+	got := make(chan int, 1)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		got <- 42
+	}()
+
+	ok := td.Cmp(t, got, td.RecvWithin(10*time.Millisecond, time.Second, 42))
+	fmt.Println("42 received within [10ms, 1s]:", ok)
+
+	got <- 42
+	ok = td.Cmp(t, got, td.RecvWithin(10*time.Millisecond, time.Second))
+	fmt.Println("received within [10ms, 1s]:", ok)
+
+	// Output:
+	// 42 received within [10ms, 1s]: true
+	// received within [10ms, 1s]: false
+}
+
+func ExampleRef() {
+	t := &testing.T{}
+
+	type Line struct {
+		Currency string
+		Price    int
+	}
+	type Order struct {
+		Currency string
+		Total    int
+		MinPrice int
+		Lines    []Line
+	}
+
+	got := Order{
+		Currency: "EUR",
+		Total:    30,
+		MinPrice: 5,
+		Lines: []Line{
+			{Currency: "EUR", Price: 10},
+			{Currency: "EUR", Price: 20},
+		},
+	}
+
+	ok := td.Cmp(t, got,
+		td.Struct(Order{}, td.StructFields{
+			"MinPrice": td.Lt(td.Ref("Total")),
+			"Lines": td.ArrayEach(td.Struct(Line{}, td.StructFields{
+				"Currency": td.Ref("$.Currency"),
+				"Price":    td.Between(td.Ref("$.MinPrice"), td.Ref("$.Total")),
+			})),
+		}),
+		"checks the order is consistent")
+	fmt.Println(ok)
+
+	got.Lines[1].Currency = "USD"
+	ok = td.Cmp(t, got,
+		td.Struct(Order{}, td.StructFields{
+			"Lines": td.ArrayEach(td.Struct(Line{}, td.StructFields{
+				"Currency": td.Ref("$.Currency"),
+			})),
+		}),
+		"checks all lines use the order currency")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleSameInstant() {
+	t := &testing.T{}
+
+	got := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+
+	// Location does not matter
+	expected := got.In(time.FixedZone("Paris", 3600))
+	ok := td.Cmp(t, got, td.SameInstant(expected),
+		"checks %v is the same instant as %v", got, expected)
+	fmt.Println(ok)
+
+	// Monotonic part does not matter
+	now := time.Now()
+	ok = td.Cmp(t, now.Round(0), td.SameInstant(now),
+		"checks %v ignoring monotonic part", now)
+	fmt.Println(ok)
+
+	expected = got.Add(time.Nanosecond)
+	ok = td.Cmp(t, got, td.SameInstant(expected),
+		"checks %v is the same instant as %v", got, expected)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleSet() {
 	t := &testing.T{}
 
@@ -3139,6 +3697,52 @@ func ExampleSlice_typedSlice() {
 	// true
 }
 
+func ExampleSliceHasPrefix() {
+	t := &testing.T{}
+
+	got := []string{"start", "login", "read", "logout", "stop"}
+
+	ok := td.Cmp(t, got, td.SliceHasPrefix("start", "login"),
+		"checks got starts with start and login")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.SliceHasPrefix("start", td.Re(`^log(in|out)$`)),
+		"checks got starts with start then a log* event")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.SliceHasPrefix("login"),
+		"checks got starts with login")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleSliceHasSuffix() {
+	t := &testing.T{}
+
+	got := []string{"start", "login", "read", "logout", "stop"}
+
+	ok := td.Cmp(t, got, td.SliceHasSuffix("logout", "stop"),
+		"checks got ends with logout and stop")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.SliceHasSuffix(td.HasPrefix("log"), "stop"),
+		"checks got ends with a log* event then stop")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.SliceHasSuffix("logout"),
+		"checks got ends with logout")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleSuperSliceOf_array() {
 	t := &testing.T{}
 
@@ -3661,6 +4265,61 @@ func ExampleHasSuffix_error() {
 	// true
 }
 
+func ExampleStringFold() {
+	t := &testing.T{}
+
+	got := "Hello World!"
+
+	ok := td.Cmp(t, got, td.StringFold("hello WORLD!"),
+		"checks %q ignoring case", got)
+	fmt.Println(ok)
+
+	// Also works with []byte, error and fmt.Stringer
+	err := errors.New("Access Denied")
+	ok = td.Cmp(t, err, td.StringFold("access denied"),
+		"checks error %q ignoring case", err)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.StringFold("hello world"),
+		"checks %q ignoring case", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleStringNorm() {
+	t := &testing.T{}
+
+	got := "  Hello \n  World!  "
+
+	ok := td.Cmp(t, got, td.StringNorm("Hello World!", td.CollapseSpace),
+		"checks %q ignoring spaces", got)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.StringNorm("hello world!", td.CollapseSpace, td.FoldCase),
+		"checks %q ignoring case and spaces", got)
+	fmt.Println(ok)
+
+	// Any func(string) string function can be used
+	noDash := strings.NewReplacer("-", "").Replace
+	ok = td.Cmp(t, "2023-03-09", td.StringNorm("20230309", noDash),
+		"checks date ignoring dashes")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.StringNorm("hello world!", td.CollapseSpace),
+		"checks %q ignoring spaces", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// true
+	// false
+}
+
 func ExampleStruct() {
 	t := &testing.T{}
 
@@ -4117,6 +4776,38 @@ func ExampleSStruct_lazy_model() {
 	// Lazy model with unknown field: false
 }
 
+func ExampleSubsequence() {
+	t := &testing.T{}
+
+	got := []string{"start", "login", "read", "write", "logout", "stop"}
+
+	ok := td.Cmp(t, got, td.Subsequence("login", "write", "logout"),
+		"checks login, write and logout appear in this order")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.Subsequence("login", td.HasPrefix("wr"), "stop"),
+		"checks login, a wr* event and stop appear in this order")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.Subsequence("logout", "login"),
+		"checks logout and login appear in this order")
+	fmt.Println(ok)
+
+	// When expected is already a non-[]any slice, it cannot be
+	// flattened directly using expected... without copying it to a new
+	// []any slice, then use td.Flatten!
+	expected := []string{"start", "stop"}
+	ok = td.Cmp(t, got, td.Subsequence(td.Flatten(expected)),
+		"checks start and stop appear in this order")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+	// true
+}
+
 func ExampleSubBagOf() {
 	t := &testing.T{}
 
@@ -4370,6 +5061,39 @@ func ExampleSubSetOf() {
 	// true
 }
 
+func ExampleSum() {
+	t := &testing.T{}
+
+	type Line struct {
+		Label string
+		Price float64
+		Qty   int
+	}
+
+	got := []Line{
+		{Label: "pen", Price: 12.5, Qty: 2},
+		{Label: "book", Price: 87.4, Qty: 1},
+	}
+
+	ok := td.Cmp(t, got, td.Sum("Price", td.N(99.9, 0.01)))
+	fmt.Println("total price is 99.9:", ok)
+
+	ok = td.Cmp(t, got, td.Sum("Qty", 3))
+	fmt.Println("total quantity is 3:", ok)
+
+	ok = td.Cmp(t, []int{1, 2, 3}, td.Sum("", 6))
+	fmt.Println("sum of ints is 6:", ok)
+
+	ok = td.Cmp(t, []Line{}, td.Sum("Qty", 0))
+	fmt.Println("sum of an empty slice is 0:", ok)
+
+	// Output:
+	// total price is 99.9: true
+	// total quantity is 3: true
+	// sum of ints is 6: true
+	// sum of an empty slice is 0: true
+}
+
 func ExampleSuperBagOf() {
 	t := &testing.T{}
 
@@ -4620,6 +5344,69 @@ func ExampleSuperSetOf() {
 	// true
 }
 
+func ExampleSwitch() {
+	t := &testing.T{}
+
+	cases := []td.SwitchCase{
+		td.Case("", td.HasPrefix("id-")),
+		td.Case(0, td.Between(1, 1000)),
+		td.Case(td.Gt(100.0), td.Lt(200.0)),
+		td.Default(td.Nil()),
+	}
+
+	for _, got := range []any{"id-12", 42, 150.0, nil, 42.0} {
+		ok := td.Cmp(t, got, td.Switch(cases...),
+			"checks %v is a valid ID", got)
+		fmt.Printf("%v: %t\n", got, ok)
+	}
+
+	// Output:
+	// id-12: true
+	// 42: true
+	// 150: true
+	// <nil>: true
+	// 42: false
+}
+
+func ExampleTar() {
+	t := &testing.T{}
+
+	// Build a tar archive in memory
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range []struct {
+		name, content string
+		mode          int64
+	}{
+		{name: "bin/tool", content: "#!/bin/sh\n", mode: 0o755},
+		{name: "README.md", content: "# Tool\nUsage: tool\n", mode: 0o644},
+	} {
+		tw.WriteHeader(&tar.Header{ //nolint: errcheck
+			Name: file.name,
+			Mode: file.mode,
+			Size: int64(len(file.content)),
+		})
+		tw.Write([]byte(file.content)) //nolint: errcheck
+	}
+	tw.Close() //nolint: errcheck
+
+	// got can be a []byte, an io.ReaderAt, an io.Reader or a file path
+	got := buf.Bytes()
+
+	ok := td.Cmp(t, got, td.Tar(td.Keys(td.Bag("README.md", "bin/tool"))))
+	fmt.Println("contains README.md & bin/tool:", ok)
+
+	ok = td.Cmp(t, got, td.Tar(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+		"bin/tool":  td.Smuggle("Mode", td.Code(func(m fs.FileMode) bool { return m&0o111 != 0 })),
+		"README.md": td.Smuggle("Content", td.Contains("Usage")),
+	})))
+	fmt.Println("bin/tool is executable & README.md documents usage:", ok)
+
+	// Output:
+	// contains README.md & bin/tool: true
+	// bin/tool is executable & README.md documents usage: true
+}
+
 func ExampleTruncTime() {
 	t := &testing.T{}
 
@@ -4657,6 +5444,59 @@ func ExampleTruncTime() {
 	// true
 }
 
+func ExampleUnique() {
+	t := &testing.T{}
+
+	got := []int{1, 2, 3, 4}
+	ok := td.Cmp(t, got, td.Unique(), "checks %v has no duplicates", got)
+	fmt.Println(ok)
+
+	got = []int{1, 2, 1, 3, 2}
+	ok = td.Cmp(t, got, td.Unique(), "checks %v has no duplicates", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleUniqueBy() {
+	t := &testing.T{}
+
+	type Profile struct {
+		Email string
+	}
+	type User struct {
+		ID      int
+		Profile Profile
+	}
+
+	got := []User{
+		{ID: 1, Profile: Profile{Email: "bob@example.com"}},
+		{ID: 2, Profile: Profile{Email: "alice@example.com"}},
+		{ID: 3, Profile: Profile{Email: "Bob@example.com"}},
+	}
+
+	ok := td.Cmp(t, got, td.UniqueBy("ID"), "checks IDs are unique")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.UniqueBy("Profile.Email"),
+		"checks emails are unique")
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got,
+		td.UniqueBy(func(u User) string {
+			return strings.ToLower(u.Profile.Email)
+		}),
+		"checks emails are unique, ignoring case")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleValues() {
 	t := &testing.T{}
 
@@ -4685,6 +5525,25 @@ func ExampleValues() {
 	// Each value is between 1 and 3: true
 }
 
+func ExampleWithinDuration() {
+	t := &testing.T{}
+
+	got := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+
+	expected := time.Date(2023, time.March, 9, 1, 2, 0, 0, time.UTC)
+	ok := td.Cmp(t, got, td.WithinDuration(expected, 5*time.Second),
+		"checks %v is less than 5 seconds away from %v", got, expected)
+	fmt.Println(ok)
+
+	ok = td.Cmp(t, got, td.WithinDuration(expected, time.Second),
+		"checks %v is less than 1 second away from %v", got, expected)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleZero() {
 	t := &testing.T{}
 
@@ -4738,3 +5597,36 @@ func ExampleZero() {
 	// false
 	// true
 }
+
+func ExampleZip() {
+	t := &testing.T{}
+
+	// Build a zip archive in memory
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"README.md": "# Foo\n",
+		"data.json": `{"id": 42, "name": "Bob"}`,
+	} {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content)) //nolint: errcheck
+	}
+	zw.Close() //nolint: errcheck
+
+	// got can be a []byte, an io.ReaderAt, an io.Reader or a file path
+	got := bytes.NewReader(buf.Bytes())
+
+	ok := td.Cmp(t, got, td.Zip(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+		"README.md": td.Smuggle("Content", td.HasPrefix("# ")),
+		"data.json": td.Smuggle("Content",
+			td.Smuggle(json.RawMessage(nil), td.JSON(`{"id": NotZero(), "name": "Bob"}`))),
+	})))
+	fmt.Println("README.md & data.json are OK:", ok)
+
+	ok = td.Cmp(t, got, td.Zip(td.Len(3)))
+	fmt.Println("contains 3 entries:", ok)
+
+	// Output:
+	// README.md & data.json are OK: true
+	// contains 3 entries: false
+}
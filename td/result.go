@@ -0,0 +1,258 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/color"
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+	"github.com/maxatome/go-testdeep/internal/types"
+)
+
+// PathSegmentKind is the kind of a [PathSegment].
+type PathSegmentKind uint8
+
+// Kinds of [PathSegment].
+const (
+	// PathField is a struct field access, as in DATA.Field.
+	PathField PathSegmentKind = iota
+	// PathIndex is an array or slice index access, as in DATA[12].
+	PathIndex
+	// PathMapKey is a map key access, as in DATA["key"].
+	PathMapKey
+	// PathFunc is a function call, as in len(DATA).
+	PathFunc
+	// PathCustom is any other path segment, as the root one ("DATA").
+	PathCustom
+)
+
+// String implements [fmt.Stringer].
+func (k PathSegmentKind) String() string {
+	switch k {
+	case PathField:
+		return "field"
+	case PathIndex:
+		return "index"
+	case PathMapKey:
+		return "map key"
+	case PathFunc:
+		return "func"
+	case PathCustom:
+		return "custom"
+	default:
+		return "?"
+	}
+}
+
+// PathSegment is a segment of the path leading to a [Mismatch].
+type PathSegment struct {
+	// Kind is the kind of the segment.
+	Kind PathSegmentKind
+	// Content is the field name for PathField, the index for
+	// PathIndex, the stringified key for PathMapKey, the function name
+	// for PathFunc and the raw content for PathCustom.
+	Content string
+	// Pointers is the number of pointers dereferenced at this level.
+	Pointers int
+}
+
+// OperatorLocation is the location of the [TestDeep] operator from
+// which a [Mismatch] originates.
+type OperatorLocation struct {
+	// Name is the name of the operator, as "Between".
+	Name string
+	// File is the file name where the operator has been called.
+	File string
+	// Line is the line number where the operator has been called.
+	Line int
+}
+
+// Mismatch is a public view of a mismatch detected during a
+// comparison.
+type Mismatch struct {
+	// Path is the path of the mismatch, as rendered in failure
+	// reports, for example DATA.Field[2].
+	Path string
+	// PathSegments is the decomposition of Path in segments.
+	PathSegments []PathSegment
+	// Message describes the mismatch, for example "values differ".
+	Message string
+	// Got is the got value, if any. Note that it can be a string
+	// describing the got value when it is not directly available.
+	Got any
+	// Expected is the expected value, if any. Note that it can be a
+	// string describing the expected value when it is not directly
+	// available.
+	Expected any
+	// GotString is the rendered got value, empty if Summary is set.
+	GotString string
+	// ExpectedString is the rendered expected value, empty if Summary
+	// is set.
+	ExpectedString string
+	// Summary is set when the mismatch is better described by a
+	// summary than by got and expected values.
+	Summary string
+	// Operator is the location of the operator from which the mismatch
+	// originates, nil if none.
+	Operator *OperatorLocation
+	// Origin is the mismatch from which this one comes, nil if none.
+	Origin *Mismatch
+}
+
+// Result is the structured result of a comparison, as returned by
+// [Diff]. It does not implement the error interface, as a successful
+// comparison also returns a non-nil *Result: use [Result.Err] to get
+// an error.
+type Result struct {
+	// Mismatches lists all mismatches detected during the comparison,
+	// in order. It is empty if the comparison succeeded.
+	Mismatches []Mismatch
+	err        *ctxerr.Error
+}
+
+// OK returns true if the comparison succeeded, so no mismatch has
+// been detected.
+func (r *Result) OK() bool {
+	return r == nil || len(r.Mismatches) == 0
+}
+
+// String returns the report as it would be rendered by a failing
+// [Cmp] call, but without colors. It returns "" if the comparison
+// succeeded.
+func (r *Result) String() string {
+	if r.OK() {
+		return ""
+	}
+	return r.err.ErrorWithoutColors()
+}
+
+// Err returns nil if the comparison succeeded, else an error
+// containing the report, as [EqDeeplyError] does.
+//
+//	if err := td.Diff(got, expected).Err(); err != nil {
+//	  return err
+//	}
+func (r *Result) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return r.err
+}
+
+// Diff compares got against expected the same way [Cmp] does, and
+// returns a [*Result] describing each mismatch. It never returns nil.
+//
+//	res := td.Diff(got, td.Struct(Person{Name: "Bob"}, td.StructFields{
+//	  "Age": td.Between(40, 45),
+//	}))
+//	for _, m := range res.Mismatches {
+//	  fmt.Printf("%s: %s\n", m.Path, m.Message)
+//	}
+//
+// config is an optional parameter and, if passed, must be unique. It
+// allows to configure the comparison. Note that its MaxErrors field
+// is honored, so a negative value should be used to retrieve all
+// mismatches. If config is omitted, [DefaultContextConfig] is used.
+func Diff(got, expected any, config ...ContextConfig) *Result {
+	var ctx ctxerr.Context
+	switch len(config) {
+	case 0:
+		ctx = newContext(nil)
+	case 1:
+		ctx = newContextWithConfig(nil, config[0])
+	default:
+		panic(color.TooManyParams("Diff(got, expected[, ContextConfig])"))
+	}
+
+	ctx.Root = reflect.ValueOf(got)
+	err := deepValueEqualFinal(ctx, ctx.Root, reflect.ValueOf(expected))
+	if err == nil {
+		return &Result{}
+	}
+
+	res := Result{err: err}
+	for ; err != nil; err = err.Next {
+		if err == ctxerr.ErrTooManyErrors {
+			break
+		}
+		res.Mismatches = append(res.Mismatches, newMismatch(err))
+	}
+	return &res
+}
+
+func newMismatch(err *ctxerr.Error) Mismatch {
+	m := Mismatch{
+		Path:           err.Context.Path.String(),
+		Message:        err.Message,
+		Got:            publicValue(err.Got),
+		Expected:       publicValue(err.Expected),
+		GotString:      err.GotString(),
+		ExpectedString: err.ExpectedString(),
+		Summary:        err.SummaryString(),
+	}
+
+	if pos := strings.Index(m.Message, "%%"); pos >= 0 {
+		m.Message = m.Message[:pos] + m.Path + m.Message[pos+2:]
+	}
+
+	if len(err.Context.Path) > 0 {
+		m.PathSegments = make([]PathSegment, len(err.Context.Path))
+		for i, level := range err.Context.Path {
+			m.PathSegments[i] = PathSegment{
+				Content:  level.Content,
+				Pointers: level.Pointers,
+			}
+			switch level.Kind {
+			case ctxerr.LevelStruct:
+				m.PathSegments[i].Kind = PathField
+			case ctxerr.LevelArray:
+				m.PathSegments[i].Kind = PathIndex
+			case ctxerr.LevelMap:
+				m.PathSegments[i].Kind = PathMapKey
+			case ctxerr.LevelFunc:
+				m.PathSegments[i].Kind = PathFunc
+			default:
+				m.PathSegments[i].Kind = PathCustom
+			}
+		}
+	}
+
+	if err.Location.IsInitialized() {
+		m.Operator = &OperatorLocation{
+			Name: err.Location.Func,
+			File: err.Location.File,
+			Line: err.Location.Line,
+		}
+	}
+
+	if err.Origin != nil {
+		origin := newMismatch(err.Origin)
+		m.Origin = &origin
+	}
+
+	return m
+}
+
+// publicValue returns v in a form that does not expose go-testdeep
+// internal types.
+func publicValue(v any) any {
+	switch tv := v.(type) {
+	case reflect.Value:
+		if i, ok := dark.GetInterface(tv, true); ok {
+			return i
+		}
+		return tv.String()
+	case types.RawString:
+		return string(tv)
+	case types.RawInt:
+		return int(tv)
+	}
+	return v
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestDiff(t *testing.T) {
+	type Person struct {
+		Name     string
+		Age      int
+		Children []*Person
+	}
+
+	res := td.Diff(42, 42)
+	if test.IsTrue(t, res != nil) {
+		test.IsTrue(t, res.OK())
+		test.EqualInt(t, len(res.Mismatches), 0)
+		test.EqualStr(t, res.String(), "")
+		test.IsTrue(t, res.Err() == nil)
+	}
+
+	// A real nil error, not a typed one
+	var err error = td.Diff("foo", "foo").Err()
+	test.IsTrue(t, err == nil)
+
+	got := Person{
+		Name: "Bob",
+		Age:  42,
+		Children: []*Person{
+			{Name: "Alice", Age: 12},
+		},
+	}
+	expected := td.Struct(Person{Name: "Bob"}, td.StructFields{
+		"Age":      td.Between(40, 41),
+		"Children": []*Person{{Name: "Alicia", Age: 12}},
+	})
+
+	res = td.Diff(got, expected, td.ContextConfig{MaxErrors: -1})
+	test.IsFalse(t, res.OK())
+	assert := td.Assert(t)
+	assert.Cmp(res.Mismatches, []td.Mismatch{
+		{
+			Path: "DATA.Age",
+			PathSegments: []td.PathSegment{
+				{Kind: td.PathCustom, Content: "DATA"},
+				{Kind: td.PathField, Content: "Age"},
+			},
+			Message:        "values differ",
+			Got:            42,
+			Expected:       "40 ≤ got ≤ 41",
+			GotString:      "42",
+			ExpectedString: "40 ≤ got ≤ 41",
+			Operator: assert.A(td.Struct(&td.OperatorLocation{
+				Name: "Between",
+				File: "result_test.go",
+			}, td.StructFields{"Line": td.Gt(0)}), (*td.OperatorLocation)(nil)).(*td.OperatorLocation),
+		},
+		{
+			Path: "DATA.Children[0].Name",
+			PathSegments: []td.PathSegment{
+				{Kind: td.PathCustom, Content: "DATA"},
+				{Kind: td.PathField, Content: "Children"},
+				{Kind: td.PathIndex, Content: "0"},
+				{Kind: td.PathField, Content: "Name"},
+			},
+			Message:        "values differ",
+			Got:            "Alice",
+			Expected:       "Alicia",
+			GotString:      `"Alice"`,
+			ExpectedString: `"Alicia"`,
+			Operator: assert.A(td.Struct(&td.OperatorLocation{
+				Name: "Struct",
+				File: "result_test.go",
+			}, td.StructFields{"Line": td.Gt(0)}), (*td.OperatorLocation)(nil)).(*td.OperatorLocation),
+		},
+	})
+	test.EqualStr(t, res.String(), td.EqDeeplyError(got, expected).Error())
+	err = res.Err()
+	if test.IsTrue(t, err != nil) {
+		test.EqualStr(t, err.Error(), td.EqDeeplyError(got, expected).Error())
+	}
+
+	// MaxErrors honored
+	res = td.Diff(got, expected, td.ContextConfig{MaxErrors: 1})
+	test.EqualInt(t, len(res.Mismatches), 1)
+
+	// Summary
+	res = td.Diff([]int{1, 2}, []int{1})
+	if test.EqualInt(t, len(res.Mismatches), 1) {
+		m := res.Mismatches[0]
+		test.EqualStr(t, m.Message, "comparing slices, from index #1")
+		test.EqualStr(t, m.Summary, "Extra item: (2)")
+		test.EqualStr(t, m.GotString, "")
+		test.IsTrue(t, m.Operator == nil)
+	}
+
+	// Path with pointers and function call
+	res = td.Diff(&got, td.Struct(&Person{}, td.StructFields{
+		"Name":     "Bob",
+		"Age":      42,
+		"Children": td.Len(td.Gt(1)),
+	}))
+	if test.EqualInt(t, len(res.Mismatches), 1) {
+		m := res.Mismatches[0]
+		test.EqualStr(t, m.Path, "len(DATA.Children)")
+		td.Cmp(t, m.PathSegments, []td.PathSegment{
+			{Kind: td.PathCustom, Content: "DATA"},
+			{Kind: td.PathField, Content: "Children"},
+			{Kind: td.PathFunc, Content: "len"},
+		})
+		test.EqualStr(t, m.Operator.Name, "Gt")
+	}
+
+	test.CheckPanic(t, func() { td.Diff(1, 1, td.ContextConfig{}, td.ContextConfig{}) },
+		"usage: Diff(got, expected[, ContextConfig]), too many parameters")
+
+	test.EqualStr(t, td.PathField.String(), "field")
+	test.EqualStr(t, td.PathIndex.String(), "index")
+	test.EqualStr(t, td.PathMapKey.String(), "map key")
+	test.EqualStr(t, td.PathFunc.String(), "func")
+	test.EqualStr(t, td.PathCustom.String(), "custom")
+	test.EqualStr(t, td.PathSegmentKind(42).String(), "?")
+}
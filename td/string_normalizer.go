@@ -0,0 +1,61 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"strings"
+	"unicode"
+)
+
+// StringNormalizer is a function transforming a string before it is
+// compared by [StringNorm], [HasPrefixNorm], [HasSuffixNorm] or
+// [ContainsNorm] operators. Both got and expected strings are
+// normalized before comparison.
+//
+// [FoldCase] and [CollapseSpace] are provided. Unicode normalization
+// (NFC, NFD, …) is not, to keep go-testdeep free of dependencies, but
+// any func(string) string function can be used, like norm.NFC.String
+// or norm.NFD.String from [golang.org/x/text/unicode/norm] package, to
+// compare Unicode strings regardless of their normalization form:
+//
+//	// "é" is U+00E9 in expected, "e" followed by U+0301 in got
+//	td.Cmp(t, "cafe\u0301", td.StringNorm("caf\u00e9", norm.NFC.String)) // succeeds
+//
+// [golang.org/x/text/unicode/norm]: https://pkg.go.dev/golang.org/x/text/unicode/norm
+type StringNormalizer func(string) string
+
+// FoldCase is a [StringNormalizer] replacing each rune of s by a
+// canonical representative of its Unicode case folding orbit, so two
+// strings are equal after FoldCase if and only if they are equal
+// according to [strings.EqualFold].
+func FoldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		return min
+	}, s)
+}
+
+// CollapseSpace is a [StringNormalizer] trimming leading and trailing
+// white spaces of s and replacing each run of white spaces inside it
+// by a single space. White spaces are defined by [unicode.IsSpace].
+//
+//	CollapseSpace("  foo \t\n bar ") // returns "foo bar"
+func CollapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func normalizeString(s string, normalizers []StringNormalizer) string {
+	for _, normalize := range normalizers {
+		s = normalize(s)
+	}
+	return s
+}
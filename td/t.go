@@ -88,6 +88,25 @@ func (t *T) ArrayEach(got, expectedValue any, args ...any) bool {
 	return t.Cmp(got, ArrayEach(expectedValue), args...)
 }
 
+// Avg is a shortcut for:
+//
+//	t.Cmp(got, td.Avg(fieldsPath, expectedValue), args...)
+//
+// See [Avg] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Avg(got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Avg(fieldsPath, expectedValue), args...)
+}
+
 // Bag is a shortcut for:
 //
 //	t.Cmp(got, td.Bag(expectedItems...), args...)
@@ -206,6 +225,63 @@ func (t *T) ContainsKey(got, expectedValue any, args ...any) bool {
 	return t.Cmp(got, ContainsKey(expectedValue), args...)
 }
 
+// ContainsNorm is a shortcut for:
+//
+//	t.Cmp(got, td.ContainsNorm(expected, normalizers...), args...)
+//
+// See [ContainsNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) ContainsNorm(got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, ContainsNorm(expected, normalizers...), args...)
+}
+
+// ContainsSeq is a shortcut for:
+//
+//	t.Cmp(got, td.ContainsSeq(expectedItems...), args...)
+//
+// See [ContainsSeq] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) ContainsSeq(got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, ContainsSeq(expectedItems...), args...)
+}
+
+// Count is a shortcut for:
+//
+//	t.Cmp(got, td.Count(filter, expectedValue), args...)
+//
+// See [Count] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Count(got, filter, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Count(filter, expectedValue), args...)
+}
+
 // Empty is a shortcut for:
 //
 //	t.Cmp(got, td.Empty(), args...)
@@ -244,6 +320,25 @@ func (t *T) CmpErrorIs(got, expectedError any, args ...any) bool {
 	return t.Cmp(got, ErrorIs(expectedError), args...)
 }
 
+// File is a shortcut for:
+//
+//	t.Cmp(got, td.File(path, expectedValue), args...)
+//
+// See [File] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) File(got any, path string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, File(path, expectedValue), args...)
+}
+
 // First is a shortcut for:
 //
 //	t.Cmp(got, td.First(filter, expectedValue), args...)
@@ -263,6 +358,25 @@ func (t *T) First(got, filter, expectedValue any, args ...any) bool {
 	return t.Cmp(got, First(filter, expectedValue), args...)
 }
 
+// FS is a shortcut for:
+//
+//	t.Cmp(got, td.FS(expectedFiles), args...)
+//
+// See [FS] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) FS(got any, expectedFiles map[string]any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, FS(expectedFiles), args...)
+}
+
 // Grep is a shortcut for:
 //
 //	t.Cmp(got, td.Grep(filter, expectedValue), args...)
@@ -339,6 +453,25 @@ func (t *T) HasPrefix(got any, expected string, args ...any) bool {
 	return t.Cmp(got, HasPrefix(expected), args...)
 }
 
+// HasPrefixNorm is a shortcut for:
+//
+//	t.Cmp(got, td.HasPrefixNorm(expected, normalizers...), args...)
+//
+// See [HasPrefixNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) HasPrefixNorm(got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, HasPrefixNorm(expected, normalizers...), args...)
+}
+
 // HasSuffix is a shortcut for:
 //
 //	t.Cmp(got, td.HasSuffix(expected), args...)
@@ -358,6 +491,67 @@ func (t *T) HasSuffix(got any, expected string, args ...any) bool {
 	return t.Cmp(got, HasSuffix(expected), args...)
 }
 
+// HasSuffixNorm is a shortcut for:
+//
+//	t.Cmp(got, td.HasSuffixNorm(expected, normalizers...), args...)
+//
+// See [HasSuffixNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) HasSuffixNorm(got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, HasSuffixNorm(expected, normalizers...), args...)
+}
+
+// If is a shortcut for:
+//
+//	t.Cmp(got, td.If(condition, thenValue, elseValue), args...)
+//
+// See [If] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) If(got, condition, thenValue, elseValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, If(condition, thenValue, elseValue), args...)
+}
+
+// Image is a shortcut for:
+//
+//	t.Cmp(got, td.Image(expectedImage, opts), args...)
+//
+// See [Image] for details.
+//
+// [Image] optional parameter opts is here mandatory.
+// td.ImageOptions{} value should be passed to mimic its absence in
+// original [Image] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Image(got, expectedImage any, opts ImageOptions, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Image(expectedImage, opts), args...)
+}
+
 // Isa is a shortcut for:
 //
 //	t.Cmp(got, td.Isa(model), args...)
@@ -567,6 +761,44 @@ func (t *T) MapEach(got, expectedValue any, args ...any) bool {
 	return t.Cmp(got, MapEach(expectedValue), args...)
 }
 
+// Max is a shortcut for:
+//
+//	t.Cmp(got, td.Max(fieldsPath, expectedValue), args...)
+//
+// See [Max] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Max(got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Max(fieldsPath, expectedValue), args...)
+}
+
+// Min is a shortcut for:
+//
+//	t.Cmp(got, td.Min(fieldsPath, expectedValue), args...)
+//
+// See [Min] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Min(got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Min(fieldsPath, expectedValue), args...)
+}
+
 // N is a shortcut for:
 //
 //	t.Cmp(got, td.N(num, tolerance), args...)
@@ -822,6 +1054,25 @@ func (t *T) Re(got, reg, capture any, args ...any) bool {
 	return t.Cmp(got, Re(reg, capture), args...)
 }
 
+// ReaderContent is a shortcut for:
+//
+//	t.Cmp(got, td.ReaderContent(expectedValue), args...)
+//
+// See [ReaderContent] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) ReaderContent(got, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, ReaderContent(expectedValue), args...)
+}
+
 // ReAll is a shortcut for:
 //
 //	t.Cmp(got, td.ReAll(reg, capture), args...)
@@ -841,6 +1092,29 @@ func (t *T) ReAll(got, reg, capture any, args ...any) bool {
 	return t.Cmp(got, ReAll(reg, capture), args...)
 }
 
+// Recent is a shortcut for:
+//
+//	t.Cmp(got, td.Recent(delta, now), args...)
+//
+// See [Recent] for details.
+//
+// [Recent] optional parameter now is here mandatory.
+// nil value should be passed to mimic its absence in
+// original [Recent] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Recent(got any, delta time.Duration, now func() time.Time, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Recent(delta, now), args...)
+}
+
 // Recv is a shortcut for:
 //
 //	t.Cmp(got, td.Recv(expectedValue, timeout), args...)
@@ -864,6 +1138,94 @@ func (t *T) Recv(got, expectedValue any, timeout time.Duration, args ...any) boo
 	return t.Cmp(got, Recv(expectedValue, timeout), args...)
 }
 
+// RecvAll is a shortcut for:
+//
+//	t.Cmp(got, td.RecvAll(expectedValue, timeout), args...)
+//
+// See [RecvAll] for details.
+//
+// [RecvAll] optional parameter timeout is here mandatory.
+// 0 value should be passed to mimic its absence in
+// original [RecvAll] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) RecvAll(got, expectedValue any, timeout time.Duration, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, RecvAll(expectedValue, timeout), args...)
+}
+
+// RecvN is a shortcut for:
+//
+//	t.Cmp(got, td.RecvN(n, expectedValue, timeout), args...)
+//
+// See [RecvN] for details.
+//
+// [RecvN] optional parameter timeout is here mandatory.
+// 0 value should be passed to mimic its absence in
+// original [RecvN] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) RecvN(got any, n int, expectedValue any, timeout time.Duration, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, RecvN(n, expectedValue, timeout), args...)
+}
+
+// RecvWithin is a shortcut for:
+//
+//	t.Cmp(got, td.RecvWithin(min, max, expectedValue), args...)
+//
+// See [RecvWithin] for details.
+//
+// [RecvWithin] optional parameter expectedValue is here mandatory.
+// td.Ignore() value should be passed to mimic its absence in
+// original [RecvWithin] call.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) RecvWithin(got any, min, max time.Duration, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, RecvWithin(min, max, expectedValue), args...)
+}
+
+// SameInstant is a shortcut for:
+//
+//	t.Cmp(got, td.SameInstant(expectedTime), args...)
+//
+// See [SameInstant] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) SameInstant(got, expectedTime any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, SameInstant(expectedTime), args...)
+}
+
 // Set is a shortcut for:
 //
 //	t.Cmp(got, td.Set(expectedItems...), args...)
@@ -921,6 +1283,44 @@ func (t *T) Slice(got, model any, expectedEntries ArrayEntries, args ...any) boo
 	return t.Cmp(got, Slice(model, expectedEntries), args...)
 }
 
+// SliceHasPrefix is a shortcut for:
+//
+//	t.Cmp(got, td.SliceHasPrefix(expectedItems...), args...)
+//
+// See [SliceHasPrefix] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) SliceHasPrefix(got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, SliceHasPrefix(expectedItems...), args...)
+}
+
+// SliceHasSuffix is a shortcut for:
+//
+//	t.Cmp(got, td.SliceHasSuffix(expectedItems...), args...)
+//
+// See [SliceHasSuffix] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) SliceHasSuffix(got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, SliceHasSuffix(expectedItems...), args...)
+}
+
 // Smuggle is a shortcut for:
 //
 //	t.Cmp(got, td.Smuggle(fn, expectedValue), args...)
@@ -982,6 +1382,44 @@ func (t *T) String(got any, expected string, args ...any) bool {
 	return t.Cmp(got, String(expected), args...)
 }
 
+// StringFold is a shortcut for:
+//
+//	t.Cmp(got, td.StringFold(expected), args...)
+//
+// See [StringFold] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) StringFold(got any, expected string, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, StringFold(expected), args...)
+}
+
+// StringNorm is a shortcut for:
+//
+//	t.Cmp(got, td.StringNorm(expected, normalizers...), args...)
+//
+// See [StringNorm] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) StringNorm(got any, expected string, normalizers []StringNormalizer, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, StringNorm(expected, normalizers...), args...)
+}
+
 // Struct is a shortcut for:
 //
 //	t.Cmp(got, td.Struct(model, expectedFields), args...)
@@ -1062,6 +1500,25 @@ func (t *T) SubMapOf(got, model any, expectedEntries MapEntries, args ...any) bo
 	return t.Cmp(got, SubMapOf(model, expectedEntries), args...)
 }
 
+// Subsequence is a shortcut for:
+//
+//	t.Cmp(got, td.Subsequence(expectedItems...), args...)
+//
+// See [Subsequence] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Subsequence(got any, expectedItems []any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Subsequence(expectedItems...), args...)
+}
+
 // SubSetOf is a shortcut for:
 //
 //	t.Cmp(got, td.SubSetOf(expectedItems...), args...)
@@ -1081,6 +1538,25 @@ func (t *T) SubSetOf(got any, expectedItems []any, args ...any) bool {
 	return t.Cmp(got, SubSetOf(expectedItems...), args...)
 }
 
+// Sum is a shortcut for:
+//
+//	t.Cmp(got, td.Sum(fieldsPath, expectedValue), args...)
+//
+// See [Sum] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Sum(got any, fieldsPath string, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Sum(fieldsPath, expectedValue), args...)
+}
+
 // SuperBagOf is a shortcut for:
 //
 //	t.Cmp(got, td.SuperBagOf(expectedItems...), args...)
@@ -1176,6 +1652,44 @@ func (t *T) SuperSliceOf(got, model any, expectedEntries ArrayEntries, args ...a
 	return t.Cmp(got, SuperSliceOf(model, expectedEntries), args...)
 }
 
+// Switch is a shortcut for:
+//
+//	t.Cmp(got, td.Switch(cases...), args...)
+//
+// See [Switch] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Switch(got any, cases []SwitchCase, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Switch(cases...), args...)
+}
+
+// Tar is a shortcut for:
+//
+//	t.Cmp(got, td.Tar(expectedValue), args...)
+//
+// See [Tar] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Tar(got, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Tar(expectedValue), args...)
+}
+
 // TruncTime is a shortcut for:
 //
 //	t.Cmp(got, td.TruncTime(expectedTime, trunc), args...)
@@ -1199,6 +1713,44 @@ func (t *T) TruncTime(got, expectedTime any, trunc time.Duration, args ...any) b
 	return t.Cmp(got, TruncTime(expectedTime, trunc), args...)
 }
 
+// Unique is a shortcut for:
+//
+//	t.Cmp(got, td.Unique(), args...)
+//
+// See [Unique] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Unique(got any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Unique(), args...)
+}
+
+// UniqueBy is a shortcut for:
+//
+//	t.Cmp(got, td.UniqueBy(keyFn), args...)
+//
+// See [UniqueBy] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) UniqueBy(got, keyFn any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, UniqueBy(keyFn), args...)
+}
+
 // Values is a shortcut for:
 //
 //	t.Cmp(got, td.Values(val), args...)
@@ -1218,6 +1770,25 @@ func (t *T) Values(got, val any, args ...any) bool {
 	return t.Cmp(got, Values(val), args...)
 }
 
+// WithinDuration is a shortcut for:
+//
+//	t.Cmp(got, td.WithinDuration(expectedTime, delta), args...)
+//
+// See [WithinDuration] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) WithinDuration(got, expectedTime any, delta time.Duration, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, WithinDuration(expectedTime, delta), args...)
+}
+
 // Zero is a shortcut for:
 //
 //	t.Cmp(got, td.Zero(), args...)
@@ -1236,3 +1807,22 @@ func (t *T) Zero(got any, args ...any) bool {
 	t.Helper()
 	return t.Cmp(got, Zero(), args...)
 }
+
+// Zip is a shortcut for:
+//
+//	t.Cmp(got, td.Zip(expectedValue), args...)
+//
+// See [Zip] for details.
+//
+// Returns true if the test is OK, false if it fails.
+//
+// args... are optional and allow to name the test. This name is
+// used in case of failure to qualify the test. If len(args) > 1 and
+// the first item of args is a string and contains a '%' rune then
+// [fmt.Fprintf] is used to compose the name, else args are passed to
+// [fmt.Fprint]. Do not forget it is the name of the test, not the
+// reason of a potential failure.
+func (t *T) Zip(got, expectedValue any, args ...any) bool {
+	t.Helper()
+	return t.Cmp(got, Zip(expectedValue), args...)
+}
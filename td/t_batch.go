@@ -0,0 +1,232 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
+	"github.com/maxatome/go-testdeep/internal/color"
+	"github.com/maxatome/go-testdeep/internal/flat"
+	"github.com/maxatome/go-testdeep/internal/trace"
+)
+
+// batchFailure is a failure recorded during a batch.
+type batchFailure struct {
+	fileLine string
+	message  string
+}
+
+// batchTB is the [testing.TB] used during a batch. It records
+// failures instead of reporting them immediately.
+type batchTB struct {
+	testing.TB
+	mu       sync.Mutex
+	failures []batchFailure
+	failed   bool
+}
+
+// batchFailNow is used to stop a batch when FailNow is called.
+type batchFailNow struct{}
+
+var _ testing.TB = (*batchTB)(nil)
+
+func (b *batchTB) record(args ...any) {
+	var fileLine string
+	if s := trace.Retrieve(1, "testing.tRunner"); len(s) > 0 {
+		fileLine = s[0].FileLine
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failed = true
+	b.failures = append(b.failures, batchFailure{
+		fileLine: fileLine,
+		message:  strings.TrimSuffix(fmt.Sprintln(args...), "\n"),
+	})
+}
+
+// Error records a failure.
+func (b *batchTB) Error(args ...any) {
+	b.record(args...)
+}
+
+// Errorf records a failure.
+func (b *batchTB) Errorf(format string, args ...any) {
+	b.record(fmt.Sprintf(format, args...))
+}
+
+// Fail marks the batch as failed.
+func (b *batchTB) Fail() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failed = true
+}
+
+// FailNow marks the batch as failed and stops it.
+func (b *batchTB) FailNow() {
+	b.Fail()
+	panic(batchFailNow{})
+}
+
+// Failed returns true if the batch or the original test failed.
+func (b *batchTB) Failed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failed || b.TB.Failed()
+}
+
+// Fatal records a failure and stops the batch.
+func (b *batchTB) Fatal(args ...any) {
+	b.record(args...)
+	b.FailNow()
+}
+
+// Fatalf records a failure and stops the batch.
+func (b *batchTB) Fatalf(format string, args ...any) {
+	b.record(fmt.Sprintf(format, args...))
+	b.FailNow()
+}
+
+// Helper is a no-op as failures locations are computed by batchTB
+// itself.
+func (b *batchTB) Helper() {}
+
+// run calls fn and returns true if it has been stopped by a FailNow call.
+func (b *batchTB) run(fn func()) (stopped bool) {
+	defer func() {
+		if x := recover(); x != nil {
+			_, stopped = x.(batchFailNow)
+			if !stopped {
+				panic(x) // rethrow
+			}
+		}
+	}()
+
+	fn()
+	return
+}
+
+// report builds the consolidated report of all recorded failures.
+func (b *batchTB) report(stopped bool, args ...any) string {
+	var buf strings.Builder
+
+	color.AppendTestNameOn(&buf)
+	buf.WriteString("Failed batch")
+	if args = flat.Interfaces(args...); len(args) > 0 {
+		buf.WriteString(" '")
+		tdutil.FbuildTestName(&buf, args...)
+		buf.WriteString("'")
+	}
+	color.AppendTestNameOff(&buf)
+
+	b.appendFailures(&buf, stopped, "batch")
+	return buf.String()
+}
+
+// appendFailures appends all recorded failures to buf, followed by
+// their count. scope is the lower-cased name of what failed, as
+// "batch".
+func (b *batchTB) appendFailures(buf *strings.Builder, stopped bool, scope string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, failure := range b.failures {
+		buf.WriteString("\n#")
+		buf.WriteString(strconv.Itoa(i + 1))
+		if failure.fileLine != "" {
+			buf.WriteByte(' ')
+			buf.WriteString(failure.fileLine)
+		}
+		buf.WriteString(": ")
+		buf.WriteString(strings.ReplaceAll(failure.message, "\n", "\n\t"))
+	}
+
+	buf.WriteByte('\n')
+	switch len(b.failures) {
+	case 0:
+		buf.WriteString(strings.ToUpper(scope[:1]))
+		buf.WriteString(scope[1:])
+		buf.WriteString(" failed without any failure message")
+	case 1:
+		buf.WriteString("1 failure in ")
+		buf.WriteString(scope)
+	default:
+		fmt.Fprintf(buf, "%d failures in %s", len(b.failures), scope)
+	}
+	if stopped {
+		buf.WriteString(", stopped by a fatal failure")
+	}
+}
+
+// Batch calls fn with a new [*T] instance inheriting the t config,
+// but recording all failures occurring during fn execution instead
+// of reporting them immediately. When fn returns, if at least one
+// failure occurred, one consolidated report is emitted, numbering
+// each failure and telling where it occurred.
+//
+// args... are optional and allow to name the batch. This name is
+// used in the report header. If len(args) > 1 and the first item of
+// args is a string and contains a '%' rune then [fmt.Fprintf] is
+// used to compose the name, else args are passed to [fmt.Fprint].
+//
+//	t.Batch(func(t *td.T) {
+//	  t.Cmp(user.Name, "Bob", "name")
+//	  t.Cmp(user.Age, td.Between(40, 45), "age")
+//	  t.Len(user.Roles, 2, "roles")
+//	}, "user %d", user.ID)
+//
+// produces, if the 2 first Cmp calls fail:
+//
+//	foo_test.go:12: Failed batch 'user 42'
+//	    #1 foo_test.go:13: Failed test 'name'
+//	    	DATA: values differ
+//	    		     got: "Alice"
+//	    		expected: "Bob"
+//	    #2 foo_test.go:14: Failed test 'age'
+//	    	DATA: values differ
+//	    		     got: 12
+//	    		expected: 40 ≤ got ≤ 45
+//	    	[under operator Between at foo_test.go:14]
+//	    2 failures in batch
+//
+// FailureIsFatal flag of t only applies at the end of the batch:
+// inside fn, failures never stop the batch, except if a Fatal-like
+// method is explicitly called (using [T.Require] for example). In
+// this case, the batch is stopped, but the consolidated report is
+// still emitted.
+//
+// It returns true if no failure occurred during the batch.
+func (t *T) Batch(fn func(t *T), args ...any) bool {
+	t.Helper()
+
+	btb := &batchTB{TB: t.TB}
+
+	nt := NewT(btb, t.Config)
+	nt.Config.FailureIsFatal = false
+
+	stopped := btb.run(func() { fn(nt) })
+
+	btb.mu.Lock()
+	failed := btb.failed
+	btb.mu.Unlock()
+
+	if !failed {
+		return true
+	}
+
+	if t.Config.FailureIsFatal {
+		t.TB.Fatal(btb.report(stopped, args...))
+	} else {
+		t.TB.Error(btb.report(stopped, args...))
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestBatch(tt *testing.T) {
+	t := td.NewT(tt)
+
+	t.Run("OK", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		ok := td.NewT(ttt).Batch(func(t *td.T) {
+			t.Cmp(1, 1)
+			t.Cmp("foo", td.HasPrefix("f"))
+		})
+		t.True(ok)
+		t.False(ttt.IsFatal)
+		t.False(ttt.Failed())
+		t.Empty(ttt.LastMessage())
+	})
+
+	t.Run("Failures", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		var afterFirstFailure bool
+		ok := td.NewT(ttt).Batch(func(t *td.T) {
+			t.Cmp(1, 2, "first")
+			afterFirstFailure = true
+			t.Cmp(3, 3, "OK")
+			t.Cmp("foo", td.HasPrefix("b"))
+		}, "batch %d", 42)
+		t.False(ok)
+		t.True(afterFirstFailure)
+		t.False(ttt.IsFatal)
+		t.True(ttt.Failed())
+		t.Cmp(ttt.LastMessage(), td.Re(`(?s)\AFailed batch 'batch 42'
+#1 td/t_batch_test\.go:\d+: Failed test 'first'
+	DATA: values differ
+		     got: 1
+		expected: 2
+#2 td/t_batch_test\.go:\d+: Failed test
+	DATA: has not prefix
+		     got: "foo"
+		expected: HasPrefix\("b"\)
+	\[under operator HasPrefix at t_batch_test\.go:\d+\]
+2 failures in batch\z`))
+	})
+
+	t.Run("FailureIsFatal at the end", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		var afterFirstFailure bool
+		ok := true
+		ttt.CatchFatal(func() {
+			ok = td.Require(ttt).Batch(func(t *td.T) {
+				t.Cmp(1, 2)
+				afterFirstFailure = true
+				t.Cmp(3, 4)
+			})
+		})
+		t.True(ok) // Batch did not return as Fatal has been called
+		t.True(afterFirstFailure)
+		t.True(ttt.IsFatal)
+		t.Cmp(ttt.LastMessage(), td.Re(`2 failures in batch\z`))
+	})
+
+	t.Run("Stopped by a fatal failure", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		var afterFatal bool
+		ok := td.NewT(ttt).Batch(func(t *td.T) {
+			t.Cmp(1, 2)
+			t.Require().Cmp(3, 4)
+			afterFatal = true
+		})
+		t.False(ok)
+		t.False(afterFatal)
+		t.False(ttt.IsFatal)
+		t.Cmp(ttt.LastMessage(),
+			td.Re(`2 failures in batch, stopped by a fatal failure\z`))
+	})
+
+	t.Run("Fail without message", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		ok := td.NewT(ttt).Batch(func(t *td.T) { t.Fail() })
+		t.False(ok)
+		t.Cmp(ttt.LastMessage(),
+			"Failed batch\nBatch failed without any failure message")
+	})
+
+	t.Run("Nested batches", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		ok := td.NewT(ttt).Batch(func(t *td.T) {
+			t.Batch(func(t *td.T) {
+				t.Cmp(1, 2)
+			}, "inner")
+			t.Cmp(3, 4)
+		}, "outer")
+		t.False(ok)
+		msg := ttt.LastMessage()
+		t.Cmp(msg, td.Re(`\AFailed batch 'outer'\n#1 [^:]+:\d+: Failed batch 'inner'\n`))
+		t.Cmp(regexp.MustCompile(`(?m)^\s*#\d`).FindAllString(msg, -1), []string{"#1", "\t#1", "#2"})
+		t.Cmp(msg, td.Re(`2 failures in batch\z`))
+	})
+}
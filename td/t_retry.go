@@ -0,0 +1,101 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/color"
+)
+
+// Retry calls fn with a new [*T] instance inheriting the t config,
+// but recording all failures occurring during fn execution instead
+// of reporting them immediately. If at least one failure occurred,
+// fn is called again, up to retries times. As soon as one attempt
+// succeeds, failures of previous attempts are ignored and the
+// successful attempt is logged.
+//
+//	t.Retry(2, func(t *td.T) {
+//	  resp, err := client.Get(url)
+//	  t.Require().CmpNoError(err)
+//	  t.Cmp(resp.StatusCode, 200)
+//	})
+//
+// produces, if the first attempt fails but the second succeeds:
+//
+//	foo_test.go:12: Retry: attempt #2/3 succeeded, 1 failed attempt ignored
+//
+// and, if all attempts fail, the failures of the last one:
+//
+//	foo_test.go:12: Failed retry: all 3 attempts failed, last one was:
+//	    #1 foo_test.go:14: Failed test
+//	    	DATA: values differ
+//	    		     got: 503
+//	    		expected: 200
+//	    1 failure in attempt
+//
+// If a Fatal-like method is called during an attempt (using
+// [T.Require] for example), only this attempt is stopped. If
+// retries ≤ 0, fn is called only once. FailureIsFatal flag of t
+// applies when reporting the failures of the last attempt.
+//
+// Note that only failures are recorded: logs and cleanup functions
+// (see [testing.T.Cleanup]) registered by any attempt go straight
+// to t.
+//
+// It returns true if one attempt succeeded.
+func (t *T) Retry(retries int, fn func(t *T)) bool {
+	t.Helper()
+
+	attempts := 1
+	if retries > 0 {
+		attempts += retries
+	}
+
+	var (
+		btb     *batchTB
+		stopped bool
+	)
+	for attempt := 1; attempt <= attempts; attempt++ {
+		btb = &batchTB{TB: t.TB}
+		nt := NewT(btb, t.Config)
+		stopped = btb.run(func() { fn(nt) })
+
+		btb.mu.Lock()
+		failed := btb.failed
+		btb.mu.Unlock()
+
+		if !failed {
+			if attempt > 1 {
+				t.Logf("Retry: attempt #%d/%d succeeded, %s ignored",
+					attempt, attempts, plural(attempt-1, "failed attempt"))
+			}
+			return true
+		}
+	}
+
+	var buf strings.Builder
+	color.AppendTestNameOn(&buf)
+	buf.WriteString("Failed retry")
+	color.AppendTestNameOff(&buf)
+	if attempts == 1 {
+		buf.WriteString(": the only attempt failed:")
+	} else {
+		buf.WriteString(": all ")
+		buf.WriteString(strconv.Itoa(attempts))
+		buf.WriteString(" attempts failed, last one was:")
+	}
+	btb.appendFailures(&buf, stopped, "attempt")
+
+	if t.Config.FailureIsFatal {
+		t.TB.Fatal(buf.String())
+	} else {
+		t.TB.Error(buf.String())
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestRetry(tt *testing.T) {
+	t := td.NewT(tt)
+
+	t.Run("OK at first attempt", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		calls := 0
+		ok := td.NewT(ttt).Retry(3, func(t *td.T) {
+			calls++
+			t.Cmp(1, 1)
+		})
+		t.True(ok)
+		t.Cmp(calls, 1)
+		t.False(ttt.Failed())
+		t.Empty(ttt.Messages)
+	})
+
+	t.Run("OK at third attempt", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		calls := 0
+		ok := td.NewT(ttt).Retry(3, func(t *td.T) {
+			calls++
+			t.Cmp(calls, 3)
+		})
+		t.True(ok)
+		t.Cmp(calls, 3)
+		t.False(ttt.Failed())
+		t.Cmp(ttt.Messages, []string{
+			"Retry: attempt #3/4 succeeded, 2 failed attempts ignored",
+		})
+
+		ttt = test.NewTestingTB(t.Name())
+		calls = 0
+		ok = td.NewT(ttt).Retry(1, func(t *td.T) {
+			calls++
+			t.Require().Cmp(calls, 2)
+		})
+		t.True(ok)
+		t.Cmp(ttt.Messages, []string{
+			"Retry: attempt #2/2 succeeded, 1 failed attempt ignored",
+		})
+	})
+
+	t.Run("All attempts fail", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		calls := 0
+		ok := td.NewT(ttt).Retry(2, func(t *td.T) {
+			calls++
+			t.Cmp(calls, 0, "attempt %d", calls)
+		})
+		t.False(ok)
+		t.Cmp(calls, 3)
+		t.True(ttt.Failed())
+		t.False(ttt.IsFatal)
+		t.Cmp(ttt.Messages, td.Len(1))
+		t.Cmp(ttt.LastMessage(), td.Re(`(?s)\AFailed retry: all 3 attempts failed, last one was:
+#1 td/t_retry_test\.go:\d+: Failed test 'attempt 3'
+	DATA: values differ
+		     got: 3
+		expected: 0
+1 failure in attempt\z`))
+	})
+
+	t.Run("No retry", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		calls := 0
+		ok := td.NewT(ttt).Retry(-1, func(t *td.T) {
+			calls++
+			t.Fail()
+		})
+		t.False(ok)
+		t.Cmp(calls, 1)
+		t.Cmp(ttt.LastMessage(),
+			"Failed retry: the only attempt failed:\nAttempt failed without any failure message")
+	})
+
+	t.Run("FailureIsFatal", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		calls := 0
+		ok := true
+		ttt.CatchFatal(func() {
+			ok = td.Require(ttt).Retry(1, func(t *td.T) {
+				calls++
+				t.Cmp(1, 2) // stops the attempt
+				t.Cmp(3, 4)
+			})
+		})
+		t.True(ok) // Retry did not return as Fatal has been called
+		t.Cmp(calls, 2)
+		t.True(ttt.IsFatal)
+		t.Cmp(ttt.LastMessage(),
+			td.Re(`\n1 failure in attempt, stopped by a fatal failure\z`))
+	})
+}
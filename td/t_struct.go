@@ -7,6 +7,7 @@
 package td
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"sync"
@@ -24,6 +25,8 @@ import (
 type T struct {
 	testing.TB
 	Config ContextConfig // defaults to DefaultContextConfig
+
+	ctx context.Context // set by WithTimeout
 }
 
 var _ testing.TB = T{}
@@ -148,6 +151,7 @@ func NewT(t testing.TB, config ...ContextConfig) *T {
 	// Already a *T, so steal its testing.TB and its Config if needed
 	if tdT, ok := t.(*T); ok {
 		newT.TB = tdT.TB
+		newT.ctx = tdT.ctx
 		if len(config) == 0 {
 			newT.Config = tdT.Config
 		} else {
@@ -436,6 +440,151 @@ func (t *T) IgnoreUnexported(types ...any) *T {
 	return t
 }
 
+// IgnoreFields tells go-testdeep to ignore fields of structs whose
+// type is typ.
+//
+// It always returns a new instance of [*T] so does not alter the original t.
+//
+//	t = t.IgnoreFields(Record{}, "CreatedAt", "UpdatedAt")
+//
+// typ can also be a [reflect.Type] item. In this case, the target type
+// is the one reflected by the [reflect.Type].
+//
+//	t = t.IgnoreFields(reflect.TypeOf(Record{}), "CreatedAt", "UpdatedAt")
+//
+// Ignored fields are skipped when comparing 2 structs of type typ, by
+// the [Struct] and [SStruct] operators and when comparing typ to
+// another struct type via [T.MapFields].
+//
+// IgnoreFields calls t.Fatal if typ is not a struct type or if one of
+// fields is not a field of typ.
+func (t *T) IgnoreFields(typ any, fields ...string) *T {
+	t = t.copyWithHooks()
+
+	err := t.Config.hooks.AddIgnoreFields(typ, fields)
+	if err != nil {
+		t.Helper()
+		t.Fatal(color.Bad("IgnoreFields " + err.Error()))
+	}
+
+	return t
+}
+
+// MapFields tells go-testdeep how to compare a got struct of type
+// got to an expected struct of another type expected. Each field of
+// got is compared to the field of expected with the same name,
+// except if mapping contains this name as key. In this case, the
+// got field is compared to the expected field named by the
+// corresponding value.
+//
+// It always returns a new instance of [*T] so does not alter the original t.
+//
+//	// Compare a DTO against an entity
+//	t = t.MapFields(UserDTO{}, User{}, map[string]string{
+//	  "ID":   "UserID",
+//	  "Mail": "Email",
+//	})
+//	t.Cmp(userDTO, user)
+//
+// got and expected can also be [reflect.Type] items. In this case,
+// the target types are the ones reflected by the [reflect.Type]s.
+//
+// The mapping also applies to pointers on got and expected types.
+//
+// Values of fields are compared as if [T.BeLax] was enabled, so
+// different but convertible types can be compared. A got field
+// without counterpart in expected is reported as extra, an expected
+// field without counterpart in got is reported as missing. Fields
+// ignored using [T.IgnoreFields] or [T.IgnoreUnexported] are not
+// taken into account.
+//
+// Only direct fields of got are compared, so mapping keys cannot be
+// promoted fields of embedded structs. As each expected field is
+// compared at most once, two fields of got cannot be mapped, explicitly
+// or not, to the same expected field.
+//
+// MapFields calls t.Fatal if got or expected are not different struct
+// types, if mapping references fields that do not exist, promoted
+// fields of got or twice the same expected field.
+func (t *T) MapFields(got, expected any, mapping map[string]string) *T {
+	t = t.copyWithHooks()
+
+	err := t.Config.hooks.AddFieldsMapping(got, expected, mapping)
+	if err != nil {
+		t.Helper()
+		t.Fatal(color.Bad("MapFields " + err.Error()))
+	}
+
+	return t
+}
+
+// NilEqualsEmpty tells go-testdeep to consider nil and empty values
+// as equal, for slices and maps whose type is one of types.
+//
+// It always returns a new instance of [*T] so does not alter the original t.
+//
+//	t = t.NilEqualsEmpty([]int{}, map[string]bool{})
+//	t.Cmp([]int(nil), []int{}) // succeeds
+//
+// types items can also be [reflect.Type] items. In this case, the
+// target type is the one reflected by the [reflect.Type].
+//
+//	t = t.NilEqualsEmpty(reflect.TypeOf([]int{}))
+//
+// As a special case, calling t.NilEqualsEmpty() or
+// t.NilEqualsEmpty(true) returns an instance considering nil and
+// empty values as equal globally, for all slice and map
+// types. t.NilEqualsEmpty(false) returns an instance making the
+// difference between nil and empty values again, except for types
+// already recorded using a previous NilEqualsEmpty call.
+func (t *T) NilEqualsEmpty(types ...any) *T {
+	// special case: NilEqualsEmpty()
+	if len(types) == 0 {
+		nt := *t
+		nt.Config.NilEqualsEmpty = true
+		return &nt
+	}
+
+	// special cases: NilEqualsEmpty(true) or NilEqualsEmpty(false)
+	if len(types) == 1 {
+		if enable, ok := types[0].(bool); ok {
+			nt := *t
+			nt.Config.NilEqualsEmpty = enable
+			return &nt
+		}
+	}
+
+	// Enable NilEqualsEmpty only for types types
+	t = t.copyWithHooks()
+
+	err := t.Config.hooks.AddNilEqualsEmpty(types)
+	if err != nil {
+		t.Helper()
+		t.Fatal(color.Bad("NilEqualsEmpty " + err.Error()))
+	}
+
+	return t
+}
+
+// TimeEqual tells go-testdeep to compare [time.Time] values using
+// their [time.Time.Equal] method, so two time instants in different
+// locations or with different monotonic clock readings are equal. It
+// avoids enabling [T.UseEqual] globally, which could affect other
+// types.
+//
+// It returns a new instance of [*T] so does not alter the original t.
+//
+//	t = t.TimeEqual()
+//	utc := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+//	t.Cmp(utc.In(time.Local), utc) // succeeds
+//
+// Note that t.TimeEqual() acts as t.TimeEqual(true).
+func (t *T) TimeEqual(enable ...bool) *T {
+	nt := *t
+	nt.Config.TimeEqual = len(enable) == 0 || enable[0]
+	return &nt
+}
+
 // TestDeepInGotOK tells go-testdeep to not panic when a [TestDeep]
 // operator is found on got side. By default it is forbidden because
 // most of the time it is a mistake to compare (expected, got) instead
@@ -7,6 +7,8 @@
 package td_test
 
 import (
+	"net"
+	"reflect"
 	"regexp"
 	"strings"
 	"sync"
@@ -546,6 +548,212 @@ func TestIgnoreUnexported(tt *testing.T) {
 		"IgnoreUnexported expects type int be a struct, not a int (@0)")
 }
 
+func TestIgnoreFields(tt *testing.T) {
+	ttt := test.NewTestingTB(tt.Name())
+
+	type SType struct {
+		ID        int
+		Name      string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	a := SType{ID: 1, Name: "Bob", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	b := SType{ID: 1, Name: "Bob"}
+
+	// Using default config
+	t := td.NewT(ttt)
+	test.IsFalse(tt, t.Cmp(a, b))
+
+	t = td.NewT(ttt).IgnoreFields(SType{}, "CreatedAt")
+	test.IsFalse(tt, t.Cmp(a, b))
+
+	t = t.IgnoreFields(reflect.TypeOf(SType{}), "UpdatedAt")
+	test.IsTrue(tt, t.Cmp(a, b))
+	test.IsTrue(tt, t.Cmp(&a, &b))
+	test.IsTrue(tt, t.Cmp([]SType{a}, []SType{b}))
+	test.IsFalse(tt, t.Cmp(a, SType{ID: 2, Name: "Bob"}))
+
+	// Struct & SStruct operators
+	test.IsTrue(tt, t.Cmp(a, td.Struct(SType{ID: 1, Name: "Bob"}, nil)))
+	test.IsTrue(tt, t.Cmp(a, td.SStruct(SType{ID: 1, Name: "Bob"}, nil)))
+	test.IsFalse(tt, td.NewT(ttt).Cmp(a, td.SStruct(SType{ID: 1, Name: "Bob"}, nil)))
+
+	test.EqualStr(tt,
+		ttt.CatchFatal(func() { td.NewT(ttt).IgnoreFields(42, "ID") }),
+		"IgnoreFields expects type int be a struct, not a int")
+	test.EqualStr(tt,
+		ttt.CatchFatal(func() { td.NewT(ttt).IgnoreFields(SType{}, "Unknown") }),
+		`IgnoreFields expects field "Unknown" exists in type td_test.SType (@0)`)
+}
+
+func TestMapFields(tt *testing.T) {
+	ttt := test.NewTestingTB(tt.Name())
+
+	type Entity struct {
+		UserID    int64
+		Name      string
+		Email     string
+		CreatedAt time.Time
+	}
+	type DTO struct {
+		ID    int
+		Name  string
+		Mail  string
+		Admin bool
+	}
+
+	entity := Entity{UserID: 42, Name: "Bob", Email: "bob@example.com", CreatedAt: time.Now()}
+	dto := DTO{ID: 42, Name: "Bob", Mail: "bob@example.com"}
+
+	// Using default config
+	t := td.NewT(ttt)
+	test.IsFalse(tt, t.Cmp(dto, entity))
+
+	t = td.NewT(ttt).
+		MapFields(DTO{}, Entity{}, map[string]string{
+			"ID":   "UserID",
+			"Mail": "Email",
+		}).
+		IgnoreFields(DTO{}, "Admin").
+		IgnoreFields(Entity{}, "CreatedAt")
+	test.IsTrue(tt, t.Cmp(dto, entity))
+	test.IsTrue(tt, t.Cmp(&dto, &entity))
+	test.IsTrue(tt, t.Cmp((*DTO)(nil), (*Entity)(nil)))
+	test.IsTrue(tt, t.Cmp([]DTO{dto}, td.Bag(entity)))
+	test.IsTrue(tt, t.Cmp(dto, td.Lax(entity)))
+	test.IsFalse(tt, t.Cmp(&dto, (*Entity)(nil)))
+	test.IsFalse(tt, t.Cmp(entity, dto)) // no mapping in this direction
+
+	ttt.ResetMessages()
+	dto.Name = "Alice"
+	test.IsFalse(tt, t.Cmp(dto, entity))
+	test.IsTrue(tt, strings.HasPrefix(ttt.LastMessage(), `Failed test
+DATA.Name: values differ
+	     got: "Alice"
+	expected: "Bob"
+`))
+
+	// Missing & extra fields
+	t = td.NewT(ttt).MapFields(DTO{}, Entity{}, map[string]string{"ID": "UserID"})
+	test.IsFalse(tt, t.Cmp(DTO{ID: 42, Name: "Bob"}, Entity{UserID: 42, Name: "Bob"}))
+	test.IsTrue(tt, strings.HasPrefix(ttt.LastMessage(), `Failed test
+DATA: comparing td_test.DTO and td_test.Entity fields
+	Missing 2 fields: ("CreatedAt",
+	                   "Email")
+	  Extra 2 fields: ("Admin",
+	                   "Mail")
+`))
+
+	test.EqualStr(tt,
+		ttt.CatchFatal(func() { td.NewT(ttt).MapFields(DTO{}, DTO{}, nil) }),
+		"MapFields expects 2 different struct types, not twice td_test.DTO")
+
+	// Promoted fields of got cannot be mapped
+	type Base struct {
+		ID int
+	}
+	type EmbeddedDTO struct {
+		Base
+		Name string
+	}
+	test.EqualStr(tt,
+		ttt.CatchFatal(func() {
+			td.NewT(ttt).MapFields(EmbeddedDTO{}, Entity{}, map[string]string{"ID": "UserID"})
+		}),
+		`MapFields expects field "ID" be a direct field of type td_test.EmbeddedDTO, not a promoted one`)
+
+	// 2 got fields cannot be compared to the same expected field
+	test.EqualStr(tt,
+		ttt.CatchFatal(func() {
+			td.NewT(ttt).MapFields(DTO{}, Entity{}, map[string]string{"Mail": "Name"})
+		}),
+		`MapFields expects only one field of type td_test.DTO mapped to field "Name", not "Mail" and "Name"`)
+}
+
+func TestNilEqualsEmpty(tt *testing.T) {
+	ttt := test.NewTestingTB(tt.Name())
+
+	type MyMap map[string]int
+
+	// Using default config
+	t := td.NewT(ttt)
+	test.IsFalse(tt, t.Cmp([]int{}, []int(nil)))
+
+	// NilEqualsEmpty
+	t = td.NewT(ttt).NilEqualsEmpty() // enable globally
+	test.IsTrue(tt, t.Cmp([]int{}, []int(nil)))
+	test.IsTrue(tt, t.Cmp(MyMap(nil), MyMap{}))
+
+	t = td.NewT(ttt).NilEqualsEmpty(true) // enable globally
+	test.IsTrue(tt, t.Cmp([]int{}, []int(nil)))
+	test.IsTrue(tt, t.Cmp(MyMap(nil), MyMap{}))
+
+	t = td.NewT(ttt).NilEqualsEmpty(false) // disable globally
+	test.IsFalse(tt, t.Cmp([]int{}, []int(nil)))
+	test.IsFalse(tt, t.Cmp(MyMap(nil), MyMap{}))
+
+	t = td.NewT(ttt).NilEqualsEmpty([]int{}) // enable only for []int
+	test.IsTrue(tt, t.Cmp([]int{}, []int(nil)))
+	test.IsFalse(tt, t.Cmp(MyMap(nil), MyMap{}))
+
+	t = t.NilEqualsEmpty().NilEqualsEmpty(false) // enable then disable globally
+	test.IsTrue(tt, t.Cmp([]int{}, []int(nil)))
+	test.IsFalse(tt, t.Cmp(MyMap(nil), MyMap{}))
+
+	t = td.NewT(ttt).NilEqualsEmpty([]int{}, reflect.TypeOf(MyMap{}))
+	test.IsTrue(tt, t.Cmp([]int{}, []int(nil)))
+	test.IsTrue(tt, t.Cmp(MyMap(nil), MyMap{}))
+
+	test.EqualStr(tt,
+		ttt.CatchFatal(func() { td.NewT(ttt).NilEqualsEmpty(42) }),
+		"NilEqualsEmpty expects type int be a slice or a map, not a int (@0)")
+}
+
+func TestTimeEqual(tt *testing.T) {
+	ttt := test.NewTestingTB(tt.Name())
+
+	type Event struct {
+		Name string
+		At   time.Time
+	}
+
+	utc := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+	paris := utc.In(time.FixedZone("Paris", 3600))
+
+	// Using default config
+	t := td.NewT(ttt)
+	test.IsFalse(tt, t.Cmp(paris, utc))
+	test.IsFalse(tt, t.Cmp(Event{Name: "x", At: paris}, Event{Name: "x", At: utc}))
+
+	t = td.NewT(ttt).TimeEqual()
+	test.IsTrue(tt, t.Cmp(paris, utc))
+	test.IsTrue(tt, t.Cmp(Event{Name: "x", At: paris}, Event{Name: "x", At: utc}))
+	test.IsTrue(tt, t.Cmp(&paris, &utc))
+	test.IsFalse(tt, t.Cmp(paris, utc.Add(time.Nanosecond)))
+	test.IsFalse(tt, t.Cmp(Event{Name: "x", At: paris}, Event{Name: "y", At: utc}))
+
+	// Monotonic clock reading is ignored
+	now := time.Now()
+	test.IsTrue(tt, t.Cmp(now, now.Round(0)))
+
+	// Other types are not affected
+	test.IsFalse(tt, t.Cmp(net.IP{127, 0, 0, 1}, net.IPv4(127, 0, 0, 1)))
+
+	// Failure report
+	test.IsFalse(tt, t.Cmp(paris, utc.Add(time.Second)))
+	td.Cmp(tt, ttt.LastMessage(), td.HasPrefix(`Failed test
+DATA: got.Equal(expected) failed
+	     got: (time.Time) 2023-03-09 02:02:03 +0100 Paris
+	expected: (time.Time) 2023-03-09 01:02:04 +0000 UTC
+`))
+
+	t = t.TimeEqual(false)
+	test.IsFalse(tt, t.Cmp(paris, utc))
+
+	t = t.TimeEqual(true)
+	test.IsTrue(tt, t.Cmp(paris, utc))
+}
+
 func TestTestDeepInGotOK(tt *testing.T) {
 	ttt := test.NewTestingTB(tt.Name())
 
@@ -0,0 +1,145 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/color"
+	"github.com/maxatome/go-testdeep/internal/trace"
+)
+
+// Context returns the context of t. Inside [T.WithTimeout], it is
+// the context cancelled when the timeout elapses. Otherwise, if t.TB
+// implements Context() (as [*testing.T] does since go1.24), its
+// result is returned, else [context.Background]() is returned.
+//
+// Note that contrary to other T methods, Context has a value
+// receiver, so T still implements [testing.TB].
+func (t T) Context() context.Context {
+	if t.ctx != nil {
+		return t.ctx
+	}
+	if c, ok := t.TB.(interface{ Context() context.Context }); ok {
+		return c.Context()
+	}
+	return context.Background()
+}
+
+// stripGoroutineTrace removes go-testdeep useless calls at the end of
+// a trace returned by trace.RetrieveGoroutine(), as (*T).WithTimeout
+// and tdsuite ones.
+func stripGoroutineTrace(s trace.Stack) trace.Stack {
+	for len(s) > 1 &&
+		(s.Match(-1, tdPkg) || s.Match(-1, tdsuitePkg) || s.Match(-1, "reflect")) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// withTimeoutEnd describes how the fn passed to [T.WithTimeout] ended.
+type withTimeoutEnd struct {
+	panicked   bool
+	panicValue any
+	goexit     bool // FailNow called
+}
+
+// WithTimeout calls fn with a new [*T] instance inheriting the t
+// config, whose [T.Context] method returns a [context.Context]
+// cancelled once d elapsed.
+//
+// If fn does not return before d elapsed, t fails reporting where fn
+// is still running, and WithTimeout returns false without waiting
+// for fn anymore. As go does not allow to stop a goroutine, fn should
+// honor its context, so it stops as soon as possible after the
+// timeout.
+//
+//	t.WithTimeout(2*time.Second, func(t *td.T) {
+//	  resp, err := client.Do(req.WithContext(t.Context()))
+//	  t.CmpNoError(err)
+//	  t.Cmp(resp.StatusCode, 200)
+//	})
+//
+// produces, if the request does not return in time:
+//
+//	foo_test.go:12: Timeout: fn did not return within 2s, still running at:
+//	    	(*Client).Do()     $GOROOT/src/net/http/client.go:590
+//	    	TestFoo.func1()    foo_test.go:13
+//
+// fn is called in a new goroutine. If fn panics, the panic is
+// propagated to the goroutine of WithTimeout caller. If fn calls a
+// Fatal-like method, FailNow is called on t once fn returned.
+//
+// It returns true if fn returned before d elapsed.
+func (t *T) WithTimeout(d time.Duration, fn func(t *T)) bool {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(t.Context(), d)
+	defer cancel()
+
+	nt := *t
+	nt.ctx = ctx
+
+	ids := make(chan uint64, 1)
+	end := make(chan withTimeoutEnd, 1)
+	go func() {
+		ids <- trace.GoroutineID()
+
+		returned := false
+		defer func() {
+			var e withTimeoutEnd
+			if !returned {
+				if x := recover(); x != nil {
+					e.panicked, e.panicValue = true, x
+				} else {
+					e.goexit = true
+				}
+			}
+			end <- e
+		}()
+
+		fn(&nt)
+		returned = true
+	}()
+	id := <-ids
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case e := <-end:
+		if e.panicked {
+			panic(e.panicValue)
+		}
+		if e.goexit {
+			t.FailNow()
+		}
+		return true
+
+	case <-timer.C:
+	}
+
+	var buf strings.Builder
+	color.AppendTestNameOn(&buf)
+	buf.WriteString("Timeout")
+	color.AppendTestNameOff(&buf)
+	buf.WriteString(": fn did not return within ")
+	buf.WriteString(d.String())
+	if s := stripGoroutineTrace(trace.RetrieveGoroutine(id)); len(s) > 0 {
+		buf.WriteString(", still running at:\n")
+		s.Dump(&buf)
+	}
+
+	if t.Config.FailureIsFatal {
+		t.Fatal(buf.String())
+	} else {
+		t.Error(buf.String())
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// goexitTB calls runtime.Goexit on FailNow, as testing.T does.
+type goexitTB struct{ *test.TestingTB }
+
+func (t goexitTB) FailNow() {
+	t.TestingTB.FailNow()
+	runtime.Goexit()
+}
+
+func failNow(t *td.T) { t.FailNow() }
+
+// inGoroutine calls fn in a new goroutine and waits for its end.
+func inGoroutine(fn func()) {
+	end := make(chan struct{})
+	go func() {
+		defer close(end)
+		fn()
+	}()
+	<-end
+}
+
+func TestWithTimeout(tt *testing.T) {
+	t := td.NewT(tt)
+
+	t.Run("OK", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		var deadline bool
+		ok := td.NewT(ttt).WithTimeout(time.Minute, func(t *td.T) {
+			_, deadline = t.Context().Deadline()
+			t.Cmp(1, 1)
+		})
+		t.True(ok)
+		t.True(deadline)
+		t.False(ttt.Failed())
+		t.Empty(ttt.Messages)
+	})
+
+	t.Run("Timeout", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		unblock := make(chan struct{})
+		defer close(unblock)
+
+		var ctxErr error
+		ok := td.NewT(ttt).WithTimeout(10*time.Millisecond, func(t *td.T) {
+			<-t.Context().Done()
+			ctxErr = t.Context().Err()
+			<-unblock
+		})
+		t.False(ok)
+		t.True(ttt.Failed())
+		t.False(ttt.IsFatal)
+		t.Cmp(ttt.LastMessage(), td.Re(`\ATimeout: fn did not return within 10ms, still running at:
+	TestWithTimeout\.func\d+\.\d+\(\) +td/t_timeout_test\.go:\d+`))
+
+		unblock <- struct{}{}
+		// WithTimeout may have returned before the deadline is detected
+		t.Cmp(ctxErr, td.Any(context.DeadlineExceeded, context.Canceled))
+	})
+
+	t.Run("Timeout fatal", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		unblock := make(chan struct{})
+		defer close(unblock)
+
+		ok := true
+		ttt.CatchFatal(func() {
+			ok = td.Require(ttt).WithTimeout(time.Millisecond, func(t *td.T) {
+				<-unblock
+			})
+		})
+		t.True(ok) // WithTimeout did not return as Fatal has been called
+		t.True(ttt.IsFatal)
+		t.Cmp(ttt.LastMessage(), td.HasPrefix("Timeout: fn did not return within 1ms"))
+	})
+
+	t.Run("Panic", func(t *td.T) {
+		test.CheckPanic(tt, func() {
+			td.NewT(tt).WithTimeout(time.Minute, func(t *td.T) {
+				panic("boom")
+			})
+		}, "boom")
+	})
+
+	t.Run("FailNow", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+
+		ok, afterFailNow := true, false
+		inGoroutine(func() {
+			ok = td.NewT(goexitTB{ttt}).WithTimeout(time.Minute, failNow)
+			afterFailNow = true
+		})
+
+		t.True(ok) // WithTimeout did not return as FailNow has been called
+		t.False(afterFailNow)
+		t.True(ttt.IsFatal)
+	})
+
+	t.Run("Context", func(t *td.T) {
+		ttt := test.NewTestingTB(t.Name())
+		t.Cmp(td.NewT(ttt).Context(), context.Background())
+	})
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+// ArchiveEntry is an entry of an archive, as [Zip] and [Tar]
+// operators expose them.
+type ArchiveEntry struct {
+	// Content is the content of the entry.
+	Content string
+	// Mode is the mode of the entry.
+	Mode fs.FileMode
+	// ModTime is the modification time of the entry.
+	ModTime time.Time
+}
+
+type archiveKind uint8
+
+const (
+	zipArchive archiveKind = iota
+	tarArchive
+)
+
+type tdArchive struct {
+	tdSmugglerBase
+	kind archiveKind
+}
+
+var _ TestDeep = &tdArchive{}
+
+func newArchive(kind archiveKind, expectedValue any) *tdArchive {
+	a := tdArchive{
+		tdSmugglerBase: newSmugglerBase(expectedValue, 1),
+		kind:           kind,
+	}
+	if !a.isTestDeeper {
+		a.expectedValue = reflect.ValueOf(expectedValue)
+	}
+	return &a
+}
+
+// summary(Zip): reads a zip archive then compares its entries
+// input(Zip): str(file path),slice([]byte),if(io.ReaderAt/io.Reader)
+
+// Zip is a smuggler operator. It reads got as a zip archive and
+// compares its entries to expectedValue. got can be:
+//   - a []byte (or convertible) containing the archive;
+//   - an [io.ReaderAt], having a Size() int64 method like
+//     [bytes.Reader] or a Stat() method like [os.File];
+//   - an [io.Reader], read until EOF;
+//   - a string containing the path of the archive file.
+//
+// The entries are exposed as a map[string][ArchiveEntry], keyed by
+// their path in the archive. Directories are not exposed, only the
+// files they contain. So expectedValue is typically a [Map] or a
+// [SuperMapOf] operator, or a map[string][ArchiveEntry]:
+//
+//	td.Cmp(t, zipContent, td.Zip(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+//	  "README.md": td.Smuggle("Content", td.HasPrefix("# ")),
+//	  "data.json": td.Smuggle("Content",
+//	    td.Smuggle(json.RawMessage(nil), td.JSON(`{"id": NotZero()}`))),
+//	})))
+//
+//	td.Cmp(t, "/path/to/file.zip", td.Zip(td.Keys(td.Bag("README.md", "data.json"))))
+//
+// In case of failure, the path reported contains the entry path, as in
+// DATA<zip>["data.json"].Content.
+//
+// See also [ArchiveEntry], [FS] and [Tar].
+func Zip(expectedValue any) TestDeep {
+	return newArchive(zipArchive, expectedValue)
+}
+
+// summary(Tar): reads a tar (optionally gzip'ed) archive then
+// compares its entries
+// input(Tar): str(file path),slice([]byte),if(io.ReaderAt/io.Reader)
+
+// Tar is a smuggler operator. It reads got as a tar archive,
+// optionally gzip'ed (as .tar.gz or .tgz files), and compares its
+// entries to expectedValue. got can be:
+//   - a []byte (or convertible) containing the archive;
+//   - an [io.ReaderAt], having a Size() int64 method like
+//     [bytes.Reader] or a Stat() method like [os.File];
+//   - an [io.Reader], read until EOF;
+//   - a string containing the path of the archive file.
+//
+// The entries are exposed as a map[string][ArchiveEntry], keyed by
+// their path in the archive, without any "./" prefix. Directories
+// are not exposed, only the files they contain. So expectedValue is
+// typically a [Map] or a [SuperMapOf] operator, or a
+// map[string][ArchiveEntry]:
+//
+//	td.Cmp(t, "/path/to/file.tar.gz", td.Tar(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+//	  "bin/tool":  td.Smuggle("Mode", fs.FileMode(0o755)),
+//	  "README.md": td.Smuggle("Content", td.Contains("Usage")),
+//	})))
+//
+// In case of failure, the path reported contains the entry path, as in
+// DATA<tar>["bin/tool"].Mode.
+//
+// See also [ArchiveEntry], [FS] and [Zip].
+func Tar(expectedValue any) TestDeep {
+	return newArchive(tarArchive, expectedValue)
+}
+
+// archiveSource returns the archive behind got as an [io.ReaderAt]
+// and its size. The returned close function has to be called once
+// the archive has been read. If got cannot be handled, a nil
+// io.ReaderAt is returned and the error can be returned as is.
+func archiveSource(ctx ctxerr.Context, got reflect.Value) (io.ReaderAt, int64, func(), *ctxerr.Error) {
+	noop := func() {}
+
+	var gotIf any
+	if got.IsValid() {
+		gotIf, _ = dark.GetInterface(got, true)
+	}
+
+	switch g := gotIf.(type) {
+	case []byte:
+		return bytes.NewReader(g), int64(len(g)), noop, nil
+
+	case string:
+		f, err := os.Open(g)
+		if err != nil {
+			return nil, 0, nil, fileError(ctx, "cannot open archive", err)
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close() //nolint: errcheck
+			return nil, 0, nil, fileError(ctx, "cannot open archive", err)
+		}
+		return f, info.Size(), func() { f.Close() }, nil //nolint: errcheck
+
+	case io.ReaderAt:
+		switch s := g.(type) {
+		case interface{ Size() int64 }:
+			return g, s.Size(), noop, nil
+		case interface{ Stat() (fs.FileInfo, error) }:
+			info, err := s.Stat()
+			if err != nil {
+				return nil, 0, nil, fileError(ctx, "cannot open archive", err)
+			}
+			return g, info.Size(), noop, nil
+		}
+	}
+
+	switch g := gotIf.(type) {
+	case io.Reader:
+		b, err := io.ReadAll(g)
+		if err != nil {
+			return nil, 0, nil, fileError(ctx, "cannot read archive", err)
+		}
+		return bytes.NewReader(b), int64(len(b)), noop, nil
+	}
+
+	// []byte convertible types
+	if got.IsValid() && got.Type().ConvertibleTo(reflect.TypeOf([]byte(nil))) &&
+		got.Kind() == reflect.Slice {
+		b := got.Convert(reflect.TypeOf([]byte(nil))).Bytes()
+		return bytes.NewReader(b), int64(len(b)), noop, nil
+	}
+
+	if ctx.BooleanError {
+		return nil, 0, nil, ctxerr.BooleanError
+	}
+	gotType := types.RawString("nil")
+	if got.IsValid() {
+		gotType = types.RawString(got.Type().String())
+	}
+	return nil, 0, nil, ctx.CollectError(&ctxerr.Error{
+		Message:  "bad type",
+		Got:      gotType,
+		Expected: types.RawString("[]byte OR io.ReaderAt OR io.Reader OR string (file path)"),
+	})
+}
+
+// readZip returns the files of the zip archive in r.
+func readZip(r io.ReaderAt, size int64) (map[string]ArchiveEntry, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]ArchiveEntry, len(zr.File))
+	for _, f := range zr.File {
+		info := f.FileInfo()
+		if info.IsDir() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close() //nolint: errcheck
+		if err != nil {
+			return nil, err
+		}
+
+		entries[path.Clean(f.Name)] = ArchiveEntry{
+			Content: string(content),
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+		}
+	}
+	return entries, nil
+}
+
+// readTar returns the files of the tar archive in r, gunzip'ing it
+// before if needed.
+func readTar(r io.ReaderAt, size int64) (map[string]ArchiveEntry, error) {
+	var in io.Reader = io.NewSectionReader(r, 0, size)
+
+	br := bufio.NewReader(in)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close() //nolint: errcheck
+		in = gr
+	} else {
+		in = br
+	}
+
+	entries := map[string]ArchiveEntry{}
+	tr := tar.NewReader(in)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, err
+		}
+
+		info := hdr.FileInfo()
+		if info.IsDir() {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		entries[path.Clean(hdr.Name)] = ArchiveEntry{
+			Content: string(content),
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+		}
+	}
+}
+
+func (a *tdArchive) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	r, size, closeFn, rErr := archiveSource(ctx, got)
+	if r == nil {
+		return rErr
+	}
+
+	var (
+		entries map[string]ArchiveEntry
+		err     error
+		level   string
+		message string
+	)
+	if a.kind == zipArchive {
+		entries, err = readZip(r, size)
+		level, message = "<zip>", "cannot read zip archive"
+	} else {
+		entries, err = readTar(r, size)
+		level, message = "<tar>", "cannot read tar archive"
+	}
+	closeFn()
+	if err != nil {
+		return fileError(ctx, message, err)
+	}
+
+	return deepValueEqual(ctx.AddCustomLevel(level),
+		reflect.ValueOf(entries), a.expectedValue)
+}
+
+func (a *tdArchive) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (a *tdArchive) String() string {
+	name := "Zip("
+	if a.kind == tarArchive {
+		name = "Tar("
+	}
+	return name + util.ToString(a.expectedValue) + ")"
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+var archiveModTime = time.Date(2023, time.March, 9, 12, 0, 0, 0, time.UTC)
+
+func newZipTestArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"dir/", "README.md", "dir/data.json"} {
+		hdr := &zip.FileHeader{Name: name, Modified: archiveModTime}
+		if strings.HasSuffix(name, "/") {
+			hdr.SetMode(fs.ModeDir | 0o755)
+		} else {
+			hdr.SetMode(0o644)
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch name {
+		case "README.md":
+			w.Write([]byte("# Title\n")) //nolint: errcheck
+		case "dir/data.json":
+			w.Write([]byte(`{"id":42}`)) //nolint: errcheck
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTarTestArchive(t *testing.T, gzipped bool) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Name: "./dir/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: archiveModTime},
+		{Name: "./README.md", Mode: 0o644, Size: 8, ModTime: archiveModTime},
+		{Name: "./dir/tool", Mode: 0o755, Size: 10, ModTime: archiveModTime},
+	} {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		switch hdr.Name {
+		case "./README.md":
+			tw.Write([]byte("# Title\n")) //nolint: errcheck
+		case "./dir/tool":
+			tw.Write([]byte("#!/bin/sh\n")) //nolint: errcheck
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !gzipped {
+		return buf.Bytes()
+	}
+
+	var gzBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzBuf)
+	gw.Write(buf.Bytes()) //nolint: errcheck
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return gzBuf.Bytes()
+}
+
+func TestZip(t *testing.T) {
+	archive := newZipTestArchive(t)
+
+	checkOK(t, archive, td.Zip(td.Keys(td.Bag("README.md", "dir/data.json"))))
+	checkOK(t, archive, td.Zip(map[string]td.ArchiveEntry{
+		"README.md": {
+			Content: "# Title\n",
+			Mode:    0o644,
+			ModTime: archiveModTime,
+		},
+		"dir/data.json": {
+			Content: `{"id":42}`,
+			Mode:    0o644,
+			ModTime: archiveModTime,
+		},
+	}))
+	checkOK(t, archive, td.Zip(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+		"dir/data.json": td.Smuggle("Content",
+			td.Smuggle(json.RawMessage(nil), td.JSON(`{"id": NotZero()}`))),
+	})))
+
+	type MyBytes []byte
+	checkOK(t, MyBytes(archive), td.Zip(td.Len(2)))
+	_checkOK(t, bytes.NewReader(archive), td.Zip(td.Len(2)))
+	// io.Reader only, so read once
+	test.IsTrue(t, td.Cmp(t, bytes.NewBuffer(archive), td.Zip(td.Len(2))))
+
+	// File path & *os.File
+	file := filepath.Join(t.TempDir(), "test.zip")
+	if err := os.WriteFile(file, archive, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checkOK(t, file, td.Zip(td.Len(2)))
+
+	fh, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fh.Close()
+	_checkOK(t, fh, td.Zip(td.Len(2)))
+
+	checkError(t, archive, td.Zip(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+		"dir/data.json": td.Smuggle("Content", td.HasPrefix("[")),
+	})),
+		expectedError{
+			Message:  mustBe("has not prefix"),
+			Path:     mustBe(`DATA<zip>["dir/data.json"].Content`),
+			Got:      mustBe("`{\"id\":42}`"),
+			Expected: mustBe(`HasPrefix("[")`),
+		})
+
+	checkError(t, archive, td.Zip(td.Keys([]string{"README.md"})),
+		expectedError{
+			Message: mustBe("comparing slices, from index #1"),
+			Path:    mustBe("keys(DATA<zip>)"),
+		})
+
+	checkError(t, []byte("not a zip"), td.Zip(td.Ignore()),
+		expectedError{
+			Message: mustBe("cannot read zip archive"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("zip: not a valid zip file"),
+		})
+
+	checkError(t, filepath.Join(t.TempDir(), "unknown.zip"), td.Zip(td.Ignore()),
+		expectedError{
+			Message: mustBe("cannot open archive"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("no such file or directory"),
+		})
+
+	checkError(t, 12, td.Zip(td.Ignore()),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("[]byte OR io.ReaderAt OR io.Reader OR string (file path)"),
+		})
+
+	checkError(t, nil, td.Zip(td.Ignore()),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("[]byte OR io.ReaderAt OR io.Reader OR string (file path)"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.Zip(td.Len(2)).String(), "Zip(len=2)")
+	test.EqualStr(t, td.Zip(12).String(), "Zip(12)")
+}
+
+func TestZipTypeBehind(t *testing.T) {
+	equalTypes(t, td.Zip(nil), nil)
+}
+
+func TestTar(t *testing.T) {
+	for _, gzipped := range []bool{false, true} {
+		archive := newTarTestArchive(t, gzipped)
+
+		checkOK(t, archive, td.Tar(td.Keys(td.Bag("README.md", "dir/tool"))))
+		checkOK(t, archive, td.Tar(map[string]td.ArchiveEntry{
+			"README.md": {
+				Content: "# Title\n",
+				Mode:    0o644,
+				ModTime: archiveModTime.Local(), // tar uses local time
+			},
+			"dir/tool": {
+				Content: "#!/bin/sh\n",
+				Mode:    0o755,
+				ModTime: archiveModTime.Local(), // tar uses local time
+			},
+		}))
+		_checkOK(t, bytes.NewReader(archive), td.Tar(td.Len(2)))
+
+		checkError(t, archive, td.Tar(td.SuperMapOf(map[string]td.ArchiveEntry{}, td.MapEntries{
+			"dir/tool": td.Smuggle("Mode", fs.FileMode(0o644)),
+		})),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe(`DATA<tar>["dir/tool"].Mode`),
+				Got:      mustBe("(fs.FileMode) -rwxr-xr-x"),
+				Expected: mustBe("(fs.FileMode) -rw-r--r--"),
+			})
+	}
+
+	checkError(t, []byte{0x1f, 0x8b, 0}, td.Tar(td.Ignore()),
+		expectedError{
+			Message: mustBe("cannot read tar archive"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("unexpected EOF"),
+		})
+
+	checkError(t, true, td.Tar(td.Ignore()),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("bool"),
+			Expected: mustBe("[]byte OR io.ReaderAt OR io.Reader OR string (file path)"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.Tar(td.Len(2)).String(), "Tar(len=2)")
+}
+
+func TestTarTypeBehind(t *testing.T) {
+	equalTypes(t, td.Tar(nil), nil)
+}
@@ -359,6 +359,7 @@ func (a *tdArray) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 	}
 
 	gotLen := got.Len()
+	ctx.Parent = got
 
 	check := func(index int, expectedValue reflect.Value) *ctxerr.Error {
 		curCtx := ctx.AddArrayIndex(index)
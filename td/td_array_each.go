@@ -84,6 +84,7 @@ func (a *tdArrayEach) Match(ctx ctxerr.Context, got reflect.Value) (err *ctxerr.
 		gotLen := got.Len()
 
 		var err *ctxerr.Error
+		ctx.Parent = got
 		for idx := 0; idx < gotLen; idx++ {
 			err = deepValueEqual(ctx.AddArrayIndex(idx), got.Index(idx), a.expected)
 			if err != nil {
@@ -92,8 +92,17 @@ type tdBetweenCmp struct {
 //	  netip.MustParse("127.0.0.1"),
 //	  td.Between(netip.MustParse("127.0.0.0"), netip.MustParse("127.255.255.255")))
 //
-// TypeBehind method returns the [reflect.Type] of from.
+// from and to can also be [Ref]s, resolved at match time.
+//
+// TypeBehind method returns the [reflect.Type] of from, or nil if
+// from or to is a [Ref].
 func Between(from, to any, bounds ...BoundsKind) TestDeep {
+	if hasRef(from, to) {
+		return newRefOperand(func(params []any) TestDeep {
+			return Between(params[0], params[1], bounds...)
+		}, from, to)
+	}
+
 	b := tdBetween{
 		base:        newBase(3),
 		expectedMin: reflect.ValueOf(from),
@@ -302,8 +311,17 @@ func (b *tdBetween) nFloat(tolerance reflect.Value) {
 //	td.Cmp(t, 12.2, td.N(12., 0.3)) // succeeds
 //	td.Cmp(t, 12.2, td.N(12., 0.1)) // fails
 //
-// TypeBehind method returns the [reflect.Type] of num.
+// num and tolerance can also be [Ref]s, resolved at match time.
+//
+// TypeBehind method returns the [reflect.Type] of num, or nil if num
+// or tolerance is a [Ref].
 func N(num any, tolerance ...any) TestDeep {
+	if hasRef(append([]any{num}, tolerance...)...) {
+		return newRefOperand(func(params []any) TestDeep {
+			return N(params[0], params[1:]...)
+		}, append([]any{num}, tolerance...)...)
+	}
+
 	n := tdBetween{
 		base:        newBase(3),
 		expectedMin: reflect.ValueOf(num),
@@ -373,8 +391,17 @@ func N(num any, tolerance ...any) TestDeep {
 //	before := time.Now()
 //	td.Cmp(t, time.Now(), td.Gt(before))
 //
-// TypeBehind method returns the [reflect.Type] of minExpectedValue.
+// minExpectedValue can also be a [Ref], resolved at match time.
+//
+// TypeBehind method returns the [reflect.Type] of minExpectedValue, or nil
+// if it is a [Ref].
 func Gt(minExpectedValue any) TestDeep {
+	if hasRef(minExpectedValue) {
+		return newRefOperand(func(params []any) TestDeep {
+			return Gt(params[0])
+		}, minExpectedValue)
+	}
+
 	b := &tdBetween{
 		base:        newBase(3),
 		expectedMin: reflect.ValueOf(minExpectedValue),
@@ -402,8 +429,17 @@ func Gt(minExpectedValue any) TestDeep {
 //	before := time.Now()
 //	td.Cmp(t, time.Now(), td.Gte(before))
 //
-// TypeBehind method returns the [reflect.Type] of minExpectedValue.
+// minExpectedValue can also be a [Ref], resolved at match time.
+//
+// TypeBehind method returns the [reflect.Type] of minExpectedValue, or nil
+// if it is a [Ref].
 func Gte(minExpectedValue any) TestDeep {
+	if hasRef(minExpectedValue) {
+		return newRefOperand(func(params []any) TestDeep {
+			return Gte(params[0])
+		}, minExpectedValue)
+	}
+
 	b := &tdBetween{
 		base:        newBase(3),
 		expectedMin: reflect.ValueOf(minExpectedValue),
@@ -431,8 +467,17 @@ func Gte(minExpectedValue any) TestDeep {
 //	before := time.Now()
 //	td.Cmp(t, before, td.Lt(time.Now()))
 //
-// TypeBehind method returns the [reflect.Type] of maxExpectedValue.
+// maxExpectedValue can also be a [Ref], resolved at match time.
+//
+// TypeBehind method returns the [reflect.Type] of maxExpectedValue, or nil
+// if it is a [Ref].
 func Lt(maxExpectedValue any) TestDeep {
+	if hasRef(maxExpectedValue) {
+		return newRefOperand(func(params []any) TestDeep {
+			return Lt(params[0])
+		}, maxExpectedValue)
+	}
+
 	b := &tdBetween{
 		base:        newBase(3),
 		expectedMin: reflect.ValueOf(maxExpectedValue),
@@ -460,8 +505,17 @@ func Lt(maxExpectedValue any) TestDeep {
 //	before := time.Now()
 //	td.Cmp(t, before, td.Lt(time.Now()))
 //
-// TypeBehind method returns the [reflect.Type] of maxExpectedValue.
+// maxExpectedValue can also be a [Ref], resolved at match time.
+//
+// TypeBehind method returns the [reflect.Type] of maxExpectedValue, or nil
+// if it is a [Ref].
 func Lte(maxExpectedValue any) TestDeep {
+	if hasRef(maxExpectedValue) {
+		return newRefOperand(func(params []any) TestDeep {
+			return Lte(params[0])
+		}, maxExpectedValue)
+	}
+
 	b := &tdBetween{
 		base:        newBase(3),
 		expectedMin: reflect.ValueOf(maxExpectedValue),
@@ -0,0 +1,346 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+// FileEntry allows to check several properties of a file using [File]
+// or [FS] operators. Each field can be a [TestDeep] operator. A nil
+// field is not checked.
+type FileEntry struct {
+	// Content is compared to the file content, as [ReaderContent]
+	// does.
+	Content any
+	// Mode is compared to the [fs.FileMode] of the file.
+	Mode any
+	// Size is compared to the int64 size of the file.
+	Size any
+	// ModTime is compared to the [time.Time] modification time of
+	// the file.
+	ModTime any
+}
+
+// fileExpectation returns the FileEntry behind expected. If expected
+// is not a FileEntry nor a *FileEntry, it is the expected content of
+// the file and the returned bool is false.
+func fileExpectation(expected any) (FileEntry, bool) {
+	switch e := expected.(type) {
+	case FileEntry:
+		return e, true
+	case *FileEntry:
+		if e != nil {
+			return *e, true
+		}
+	}
+	return FileEntry{Content: expected}, false
+}
+
+func fileError(ctx ctxerr.Context, message string, err error) *ctxerr.Error {
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message: message,
+		Summary: ctxerr.NewSummary(err.Error()),
+	})
+}
+
+// gotFS returns the [fs.FS] behind got, an [fs.FS] or a string
+// containing the path of a directory. If it cannot be determined,
+// the returned fs.FS is nil and the error can be returned as is.
+func gotFS(ctx ctxerr.Context, got reflect.Value) (fs.FS, *ctxerr.Error) {
+	if got.IsValid() {
+		gotIf, _ := dark.GetInterface(got, true)
+		switch g := gotIf.(type) {
+		case fs.FS:
+			return g, nil
+
+		case string:
+			info, err := os.Stat(g)
+			if err != nil {
+				return nil, fileError(ctx, "cannot open directory", err)
+			}
+			if !info.IsDir() {
+				if ctx.BooleanError {
+					return nil, ctxerr.BooleanError
+				}
+				return nil, ctx.CollectError(&ctxerr.Error{
+					Message:  "not a directory",
+					Got:      got,
+					Expected: types.RawString("directory"),
+				})
+			}
+			return os.DirFS(g), nil
+		}
+	}
+
+	if ctx.BooleanError {
+		return nil, ctxerr.BooleanError
+	}
+	gotType := types.RawString("nil")
+	if got.IsValid() {
+		gotType = types.RawString(got.Type().String())
+	}
+	return nil, ctx.CollectError(&ctxerr.Error{
+		Message:  "bad type",
+		Got:      gotType,
+		Expected: types.RawString("fs.FS OR string"),
+	})
+}
+
+// checkFile compares the file name of fsys to expected, a [FileEntry]
+// or the expected content of the file.
+func checkFile(ctx ctxerr.Context, fsys fs.FS, name string, expected any) *ctxerr.Error {
+	entry, isEntry := fileExpectation(expected)
+
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return fileError(ctx, "cannot stat file", err)
+	}
+
+	// Mode, Size and ModTime are compared in Lax mode, so the user
+	// does not have to type the expected values
+	for _, field := range [...]struct {
+		name     string
+		got      any
+		expected any
+	}{
+		{name: "Mode", got: info.Mode(), expected: entry.Mode},
+		{name: "Size", got: info.Size(), expected: entry.Size},
+		{name: "ModTime", got: info.ModTime(), expected: entry.ModTime},
+	} {
+		if field.expected == nil {
+			continue
+		}
+		fieldCtx := ctx.AddField(field.name)
+		fieldCtx.BeLax = true
+		err := deepValueEqual(fieldCtx,
+			reflect.ValueOf(field.got), reflect.ValueOf(field.expected))
+		if err != nil {
+			return err
+		}
+	}
+
+	if isEntry && entry.Content == nil {
+		return nil
+	}
+
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fileError(ctx, "cannot read file", err)
+	}
+
+	contentCtx := ctx.AddCustomLevel(contentLevel)
+	if isEntry {
+		contentCtx = ctx.AddField("Content")
+	}
+	expectedContent := reflect.ValueOf(entry.Content)
+	return deepValueEqual(contentCtx,
+		contentValue(content, expectedContent), expectedContent)
+}
+
+type tdFile struct {
+	base
+	path     string
+	expected any
+}
+
+var _ TestDeep = &tdFile{}
+
+// summary(File): compares the content and/or the properties of a
+// file of a directory tree
+// input(File): str(directory path),map(fstest.MapFS),if(fs.FS)
+
+// File operator compares the file at path of got, an [fs.FS] or a
+// string containing the path of a directory, to expectedValue.
+//
+// expectedValue can be a [FileEntry], to check several properties of
+// the file, or any other value, then compared to the file content as
+// [ReaderContent] does.
+//
+//	td.Cmp(t, "/path/to/dir", td.File("go.mod", td.Contains("module"))) // succeeds
+//	td.Cmp(t, os.DirFS("/path/to/dir"), td.File("go.mod", td.FileEntry{
+//	  Content: td.HasPrefix("module "),
+//	  Mode:    fs.FileMode(0o644),
+//	})) // succeeds
+//
+// path has to be a valid path as [fs.ValidPath] expects: unrooted,
+// slash-separated and without "." or ".." elements.
+//
+// See also [FS] and [ReaderContent].
+func File(path string, expectedValue any) TestDeep {
+	f := tdFile{
+		base:     newBase(3),
+		path:     path,
+		expected: expectedValue,
+	}
+	if !fs.ValidPath(path) {
+		f.err = ctxerr.OpBad("File",
+			"File(PATH, EXPECTED_VALUE): %q is not a valid path, see fs.ValidPath", path)
+	}
+	return &f
+}
+
+func (f *tdFile) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if f.err != nil {
+		return ctx.CollectError(f.err)
+	}
+
+	fsys, rErr := gotFS(ctx, got)
+	if fsys == nil {
+		return rErr
+	}
+	return checkFile(ctx.AddMapKey(f.path), fsys, f.path, f.expected)
+}
+
+func (f *tdFile) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (f *tdFile) String() string {
+	if f.err != nil {
+		return f.stringError()
+	}
+	return "File(" + strconv.Quote(f.path) + ", " + util.ToString(f.expected) + ")"
+}
+
+type tdFS struct {
+	base
+	expected map[string]any
+	names    []string // sorted keys of expected
+}
+
+var _ TestDeep = &tdFS{}
+
+// summary(FS): compares the files of a directory tree
+// input(FS): str(directory path),map(fstest.MapFS),if(fs.FS)
+
+// FS operator walks got, an [fs.FS] or a string containing the path
+// of a directory, and compares all the files it contains to
+// expectedFiles. Each key of expectedFiles is the path of a file,
+// relative to the root of got, and each value the expectation for
+// this file: a [FileEntry], to check several properties of the file,
+// or any other value, then compared to the file content as
+// [ReaderContent] does.
+//
+//	td.Cmp(t, "/path/to/generated", td.FS(map[string]any{
+//	  "README.md":   td.HasPrefix("# "),
+//	  "go.mod":      "module example.com/foo\n",
+//	  "cmd/main.go": td.FileEntry{Content: td.Contains("func main()"), Mode: 0o644},
+//	  "LICENSE":     td.Ignore(), // only has to exist
+//	}))
+//
+// got has to contain exactly the files of expectedFiles: missing and
+// extra files are reported. Directories are not compared, only the
+// files they contain, so empty directories are ignored. But a key
+// of expectedFiles can designate a directory, to check its mode or
+// modification time using a [FileEntry].
+//
+// Each key has to be a valid path as [fs.ValidPath] expects:
+// unrooted, slash-separated and without "." or ".." elements.
+//
+// A [testing/fstest.MapFS] is a convenient way to build a got tree
+// in memory.
+//
+// See also [File], [Map] and [ReaderContent].
+func FS(expectedFiles map[string]any) TestDeep {
+	f := tdFS{
+		base:     newBase(3),
+		expected: expectedFiles,
+		names:    make([]string, 0, len(expectedFiles)),
+	}
+	for name := range expectedFiles {
+		if !fs.ValidPath(name) {
+			f.err = ctxerr.OpBad("FS",
+				"FS(EXPECTED_FILES): %q is not a valid path, see fs.ValidPath", name)
+			return &f
+		}
+		f.names = append(f.names, name)
+	}
+	sort.Strings(f.names)
+	return &f
+}
+
+func (f *tdFS) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if f.err != nil {
+		return ctx.CollectError(f.err)
+	}
+
+	fsys, rErr := gotFS(ctx, got)
+	if fsys == nil {
+		return rErr
+	}
+
+	var files []string
+	exists := map[string]bool{}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		exists[name] = true
+		if !d.IsDir() {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return fileError(ctx, "cannot walk directory tree", err)
+	}
+
+	var res tdSetResult
+	for _, name := range f.names {
+		if !exists[name] {
+			res.Missing = append(res.Missing, reflect.ValueOf(name))
+			continue
+		}
+		err := checkFile(ctx.AddMapKey(name), fsys, name, f.expected[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range files {
+		if _, ok := f.expected[name]; !ok {
+			res.Extra = append(res.Extra, reflect.ValueOf(name))
+		}
+	}
+
+	if res.IsEmpty() {
+		return nil
+	}
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	res.Kind = filesSetResult
+	res.Sort = true
+	return ctx.CollectError(&ctxerr.Error{
+		Message: "comparing files of %%",
+		Summary: res.Summary(),
+	})
+}
+
+func (f *tdFS) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (f *tdFS) String() string {
+	if f.err != nil {
+		return f.stringError()
+	}
+	return "FS(" + util.ToString(f.expected) + ")"
+}
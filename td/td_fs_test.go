@@ -0,0 +1,244 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func newFSTestTree() fstest.MapFS {
+	return fstest.MapFS{
+		"README.md": {Data: []byte("# Title\n"), Mode: 0o644},
+		"go.mod":    {Data: []byte("module example.com/foo\n"), Mode: 0o644},
+		"cmd/main.go": {
+			Data:    []byte("package main\n\nfunc main() {}\n"),
+			Mode:    0o755,
+			ModTime: time.Date(2023, time.March, 9, 12, 0, 0, 0, time.UTC),
+		},
+		"empty": {Mode: fs.ModeDir | 0o700},
+	}
+}
+
+func TestFile(t *testing.T) {
+	tree := newFSTestTree()
+
+	checkOK(t, tree, td.File("go.mod", "module example.com/foo\n"))
+	checkOK(t, tree, td.File("go.mod", td.HasPrefix("module ")))
+	checkOK(t, tree, td.File("cmd/main.go", td.FileEntry{
+		Content: td.Contains("func main()"),
+		Mode:    0o755,
+		Size:    29,
+		ModTime: td.Between(
+			time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC)),
+	}))
+	checkOK(t, tree, td.File("cmd/main.go", &td.FileEntry{Mode: fs.FileMode(0o755)}))
+	checkOK(t, tree, td.File("cmd", td.FileEntry{Mode: td.Code(fs.FileMode.IsDir)}))
+
+	// Real directory
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "foo.txt"), []byte("bar"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checkOK(t, dir, td.File("foo.txt", "bar"))
+	checkOK(t, os.DirFS(dir), td.File("foo.txt", []byte("bar")))
+
+	checkError(t, tree, td.File("go.mod", "module foo\n"),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe(`DATA["go.mod"]<content>`),
+			Got:      mustContain("module example.com/foo"),
+			Expected: mustContain("module foo"),
+		})
+
+	checkError(t, tree, td.File("cmd/main.go", td.FileEntry{Mode: 0o644}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe(`DATA["cmd/main.go"].Mode`),
+			Got:      mustBe("(fs.FileMode) -rwxr-xr-x"),
+			Expected: mustBe("(fs.FileMode) -rw-r--r--"),
+		})
+
+	checkError(t, tree, td.File("cmd/main.go", td.FileEntry{Content: td.Empty()}),
+		expectedError{
+			Message: mustBe("not empty"),
+			Path:    mustBe(`DATA["cmd/main.go"].Content`),
+		})
+
+	checkError(t, tree, td.File("unknown", td.Ignore()),
+		expectedError{
+			Message: mustBe("cannot stat file"),
+			Path:    mustBe(`DATA["unknown"]`),
+			Summary: mustContain("file does not exist"),
+		})
+
+	checkError(t, tree, td.File("cmd", "foo"),
+		expectedError{
+			Message: mustBe("cannot read file"),
+			Path:    mustBe(`DATA["cmd"]`),
+		})
+
+	checkError(t, filepath.Join(dir, "unknown"), td.File("foo.txt", "bar"),
+		expectedError{
+			Message: mustBe("cannot open directory"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("no such file or directory"),
+		})
+
+	checkError(t, filepath.Join(dir, "foo.txt"), td.File("foo.txt", "bar"),
+		expectedError{
+			Message:  mustBe("not a directory"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe("directory"),
+		})
+
+	checkError(t, 12, td.File("foo.txt", "bar"),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("fs.FS OR string"),
+		})
+
+	checkError(t, nil, td.File("foo.txt", "bar"),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("fs.FS OR string"),
+		})
+
+	checkError(t, "never tested", td.File("../foo.txt", "bar"),
+		expectedError{
+			Message: mustBe("bad usage of File operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`File(PATH, EXPECTED_VALUE): "../foo.txt" is not a valid path, see fs.ValidPath`),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.File("foo.txt", "bar").String(), `File("foo.txt", "bar")`)
+	test.EqualStr(t, td.File("foo.txt", td.Len(3)).String(), `File("foo.txt", len=3)`)
+	test.EqualStr(t, td.File("/foo.txt", "bar").String(), "File(<ERROR>)")
+}
+
+func TestFileTypeBehind(t *testing.T) {
+	equalTypes(t, td.File("foo.txt", "bar"), nil)
+}
+
+func TestFS(t *testing.T) {
+	tree := newFSTestTree()
+
+	checkOK(t, tree, td.FS(map[string]any{
+		"README.md":   td.HasPrefix("# "),
+		"go.mod":      "module example.com/foo\n",
+		"cmd/main.go": td.FileEntry{Content: td.Contains("func main()"), Mode: 0o755},
+	}))
+	checkOK(t, tree, td.FS(map[string]any{
+		"README.md":   td.Ignore(),
+		"go.mod":      td.Ignore(),
+		"cmd/main.go": td.Ignore(),
+		"empty":       td.FileEntry{Mode: fs.ModeDir | 0o700},
+	}))
+	checkOK(t, fstest.MapFS{}, td.FS(map[string]any{}))
+	checkOK(t, fstest.MapFS{}, td.FS(nil))
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a", "b", "c.txt"), []byte("c"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "d.txt"), []byte("d"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checkOK(t, dir, td.FS(map[string]any{
+		"a/b/c.txt": "c",
+		"d.txt":     []byte("d"),
+	}))
+
+	checkError(t, tree, td.FS(map[string]any{
+		"README.md": td.Ignore(),
+		"go.mod":    td.Contains("bar"),
+		"unknown":   "foo",
+	}),
+		expectedError{
+			Message:  mustBe("does not contain"),
+			Path:     mustBe(`DATA["go.mod"]<content>`),
+			Expected: mustBe(`Contains("bar")`),
+		})
+
+	checkError(t, tree, td.FS(map[string]any{
+		"README.md": td.Ignore(),
+		"unknown":   "foo",
+	}),
+		expectedError{
+			Message: mustBe("comparing files of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(` Missing file: ("unknown")
+Extra 2 files: ("cmd/main.go",
+                "go.mod")`),
+		})
+
+	checkError(t, tree, td.FS(map[string]any{
+		"README.md":   td.Ignore(),
+		"go.mod":      td.Ignore(),
+		"cmd/main.go": td.Ignore(),
+		"a":           td.Ignore(),
+		"b":           td.Ignore(),
+	}),
+		expectedError{
+			Message: mustBe("comparing files of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 2 files: ("a",
+                  "b")`),
+		})
+
+	checkError(t, tree, td.FS(nil),
+		expectedError{
+			Message: mustBe("comparing files of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Extra 3 files: ("README.md",
+                "cmd/main.go",
+                "go.mod")`),
+		})
+
+	checkError(t, 12, td.FS(nil),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("fs.FS OR string"),
+		})
+
+	checkError(t, "never tested", td.FS(map[string]any{"a/../b": 1}),
+		expectedError{
+			Message: mustBe("bad usage of FS operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`FS(EXPECTED_FILES): "a/../b" is not a valid path, see fs.ValidPath`),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.FS(map[string]any{"/a": 1}).String(), "FS(<ERROR>)")
+	test.EqualStr(t, td.FS(map[string]any{"a": 1}).String(), `FS((map[string]interface {}) (len=1) {
+ (string) (len=1) "a": (int) 1
+})`)
+}
+
+func TestFSTypeBehind(t *testing.T) {
+	equalTypes(t, td.FS(nil), nil)
+}
@@ -8,9 +8,13 @@ package td
 
 import (
 	"reflect"
+	"time"
 
+	"github.com/maxatome/go-testdeep/helpers/tdutil"
 	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
 	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
 )
 
 const grepUsage = "(FILTER_FUNC|FILTER_TESTDEEP_OPERATOR, TESTDEEP_OPERATOR|EXPECTED_VALUE)"
@@ -59,7 +63,23 @@ func (g *tdGrepBase) initGrepBase(filter, expectedValue any) {
 	g.filter = vfilter
 }
 
-func (g *tdGrepBase) matchItem(ctx ctxerr.Context, idx int, item reflect.Value) (bool, *ctxerr.Error) {
+// collItem is an item of an array, a slice or a map.
+type collItem struct {
+	index int           // index in array/slice
+	key   reflect.Value // key in map, invalid for arrays & slices
+	value reflect.Value
+}
+
+// context returns ctx with the path level of item added.
+func (i collItem) context(ctx ctxerr.Context) ctxerr.Context {
+	if i.key.IsValid() {
+		return ctx.AddMapKey(i.key)
+	}
+	return ctx.AddArrayIndex(i.index)
+}
+
+func (g *tdGrepBase) matchItem(ctx ctxerr.Context, ci collItem) (bool, *ctxerr.Error) {
+	item := ci.value
 	if g.argType == nil {
 		// g.filter is a TestDeep operator
 		return deepValueEqualFinalOK(ctx, item, g.filter), nil
@@ -76,7 +96,7 @@ func (g *tdGrepBase) matchItem(ctx ctxerr.Context, idx int, item reflect.Value)
 			if ctx.BooleanError {
 				return false, ctxerr.BooleanError
 			}
-			return false, ctx.AddArrayIndex(idx).CollectError(&ctxerr.Error{
+			return false, ci.context(ctx).CollectError(&ctxerr.Error{
 				Message:  "incompatible parameter type",
 				Got:      types.RawString(item.Type().String()),
 				Expected: types.RawString(g.argType.String()),
@@ -190,7 +210,7 @@ var _ TestDeep = &tdGrep{}
 //	td.Cmp(t, got, td.Grep(td.Gt(0), td.Nil()))     // succeeds
 //	td.Cmp(t, got, td.Grep(td.Gt(0), []int{}))      // fails
 //
-// See also [First], [Last] and [Flatten].
+// See also [Count], [First], [Last] and [Flatten].
 func Grep(filter, expectedValue any) TestDeep {
 	g := tdGrep{}
 	g.initGrepBase(filter, expectedValue)
@@ -229,7 +249,7 @@ func (g *tdGrep) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 
 		for idx := 0; idx < l; idx++ {
 			item := got.Index(idx)
-			ok, rErr := g.matchItem(ctx, idx, item)
+			ok, rErr := g.matchItem(ctx, collItem{index: idx, value: item})
 			if rErr != nil {
 				return rErr
 			}
@@ -297,7 +317,7 @@ func (g *tdFirst) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 	case reflect.Slice, reflect.Array:
 		for idx, l := 0, got.Len(); idx < l; idx++ {
 			item := got.Index(idx)
-			ok, rErr := g.matchItem(ctx, idx, item)
+			ok, rErr := g.matchItem(ctx, collItem{index: idx, value: item})
 			if rErr != nil {
 				return rErr
 			}
@@ -372,7 +392,7 @@ func (g *tdLast) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 	case reflect.Slice, reflect.Array:
 		for idx := got.Len() - 1; idx >= 0; idx-- {
 			item := got.Index(idx)
-			ok, rErr := g.matchItem(ctx, idx, item)
+			ok, rErr := g.matchItem(ctx, collItem{index: idx, value: item})
 			if rErr != nil {
 				return rErr
 			}
@@ -393,3 +413,548 @@ func (g *tdLast) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 func (g *tdLast) TypeBehind() reflect.Type {
 	return g.sliceTypeBehind()
 }
+
+// collItems returns the items of got, an array, a slice, a map or a
+// pointer on map. Map items are sorted by key. It returns false if
+// got has another kind.
+func collItems(got reflect.Value) ([]collItem, bool) {
+	switch got.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]collItem, got.Len())
+		for idx := range items {
+			items[idx] = collItem{index: idx, value: got.Index(idx)}
+		}
+		return items, true
+
+	case reflect.Map:
+		keys := tdutil.MapSortedKeys(got)
+		items := make([]collItem, len(keys))
+		for idx, key := range keys {
+			items[idx] = collItem{key: key, value: got.MapIndex(key)}
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// aggregateResolvePtr is the same as grepResolvePtr, but also
+// accepts pointers on maps.
+func aggregateResolvePtr(ctx ctxerr.Context, got *reflect.Value) *ctxerr.Error {
+	if got.Kind() == reflect.Ptr {
+		gotElem := got.Elem()
+		if !gotElem.IsValid() {
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return ctx.CollectError(ctxerr.NilPointer(*got, "non-nil *slice OR *array OR *map"))
+		}
+		switch gotElem.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			*got = gotElem
+		}
+	}
+	return nil
+}
+
+func aggregateBadKind(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(ctxerr.BadKind(got,
+		"slice OR array OR map OR *slice OR *array OR *map"))
+}
+
+type aggregateKind uint8
+
+const (
+	aggregateSum aggregateKind = iota
+	aggregateMin
+	aggregateMax
+	aggregateAvg
+	aggregateCount
+)
+
+// aggregateInfos contains, for each aggregateKind, the path level
+// used to compare the computed value, then the error message and the
+// summary label used when this comparison fails.
+var aggregateInfos = [...]struct{ level, message, label string }{
+	aggregateSum:   {"<sum>", "bad sum", "sum of"},
+	aggregateMin:   {"<min>", "bad minimum", "minimum of"},
+	aggregateMax:   {"<max>", "bad maximum", "maximum of"},
+	aggregateAvg:   {"<avg>", "bad average", "average of"},
+	aggregateCount: {"<count>", "bad count", "counted items"},
+}
+
+// aggregateCheck compares result, computed from values by an
+// aggregate operator of kind kind, to expectedValue.
+func aggregateCheck(ctx ctxerr.Context, kind aggregateKind, result, values, expectedValue reflect.Value) *ctxerr.Error {
+	if ctx.BooleanError {
+		return deepValueEqualFinal(ctx, result, expectedValue)
+	}
+
+	info := aggregateInfos[kind]
+
+	// Use deepValueEqualFinal here instead of deepValueEqual as we
+	// want to know whether an error occurred or not, we do not want
+	// to accumulate it silently
+	origErr := deepValueEqualFinal(
+		ctx.ResetErrors().AddCustomLevel(info.level), result, expectedValue)
+	if origErr == nil {
+		return nil
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message: info.message,
+		Summary: ctxerr.ErrorSummaryItems{
+			{
+				Label: info.label,
+				Value: util.ToString(values),
+			},
+		},
+		Origin: origErr,
+	})
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// aggregateOrder returns a function able to compare 2 values of type
+// typ, or nil if typ values cannot be ordered.
+func aggregateOrder(typ reflect.Type) func(a, b reflect.Value) int {
+	if cmp := types.NewOrder(typ); cmp != nil {
+		return cmp
+	}
+
+	var less func(a, b reflect.Value) bool
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+
+	case reflect.Float32, reflect.Float64:
+		less = func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+
+	case reflect.String:
+		less = func(a, b reflect.Value) bool { return a.String() < b.String() }
+
+	case reflect.Struct:
+		if !typ.ConvertibleTo(types.Time) {
+			return nil
+		}
+		less = func(a, b reflect.Value) bool {
+			ta := dark.MustGetInterface(a.Convert(types.Time)).(time.Time)
+			tb := dark.MustGetInterface(b.Convert(types.Time)).(time.Time)
+			return ta.Before(tb)
+		}
+
+	default:
+		return nil
+	}
+
+	return func(a, b reflect.Value) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+}
+
+const aggregateUsage = "(FIELDS_PATH, TESTDEEP_OPERATOR|EXPECTED_VALUE)"
+
+type tdAggregate struct {
+	tdSmugglerBase
+	kind     aggregateKind
+	path     string
+	fieldsFn func(any) (smuggleValue, error) // nil if path is ""
+}
+
+var _ TestDeep = &tdAggregate{}
+
+func newAggregate(kind aggregateKind, fieldsPath string, expectedValue any) *tdAggregate {
+	a := tdAggregate{
+		tdSmugglerBase: newSmugglerBase(expectedValue, 1),
+		kind:           kind,
+		path:           fieldsPath,
+	}
+
+	if !a.isTestDeeper {
+		a.expectedValue = reflect.ValueOf(expectedValue)
+	}
+
+	if fieldsPath != "" {
+		fn, err := buildFieldsPathFn(fieldsPath)
+		if err != nil {
+			a.err = ctxerr.OpBad(a.GetLocation().Func,
+				"usage: %s%s, %s", a.GetLocation().Func, aggregateUsage, err)
+			return &a
+		}
+		a.fieldsFn = fn
+	}
+	return &a
+}
+
+// summary(Sum): sums the items of a slice, an array or a map then
+// compares the result
+// input(Sum): array,slice,map,ptr(ptr on array/slice/map)
+
+// Sum is a smuggler operator. It takes an array, a slice, a map or a
+// pointer on array/slice/map, sums its items (its values for a map)
+// and compares the sum to expectedValue.
+//
+// If fieldsPath is not empty, each item is first projected through
+// it, fieldsPath being a fields-path as [Smuggle] operator accepts,
+// like "Price" or "Lines[0].Amount". All summed values must have the
+// same integer, unsigned integer or float type, the sum having this
+// type too.
+//
+//	td.Cmp(t, []int{1, 2, 3}, td.Sum("", 6)) // succeeds
+//
+//	type Line struct{ Price float64 }
+//	lines := []Line{{Price: 12.5}, {Price: 87.4}}
+//	td.Cmp(t, lines, td.Sum("Price", td.N(99.9, 0.01))) // succeeds
+//
+// The sum of an empty array, slice or map is 0, of the type behind
+// expectedValue if it is a number, int otherwise.
+//
+// In case of failure, the summed values are reported.
+//
+// See also [Avg], [Count], [Max], [Min] and [Smuggle].
+func Sum(fieldsPath string, expectedValue any) TestDeep {
+	return newAggregate(aggregateSum, fieldsPath, expectedValue)
+}
+
+// summary(Min): finds the minimum of the items of a slice, an array
+// or a map then compares it
+// input(Min): array,slice,map,ptr(ptr on array/slice/map)
+
+// Min is a smuggler operator. It takes an array, a slice, a map or a
+// pointer on array/slice/map, finds the minimum of its items (of its
+// values for a map) and compares it to expectedValue.
+//
+// If fieldsPath is not empty, each item is first projected through
+// it, fieldsPath being a fields-path as [Smuggle] operator accepts,
+// like "Score" or "Stats.Min". All compared values must have the same
+// type, being a number, a string, a [time.Time] or having a
+// Compare(T) int or a Less(T) bool method.
+//
+//	td.Cmp(t, []int{3, 1, 2}, td.Min("", 1)) // succeeds
+//
+//	type Player struct{ Score int }
+//	players := []Player{{Score: 12}, {Score: 42}}
+//	td.Cmp(t, players, td.Min("Score", td.Gte(10))) // succeeds
+//
+// An empty array, slice or map has no minimum, so an error is raised
+// before comparing to expectedValue.
+//
+// In case of failure, the compared values are reported.
+//
+// See also [Avg], [Count], [Max], [Sum] and [Smuggle].
+func Min(fieldsPath string, expectedValue any) TestDeep {
+	return newAggregate(aggregateMin, fieldsPath, expectedValue)
+}
+
+// summary(Max): finds the maximum of the items of a slice, an array
+// or a map then compares it
+// input(Max): array,slice,map,ptr(ptr on array/slice/map)
+
+// Max is a smuggler operator. It takes an array, a slice, a map or a
+// pointer on array/slice/map, finds the maximum of its items (of its
+// values for a map) and compares it to expectedValue.
+//
+// If fieldsPath is not empty, each item is first projected through
+// it, fieldsPath being a fields-path as [Smuggle] operator accepts,
+// like "Score" or "Stats.Max". All compared values must have the same
+// type, being a number, a string, a [time.Time] or having a
+// Compare(T) int or a Less(T) bool method.
+//
+//	td.Cmp(t, []int{3, 1, 2}, td.Max("", 3)) // succeeds
+//
+//	type Player struct{ Score int }
+//	players := []Player{{Score: 12}, {Score: 100}}
+//	td.Cmp(t, players, td.Max("Score", 100)) // succeeds
+//
+// An empty array, slice or map has no maximum, so an error is raised
+// before comparing to expectedValue.
+//
+// In case of failure, the compared values are reported.
+//
+// See also [Avg], [Count], [Min], [Sum] and [Smuggle].
+func Max(fieldsPath string, expectedValue any) TestDeep {
+	return newAggregate(aggregateMax, fieldsPath, expectedValue)
+}
+
+// summary(Avg): computes the average of the items of a slice, an
+// array or a map then compares it
+// input(Avg): array,slice,map,ptr(ptr on array/slice/map)
+
+// Avg is a smuggler operator. It takes an array, a slice, a map or a
+// pointer on array/slice/map, computes the average of its items (of
+// its values for a map) and compares it to expectedValue.
+//
+// If fieldsPath is not empty, each item is first projected through
+// it, fieldsPath being a fields-path as [Smuggle] operator accepts,
+// like "Price" or "Lines[0].Amount". All averaged values must have
+// the same integer, unsigned integer or float type. Whatever this
+// type is, the average is always a float64.
+//
+//	td.Cmp(t, []int{1, 2}, td.Avg("", 1.5)) // succeeds
+//
+//	type Player struct{ Score int }
+//	players := []Player{{Score: 12}, {Score: 15}}
+//	td.Cmp(t, players, td.Avg("Score", td.Between(13.0, 14.0))) // succeeds
+//
+// An empty array, slice or map has no average, so an error is raised
+// before comparing to expectedValue.
+//
+// In case of failure, the averaged values are reported.
+//
+// See also [Count], [Max], [Min], [Sum] and [Smuggle].
+func Avg(fieldsPath string, expectedValue any) TestDeep {
+	return newAggregate(aggregateAvg, fieldsPath, expectedValue)
+}
+
+// value returns the value of item, projected through a.fieldsFn if
+// needed. If it cannot be computed, the returned value is invalid and
+// the error can be returned as is.
+func (a *tdAggregate) value(ctx ctxerr.Context, item collItem) (reflect.Value, *ctxerr.Error) {
+	v := item.value
+	if a.fieldsFn != nil {
+		iface, _ := dark.GetInterface(v, true)
+		sv, err := a.fieldsFn(iface)
+		if err != nil {
+			if ctx.BooleanError {
+				return reflect.Value{}, ctxerr.BooleanError
+			}
+			return reflect.Value{}, item.context(ctx).CollectError(&ctxerr.Error{
+				Message: "cannot resolve fields-path",
+				Summary: ctxerr.NewSummaryReason(v, err.Error()),
+			})
+		}
+		v = sv.Value
+	}
+
+	// Resolve interfaces and bypass possible private fields
+	iface, _ := dark.GetInterface(v, true)
+	return reflect.ValueOf(iface), nil
+}
+
+// acceptedKinds returns the kinds a.kind operator accepts for the
+// items, as displayed in errors.
+func (a *tdAggregate) acceptedKinds() string {
+	if a.kind == aggregateMin || a.kind == aggregateMax {
+		return "number OR string OR time.Time OR ordered type"
+	}
+	return "number"
+}
+
+func (a *tdAggregate) accepts(typ reflect.Type) bool {
+	if a.kind == aggregateMin || a.kind == aggregateMax {
+		return aggregateOrder(typ) != nil
+	}
+	return isNumberKind(typ.Kind())
+}
+
+func (a *tdAggregate) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if a.err != nil {
+		return ctx.CollectError(a.err)
+	}
+
+	if rErr := aggregateResolvePtr(ctx, &got); rErr != nil {
+		return rErr
+	}
+
+	items, ok := collItems(got)
+	if !ok {
+		return aggregateBadKind(ctx, got)
+	}
+
+	if len(items) == 0 {
+		if a.kind == aggregateSum {
+			typ := a.internalTypeBehind()
+			if typ == nil || !isNumberKind(typ.Kind()) {
+				typ = types.Int
+			}
+			zero := reflect.New(typ).Elem()
+			return aggregateCheck(ctx, a.kind, zero,
+				reflect.MakeSlice(reflect.SliceOf(typ), 0, 0), a.expectedValue)
+		}
+
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message:  "no items",
+			Got:      got,
+			Expected: types.RawString(a.String()),
+		})
+	}
+
+	var values reflect.Value
+	for _, item := range items {
+		v, rErr := a.value(ctx, item)
+		if !v.IsValid() {
+			if rErr != nil {
+				return rErr
+			}
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return item.context(ctx).CollectError(ctxerr.BadKind(v, a.acceptedKinds()))
+		}
+
+		if !values.IsValid() {
+			if !a.accepts(v.Type()) {
+				if ctx.BooleanError {
+					return ctxerr.BooleanError
+				}
+				return item.context(ctx).CollectError(ctxerr.BadKind(v, a.acceptedKinds()))
+			}
+			values = reflect.MakeSlice(reflect.SliceOf(v.Type()), 0, len(items))
+		} else if typ := values.Type().Elem(); v.Type() != typ {
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return item.context(ctx).CollectError(ctxerr.TypeMismatch(v.Type(), typ))
+		}
+		values = reflect.Append(values, v)
+	}
+
+	return aggregateCheck(ctx, a.kind, a.compute(values), values, a.expectedValue)
+}
+
+// compute returns the result of a.kind operator applied to values, a
+// non-empty slice.
+func (a *tdAggregate) compute(values reflect.Value) reflect.Value {
+	typ := values.Type().Elem()
+	l := values.Len()
+
+	if a.kind == aggregateMin || a.kind == aggregateMax {
+		order := aggregateOrder(typ)
+		res := values.Index(0)
+		for idx := 1; idx < l; idx++ {
+			v := values.Index(idx)
+			if c := order(v, res); a.kind == aggregateMin && c < 0 || a.kind == aggregateMax && c > 0 {
+				res = v
+			}
+		}
+		return res
+	}
+
+	sum := reflect.New(typ).Elem()
+	for idx := 0; idx < l; idx++ {
+		v := values.Index(idx)
+		switch typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			sum.SetInt(sum.Int() + v.Int())
+		case reflect.Float32, reflect.Float64:
+			sum.SetFloat(sum.Float() + v.Float())
+		default: // uint*
+			sum.SetUint(sum.Uint() + v.Uint())
+		}
+	}
+	if a.kind == aggregateSum {
+		return sum
+	}
+
+	var fsum float64
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fsum = float64(sum.Int())
+	case reflect.Float32, reflect.Float64:
+		fsum = sum.Float()
+	default: // uint*
+		fsum = float64(sum.Uint())
+	}
+	return reflect.ValueOf(fsum / float64(l))
+}
+
+func (a *tdAggregate) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (a *tdAggregate) String() string {
+	if a.err != nil {
+		return a.stringError()
+	}
+	return S("%s(%q)", a.GetLocation().Func, a.path)
+}
+
+type tdCount struct {
+	tdGrepBase
+}
+
+var _ TestDeep = &tdCount{}
+
+// summary(Count): counts the matching items of a slice, an array or a
+// map then compares the result
+// input(Count): array,slice,map,ptr(ptr on array/slice/map)
+
+// Count is a smuggler operator. It takes an array, a slice, a map or
+// a pointer on array/slice/map. For each item (each value for a map)
+// it applies filter, a [TestDeep] operator or a function returning a
+// bool. It counts the items for which the filter matched and
+// compares this count, an int, to expectedValue. The filter matches
+// when it is a:
+//   - [TestDeep] operator and it matches for the item;
+//   - function receiving the item and it returns true.
+//
+// expectedValue can of course be a [TestDeep] operator.
+//
+//	got := []int{-3, -2, -1, 0, 1, 2, 3}
+//	td.Cmp(t, got, td.Count(td.Gt(0), 3))                             // succeeds
+//	td.Cmp(t, got, td.Count(func(x int) bool { return x%2 == 0 }, 3)) // succeeds
+//	td.Cmp(t, got, td.Count(td.Gt(10), 0))                            // succeeds
+//	td.Cmp(t, got, td.Count(td.Lt(0), td.Between(1, 2)))              // fails
+//
+// In case of failure, the counted items are reported.
+//
+// See also [Avg], [Grep], [Max], [Min] and [Sum].
+func Count(filter, expectedValue any) TestDeep {
+	g := tdCount{}
+	g.initGrepBase(filter, expectedValue)
+	return &g
+}
+
+func (g *tdCount) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if g.err != nil {
+		return ctx.CollectError(g.err)
+	}
+
+	if rErr := aggregateResolvePtr(ctx, &got); rErr != nil {
+		return rErr
+	}
+
+	items, ok := collItems(got)
+	if !ok {
+		return aggregateBadKind(ctx, got)
+	}
+
+	counted := reflect.MakeSlice(reflect.SliceOf(got.Type().Elem()), 0, len(items))
+	for _, item := range items {
+		ok, rErr := g.matchItem(ctx, item)
+		if rErr != nil {
+			return rErr
+		}
+		if ok {
+			counted = reflect.Append(counted, item.value)
+		}
+	}
+
+	return aggregateCheck(ctx, aggregateCount,
+		reflect.ValueOf(counted.Len()), counted, g.expectedValue)
+}
+
+func (g *tdCount) TypeBehind() reflect.Type {
+	return nil
+}
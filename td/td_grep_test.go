@@ -8,6 +8,7 @@ package td_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/maxatome/go-testdeep/internal/test"
 	"github.com/maxatome/go-testdeep/td"
@@ -789,3 +790,359 @@ func TestLastTypeBehind(t *testing.T) {
 	// Erroneous op
 	equalTypes(t, td.Last(42, 33), nil)
 }
+
+type aggregateLine struct {
+	Name  string
+	Price float64
+	Qty   int
+}
+
+func TestSum(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		got := [...]int{1, 2, 3}
+		sgot := got[:]
+		mgot := map[string]int{"a": 1, "b": 2, "c": 3}
+
+		testCases := []struct {
+			name string
+			got  any
+		}{
+			{"slice", sgot},
+			{"array", got},
+			{"*slice", &sgot},
+			{"*array", &got},
+			{"map", mgot},
+			{"*map", &mgot},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				checkOK(t, tc.got, td.Sum("", 6))
+				checkOK(t, tc.got, td.Sum("", td.Between(5, 7)))
+			})
+		}
+
+		checkOK(t, []any{1.5, 2.5}, td.Sum("", 4.0))
+		checkOK(t, []uint8{200, 50}, td.Sum("", uint8(250)))
+		checkOK(t, []time.Duration{time.Second, time.Minute},
+			td.Sum("", 61*time.Second))
+	})
+
+	t.Run("fields-path", func(t *testing.T) {
+		lines := []aggregateLine{
+			{Name: "foo", Price: 12.5, Qty: 1},
+			{Name: "bar", Price: 87.4, Qty: 3},
+		}
+		checkOK(t, lines, td.Sum("Price", td.N(99.9, 0.01)))
+		checkOK(t, lines, td.Sum("Qty", 4))
+		checkOK(t, []*aggregateLine{{Qty: 1}, {Qty: 2}}, td.Sum("Qty", 3))
+		checkOK(t, map[string][]int{"a": {1, 2}, "b": {3, 4}}, td.Sum("[1]", 6))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		checkOK(t, []int{}, td.Sum("", 0))
+		checkOK(t, ([]int)(nil), td.Sum("", 0))
+		checkOK(t, []aggregateLine{}, td.Sum("Price", 0.0))
+		checkOK(t, []aggregateLine{}, td.Sum("Price", td.N(0.0)))
+		checkOK(t, map[string]int{}, td.Sum("", td.Zero()))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		got := map[string]any{
+			"lines": []any{
+				map[string]any{"price": 1.5},
+				map[string]any{"price": 2.5},
+			},
+		}
+		checkOK(t, got, td.JSON(`{"lines": Sum("[price]", 4)}`))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, []int{1, 2, 3}, td.Sum("", 7),
+			expectedError{
+				Message: mustBe("bad sum"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`sum of: ([]int) (len=3 cap=3) {
+         (int) 1,
+         (int) 2,
+         (int) 3
+        }`),
+				Origin: &expectedError{
+					Message:  mustBe("values differ"),
+					Path:     mustBe("DATA<sum>"),
+					Got:      mustBe("6"),
+					Expected: mustBe("7"),
+				},
+			})
+
+		checkError(t, []aggregateLine{{Price: 1}}, td.Sum("Price", 2),
+			expectedError{
+				Message: mustBe("bad sum"),
+				Path:    mustBe("DATA"),
+				Origin: &expectedError{
+					Message:  mustBe("type mismatch"),
+					Path:     mustBe("DATA<sum>"),
+					Got:      mustBe("float64"),
+					Expected: mustBe("int"),
+				},
+			})
+
+		checkError(t, []any{1, 2.5}, td.Sum("", 3),
+			expectedError{
+				Message:  mustBe("type mismatch"),
+				Path:     mustBe("DATA[1]"),
+				Got:      mustBe("float64"),
+				Expected: mustBe("int"),
+			})
+
+		checkError(t, []string{"a"}, td.Sum("", 3),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA[0]"),
+				Got:      mustBe("string"),
+				Expected: mustBe("number"),
+			})
+
+		checkError(t, []any{nil}, td.Sum("", 3),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA[0]"),
+				Got:      mustBe("nil"),
+				Expected: mustBe("number"),
+			})
+
+		checkError(t, map[string]aggregateLine{"a": {}}, td.Sum("Foo", 3),
+			expectedError{
+				Message: mustBe("cannot resolve fields-path"),
+				Path:    mustBe(`DATA["a"]`),
+				Summary: mustContain(`field "Foo" not found`),
+			})
+
+		checkError(t, 12, td.Sum("", 12),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("int"),
+				Expected: mustBe("slice OR array OR map OR *slice OR *array OR *map"),
+			})
+
+		checkError(t, nil, td.Sum("", 12),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("nil"),
+				Expected: mustBe("slice OR array OR map OR *slice OR *array OR *map"),
+			})
+
+		checkError(t, (*[]int)(nil), td.Sum("", 12),
+			expectedError{
+				Message:  mustBe("nil pointer"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("nil *slice (*[]int type)"),
+				Expected: mustBe("non-nil *slice OR *array OR *map"),
+			})
+
+		for _, op := range []td.TestDeep{
+			td.Sum("", 12),
+			td.Min("", 12),
+			td.Max("", 12),
+			td.Avg("", 12),
+			td.Count(td.Gt(0), 12),
+		} {
+			checkError(t, (*map[string]int)(nil), op,
+				expectedError{
+					Message:  mustBe("nil pointer"),
+					Path:     mustBe("DATA"),
+					Got:      mustBe("nil *map (*map[string]int type)"),
+					Expected: mustBe("non-nil *slice OR *array OR *map"),
+				})
+		}
+
+		checkError(t, "never tested", td.Sum("Foo..Bar", 12),
+			expectedError{
+				Message: mustBe("bad usage of Sum operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`usage: Sum(FIELDS_PATH, TESTDEEP_OPERATOR|EXPECTED_VALUE), unexpected '.' after '.' in FIELD_PATH "Foo..Bar"`),
+			})
+	})
+}
+
+func TestMinMax(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		checkOK(t, []int{3, 1, 2}, td.Min("", 1))
+		checkOK(t, []int{3, 1, 2}, td.Max("", 3))
+		checkOK(t, [...]uint{3, 1, 2}, td.Max("", uint(3)))
+		checkOK(t, map[string]float64{"a": 1.5, "b": -2}, td.Min("", -2.0))
+		checkOK(t, []string{"b", "c", "a"}, td.Min("", "a"))
+		checkOK(t, []string{"b", "c", "a"}, td.Max("", "c"))
+
+		now := time.Now()
+		times := []time.Time{now, now.Add(time.Hour), now.Add(-time.Hour)}
+		checkOK(t, times, td.Min("", now.Add(-time.Hour)))
+		checkOK(t, times, td.Max("", td.Between(now, now.Add(2*time.Hour))))
+
+		type MyTime time.Time
+		checkOK(t, []MyTime{MyTime(now), MyTime(now.Add(time.Hour))},
+			td.Max("", MyTime(now.Add(time.Hour))))
+	})
+
+	t.Run("fields-path", func(t *testing.T) {
+		lines := []aggregateLine{
+			{Name: "foo", Price: 12.5, Qty: 1},
+			{Name: "bar", Price: 87.4, Qty: 3},
+		}
+		checkOK(t, lines, td.Min("Price", 12.5))
+		checkOK(t, lines, td.Max("Qty", 3))
+		checkOK(t, lines, td.Min("Name", "bar"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, []int{3, 1, 2}, td.Max("", 4),
+			expectedError{
+				Message: mustBe("bad maximum"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("maximum of: ([]int) (len=3 cap=3) {"),
+				Origin: &expectedError{
+					Message:  mustBe("values differ"),
+					Path:     mustBe("DATA<max>"),
+					Got:      mustBe("3"),
+					Expected: mustBe("4"),
+				},
+			})
+
+		checkError(t, []int{3, 1, 2}, td.Min("", td.Gt(1)),
+			expectedError{
+				Message: mustBe("bad minimum"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("minimum of: ([]int) (len=3 cap=3) {"),
+				Origin: &expectedError{
+					Message:  mustBe("values differ"),
+					Path:     mustBe("DATA<min>"),
+					Got:      mustBe("1"),
+					Expected: mustBe("> 1"),
+				},
+			})
+
+		checkError(t, []int{}, td.Max("", 4),
+			expectedError{
+				Message:  mustBe("no items"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("([]int) {\n}"),
+				Expected: mustBe(`Max("")`),
+			})
+
+		checkError(t, []bool{true}, td.Min("", true),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA[0]"),
+				Got:      mustBe("bool"),
+				Expected: mustBe("number OR string OR time.Time OR ordered type"),
+			})
+	})
+}
+
+func TestAvg(t *testing.T) {
+	checkOK(t, []int{1, 2}, td.Avg("", 1.5))
+	checkOK(t, []uint{1, 2}, td.Avg("", 1.5))
+	checkOK(t, map[int]float32{1: 1, 2: 2, 3: 6}, td.Avg("", 3.0))
+	checkOK(t, []aggregateLine{{Qty: 12}, {Qty: 15}},
+		td.Avg("Qty", td.Between(13.0, 14.0)))
+
+	checkError(t, []int{1, 2}, td.Avg("", 1),
+		expectedError{
+			Message: mustBe("bad average"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("average of: ([]int) (len=2 cap=2) {"),
+			Origin: &expectedError{
+				Message:  mustBe("type mismatch"),
+				Path:     mustBe("DATA<avg>"),
+				Got:      mustBe("float64"),
+				Expected: mustBe("int"),
+			},
+		})
+
+	checkError(t, map[string]int{}, td.Avg("", 1),
+		expectedError{
+			Message:  mustBe("no items"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe(`Avg("")`),
+		})
+}
+
+func TestCount(t *testing.T) {
+	got := []int{-3, -2, -1, 0, 1, 2, 3}
+
+	checkOK(t, got, td.Count(td.Gt(0), 3))
+	checkOK(t, &got, td.Count(func(x int) bool { return x%2 == 0 }, 3))
+	checkOK(t, got, td.Count(td.Gt(10), 0))
+	checkOK(t, ([]int)(nil), td.Count(td.Gt(10), 0))
+	mgot := map[string]int{"a": 1, "b": 12, "c": 20}
+	checkOK(t, mgot, td.Count(td.Gt(10), 2))
+	checkOK(t, &mgot, td.Count(td.Gt(10), 2))
+	checkOK(t, []any{1, "foo", 2}, td.Count(td.Isa(0), td.Between(1, 2)))
+
+	checkError(t, got, td.Count(td.Lt(0), td.Between(1, 2)),
+		expectedError{
+			Message: mustBe("bad count"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`counted items: ([]int) (len=3 cap=7) {
+                (int) -3,
+                (int) -2,
+                (int) -1
+               }`),
+			Origin: &expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA<count>"),
+				Got:      mustBe("3"),
+				Expected: mustBe("1 ≤ got ≤ 2"),
+			},
+		})
+
+	checkError(t, map[string]any{"a": 1, "b": "x"},
+		td.Count(func(x int) bool { return true }, 2),
+		expectedError{
+			Message:  mustBe("incompatible parameter type"),
+			Path:     mustBe(`DATA["b"]`),
+			Got:      mustBe("string"),
+			Expected: mustBe("int"),
+		})
+
+	checkError(t, 12, td.Count(td.Gt(0), 1),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe("slice OR array OR map OR *slice OR *array OR *map"),
+		})
+
+	checkError(t, "never tested", td.Count(42, 1),
+		expectedError{
+			Message: mustBe("bad usage of Count operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: Count(FILTER_FUNC|FILTER_TESTDEEP_OPERATOR, TESTDEEP_OPERATOR|EXPECTED_VALUE), FILTER_FUNC must be a function or FILTER_TESTDEEP_OPERATOR a TestDeep operator"),
+		})
+}
+
+func TestAggregateString(t *testing.T) {
+	test.EqualStr(t, td.Sum("Price", 12).String(), `Sum("Price")`)
+	test.EqualStr(t, td.Min("", 12).String(), `Min("")`)
+	test.EqualStr(t, td.Max("[0]", 12).String(), `Max("[0]")`)
+	test.EqualStr(t, td.Avg("A.B", 12).String(), `Avg("A.B")`)
+	test.EqualStr(t, td.Count(td.Gt(0), 12).String(), "Count(> 0)")
+	test.EqualStr(t,
+		td.Count(func(n int) bool { return true }, 12).String(),
+		"Count(func(int) bool)")
+
+	// Erroneous op
+	test.EqualStr(t, td.Sum("Foo..Bar", 12).String(), "Sum(<ERROR>)")
+	test.EqualStr(t, td.Count(42, 12).String(), "Count(<ERROR>)")
+}
+
+func TestAggregateTypeBehind(t *testing.T) {
+	equalTypes(t, td.Sum("", 12), nil)
+	equalTypes(t, td.Min("", 12), nil)
+	equalTypes(t, td.Max("", 12), nil)
+	equalTypes(t, td.Avg("", 12), nil)
+	equalTypes(t, td.Count(td.Gt(0), 12), nil)
+
+	// Erroneous op
+	equalTypes(t, td.Sum("Foo..Bar", 12), nil)
+}
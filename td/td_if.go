@@ -0,0 +1,115 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type tdIf struct {
+	baseOKNil
+	items [3]reflect.Value // condition, then, else
+}
+
+var _ TestDeep = &tdIf{}
+
+// summary(If): compares data against an expected value or another
+// one, depending on a condition
+// input(If): all
+
+// If operator first checks whether got matches condition, as [EqDeeply]
+// would do. If it does, got is then compared against thenValue, else
+// against elseValue. Only the comparison against thenValue or
+// elseValue can fail, with full error reporting.
+//
+//	td.Cmp(t, 12, td.If(td.Gt(10), td.Lt(20), td.Gte(0))) // succeeds
+//	td.Cmp(t, -1, td.If(td.Gt(10), td.Lt(20), td.Gte(0))) // fails
+//
+// As condition is applied on got, checking a field depending on the
+// value of another field of the same struct is done on the struct
+// itself, using [Smuggle] to access fields:
+//
+//	td.Cmp(t, resp, td.If(
+//	  td.Smuggle("Status", "error"),
+//	  td.Smuggle("Error", td.NotEmpty()),
+//	  td.Smuggle("Error", td.Empty()),
+//	))
+//
+// In case of failure, the error tells which branch was taken and
+// why, then the failure of this branch is reported, its path
+// containing <If→then> or <If→else>.
+//
+// Note that a nil thenValue or elseValue means that got has to be
+// nil. Use [Ignore] if one branch has to always succeed.
+//
+// TypeBehind method can return a non-nil [reflect.Type] if thenValue
+// and elseValue types are equal, as [Any] does.
+//
+// See also [Any], [Switch] and [Smuggle].
+func If(condition, thenValue, elseValue any) TestDeep {
+	return &tdIf{
+		baseOKNil: newBaseOKNil(3),
+		items: [3]reflect.Value{
+			reflect.ValueOf(condition),
+			reflect.ValueOf(thenValue),
+			reflect.ValueOf(elseValue),
+		},
+	}
+}
+
+func (i *tdIf) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	var (
+		branch  reflect.Value
+		message string
+		level   string
+	)
+	if deepValueEqualFinalOK(ctx, got, i.items[0]) {
+		branch = i.items[1]
+		message = "condition matched, but then branch failed"
+		level = "<If→then>"
+	} else {
+		branch = i.items[2]
+		message = "condition did not match, and else branch failed"
+		level = "<If→else>"
+	}
+
+	if ctx.BooleanError {
+		return deepValueEqualFinal(ctx, got, branch)
+	}
+
+	// Use deepValueEqualFinal here instead of deepValueEqual as we
+	// want to know whether an error occurred or not, we do not want
+	// to accumulate it silently
+	origErr := deepValueEqualFinal(ctx.ResetErrors().AddCustomLevel(level), got, branch)
+	if origErr == nil {
+		return nil
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message: message,
+		Summary: ctxerr.ErrorSummaryItems{
+			{
+				Label: "condition",
+				Value: util.ToString(i.items[0]),
+			},
+		},
+		Origin: origErr,
+	})
+}
+
+func (i *tdIf) String() string {
+	var b strings.Builder
+	b.WriteString("If")
+	return util.SliceToString(&b, i.items[:]).String()
+}
+
+func (i *tdIf) TypeBehind() reflect.Type {
+	return uniqTypeBehindSlice(i.items[1:])
+}
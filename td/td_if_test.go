@@ -0,0 +1,100 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestIf(t *testing.T) {
+	checkOK(t, 12, td.If(td.Gt(10), td.Lt(20), td.Gte(0)))
+	checkOK(t, 5, td.If(td.Gt(10), td.Lt(20), td.Gte(0)))
+	checkOK(t, 5, td.If(5, 5, td.Ignore()))
+	checkOK(t, nil, td.If(nil, nil, 12))
+	checkOK(t, nil, td.If(td.NotNil(), 12, nil))
+
+	checkError(t, 25, td.If(td.Gt(10), td.Lt(20), td.Gte(0)),
+		expectedError{
+			Message: mustBe("condition matched, but then branch failed"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("condition: > 10"),
+			Origin: &expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA<If→then>"),
+				Got:      mustBe("25"),
+				Expected: mustBe("< 20"),
+			},
+		})
+
+	checkError(t, -1, td.If(td.Gt(10), td.Lt(20), td.Gte(0)),
+		expectedError{
+			Message: mustBe("condition did not match, and else branch failed"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("condition: > 10"),
+			Origin: &expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA<If→else>"),
+				Got:      mustBe("-1"),
+				Expected: mustBe("≥ 0"),
+			},
+		})
+
+	type Response struct {
+		Status string
+		Error  string
+	}
+	check := td.If(
+		td.Smuggle("Status", "error"),
+		td.Smuggle("Error", td.NotEmpty()),
+		td.Smuggle("Error", td.Empty()),
+	)
+	checkOK(t, Response{Status: "error", Error: "boom"}, check)
+	checkOK(t, Response{Status: "ok"}, check)
+	checkOK(t, []Response{{Status: "ok"}, {Status: "error", Error: "boom"}},
+		td.ArrayEach(check))
+
+	checkError(t, []Response{{Status: "ok"}, {Status: "ok", Error: "boom"}},
+		td.ArrayEach(check),
+		expectedError{
+			Message: mustBe("condition did not match, and else branch failed"),
+			Path:    mustBe("DATA[1]"),
+			Summary: mustBe(`condition: Smuggle("Status", "error")`),
+			Origin: &expectedError{
+				Message: mustBe("not empty"),
+				Path:    mustBe("DATA[1]<If→else>.Error"),
+			},
+		})
+
+	// Non-operator branch
+	checkError(t, 12, td.If(12, 13, 12),
+		expectedError{
+			Message: mustBe("condition matched, but then branch failed"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("condition: 12"),
+			Origin: &expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA<If→then>"),
+				Got:      mustBe("12"),
+				Expected: mustBe("13"),
+			},
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.If(td.Gt(10), 20, nil).String(), `If(> 10,
+   20,
+   nil)`)
+}
+
+func TestIfTypeBehind(t *testing.T) {
+	equalTypes(t, td.If(td.Gt(10), td.Lt(20), td.Gte(0)), 0)
+	equalTypes(t, td.If(td.Gt(10), 20, "foo"), nil)
+	equalTypes(t, td.If(td.Gt(10), 20, nil), nil)
+}
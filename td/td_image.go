@@ -0,0 +1,353 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	"image/png"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+	"github.com/maxatome/go-testdeep/internal/types"
+)
+
+// ImageOptions allows to tune the comparison done by [Image]
+// operator. Its zero value requires images to be strictly identical.
+type ImageOptions struct {
+	// Tolerance is the maximum difference allowed between each
+	// channel (red, green, blue and alpha) of two compared pixels,
+	// using 8 bits per channel. 0 means channels have to be equal.
+	Tolerance uint8
+	// MaxDiffRatio is the maximum ratio of differing pixels, between
+	// 0 and 1. 0 means no pixel can differ.
+	MaxDiffRatio float64
+	// DiffDir is the directory where the diff image is written in case
+	// of failure. If empty, the TempDir() of the current test is used
+	// if available, else a new temporary directory is created.
+	DiffDir string
+}
+
+type tdImage struct {
+	base
+	expected     image.Image
+	expectedDesc string
+	opts         ImageOptions
+}
+
+var _ TestDeep = &tdImage{}
+
+// summary(Image): decodes an image then compares its dimensions and
+// pixels
+// input(Image): str(file path),slice([]byte),if(image.Image/io.Reader)
+
+// Image operator decodes got as an image then compares it to
+// expectedImage, an [image.Image] or a string containing the path of
+// an image file. got can be:
+//   - an [image.Image];
+//   - a []byte containing the encoded image;
+//   - an [io.Reader], read until EOF;
+//   - a string containing the path of an image file.
+//
+// PNG, JPEG and GIF formats are supported, as well as any other
+// format registered using [image.RegisterFormat].
+//
+// Dimensions of both images have to be the same. Then pixels are
+// compared channel by channel: a pixel differs as soon as one of its
+// channels differs by more than opts Tolerance. The comparison fails
+// if the ratio of differing pixels is greater than opts
+// MaxDiffRatio. As the encoding is not compared, re-encoding an image
+// or changing its format does not make the comparison fail, as long
+// as pixels are the same, give or take the tolerance.
+//
+//	td.Cmp(t, thumbnail, td.Image("testdata/thumbnail.png")) // strict comparison
+//	td.Cmp(t, "out.jpg", td.Image("testdata/thumbnail.png", td.ImageOptions{
+//	  Tolerance:    8,    // JPEG compression artifacts
+//	  MaxDiffRatio: 0.01, // at most 1% of pixels can differ
+//	}))
+//
+// In case of failure, a diff image is written in the opts DiffDir
+// directory or, if empty, in the TempDir() of the current test, and
+// its path is reported. Differing pixels are painted in red, others
+// are the expected ones, grayed and faded.
+//
+// At most one opts can be passed.
+func Image(expectedImage any, opts ...ImageOptions) TestDeep {
+	i := tdImage{
+		base: newBase(3),
+	}
+
+	const usage = "(IMAGE|STRING_FILENAME[, IMAGE_OPTIONS])"
+
+	if len(opts) > 0 {
+		if len(opts) > 1 {
+			i.err = ctxerr.OpTooManyParams("Image", usage)
+			return &i
+		}
+		i.opts = opts[0]
+		if i.opts.MaxDiffRatio < 0 || i.opts.MaxDiffRatio > 1 {
+			i.err = ctxerr.OpBad("Image",
+				"Image%s: MaxDiffRatio must be between 0 and 1, not %g",
+				usage, i.opts.MaxDiffRatio)
+			return &i
+		}
+	}
+
+	switch expected := expectedImage.(type) {
+	case image.Image:
+		i.expected = expected
+		i.expectedDesc = fmt.Sprintf("%T %s", expected, imageSize(expected))
+
+	case string:
+		img, err := decodeImageFile(expected)
+		if err != nil {
+			i.err = ctxerr.OpBad("Image", "Image%s: %s", usage, err)
+			return &i
+		}
+		i.expected = img
+		i.expectedDesc = strconv.Quote(expected)
+
+	default:
+		i.err = ctxerr.OpBadUsage("Image", usage, expectedImage, 1, true)
+	}
+	return &i
+}
+
+func imageSize(img image.Image) string {
+	b := img.Bounds()
+	return strconv.Itoa(b.Dx()) + "x" + strconv.Itoa(b.Dy())
+}
+
+func decodeImageFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint: errcheck
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %w", path, err)
+	}
+	return img, nil
+}
+
+// decodeImage decodes got, an [image.Image], a []byte, an
+// [io.Reader] or a string containing the path of an image file. The
+// returned bool is false if got is none of these types.
+func decodeImage(got any) (image.Image, bool, error) {
+	var (
+		img image.Image
+		err error
+	)
+	switch g := got.(type) {
+	case image.Image:
+		return g, true, nil
+	case []byte:
+		img, _, err = image.Decode(bytes.NewReader(g))
+	case io.Reader:
+		img, _, err = image.Decode(g)
+	case string:
+		img, err = decodeImageFile(g)
+	default:
+		return nil, false, nil
+	}
+	return img, true, err
+}
+
+// gotImage returns the [image.Image] behind got. If it cannot be
+// determined, the returned image is nil and the error can be
+// returned as is.
+func gotImage(ctx ctxerr.Context, got reflect.Value) (image.Image, *ctxerr.Error) {
+	if got.IsValid() {
+		gotIf, _ := dark.GetInterface(got, true)
+		img, handled, err := decodeImage(gotIf)
+		if handled {
+			if err == nil {
+				return img, nil
+			}
+			if ctx.BooleanError {
+				return nil, ctxerr.BooleanError
+			}
+			return nil, ctx.CollectError(&ctxerr.Error{
+				Message: "cannot decode image",
+				Summary: ctxerr.NewSummary(err.Error()),
+			})
+		}
+	}
+
+	if ctx.BooleanError {
+		return nil, ctxerr.BooleanError
+	}
+	gotType := types.RawString("nil")
+	if got.IsValid() {
+		gotType = types.RawString(got.Type().String())
+	}
+	return nil, ctx.CollectError(&ctxerr.Error{
+		Message:  "bad type",
+		Got:      gotType,
+		Expected: types.RawString("image.Image OR []byte OR io.Reader OR string (file path)"),
+	})
+}
+
+// rgba8 returns the 8 bits per channel alpha-premultiplied components
+// of c.
+func rgba8(c color.Color) [4]uint8 {
+	r, g, b, a := c.RGBA()
+	return [4]uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
+
+func (i *tdImage) pixelsDiffer(got, expected [4]uint8) bool {
+	for n, g := range got {
+		e := expected[n]
+		if g > e {
+			g, e = e, g
+		}
+		if e-g > i.opts.Tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// writeDiff writes diff as a PNG file and returns its path.
+func (i *tdImage) writeDiff(ctx ctxerr.Context, diff image.Image) (string, error) {
+	dir := i.opts.DiffDir
+	if dir == "" {
+		if ctx.OriginalTB != nil {
+			dir = ctx.OriginalTB.TempDir()
+		} else {
+			var err error
+			dir, err = os.MkdirTemp("", "td-image-")
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	f, err := os.CreateTemp(dir, "diff-*.png")
+	if err != nil {
+		return "", err
+	}
+	err = png.Encode(f, diff)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func (i *tdImage) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if i.err != nil {
+		return ctx.CollectError(i.err)
+	}
+
+	gotImg, err := gotImage(ctx, got)
+	if gotImg == nil {
+		return err
+	}
+
+	gotBounds, expBounds := gotImg.Bounds(), i.expected.Bounds()
+	if gotBounds.Size() != expBounds.Size() {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message:  "image dimensions differ",
+			Got:      types.RawString(imageSize(gotImg)),
+			Expected: types.RawString(imageSize(i.expected)),
+		})
+	}
+
+	var (
+		diff      *image.NRGBA
+		numDiff   int
+		firstDiff image.Point
+		firstGot  [4]uint8
+		firstExp  [4]uint8
+	)
+	if !ctx.BooleanError {
+		diff = image.NewNRGBA(image.Rect(0, 0, expBounds.Dx(), expBounds.Dy()))
+	}
+	for y := 0; y < expBounds.Dy(); y++ {
+		for x := 0; x < expBounds.Dx(); x++ {
+			gotPix := rgba8(gotImg.At(gotBounds.Min.X+x, gotBounds.Min.Y+y))
+			expPix := rgba8(i.expected.At(expBounds.Min.X+x, expBounds.Min.Y+y))
+
+			if i.pixelsDiffer(gotPix, expPix) {
+				if numDiff == 0 {
+					firstDiff, firstGot, firstExp = image.Pt(x, y), gotPix, expPix
+				}
+				numDiff++
+				if diff != nil {
+					diff.SetNRGBA(x, y, color.NRGBA{R: 0xff, A: 0xff})
+				}
+			} else if diff != nil {
+				// Grayed and faded expected pixel
+				gray := color.GrayModel.Convert(i.expected.At(expBounds.Min.X+x, expBounds.Min.Y+y)).(color.Gray).Y
+				v := 0xff - (0xff-gray)/4
+				diff.SetNRGBA(x, y, color.NRGBA{R: v, G: v, B: v, A: 0xff})
+			}
+		}
+	}
+
+	total := expBounds.Dx() * expBounds.Dy()
+	if numDiff == 0 || float64(numDiff)/float64(total) <= i.opts.MaxDiffRatio {
+		return nil
+	}
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+
+	diffPath, wErr := i.writeDiff(ctx, diff)
+	if wErr != nil {
+		diffPath = "cannot be written: " + wErr.Error()
+	}
+
+	return ctx.CollectError(&ctxerr.Error{
+		Message: "images differ",
+		Summary: ctxerr.ErrorSummaryItems{
+			{
+				Label: "differing pixels",
+				Value: fmt.Sprintf("%d of %d (%.2f%%)",
+					numDiff, total, float64(numDiff)*100/float64(total)),
+				Explanation: fmt.Sprintf("first at (%d,%d): got RGBA%v, expected RGBA%v",
+					firstDiff.X, firstDiff.Y, firstGot, firstExp),
+			},
+			{
+				Label: "max allowed",
+				Value: fmt.Sprintf("%.2f%% with a tolerance of %d per channel",
+					i.opts.MaxDiffRatio*100, i.opts.Tolerance),
+			},
+			{
+				Label: "diff image",
+				Value: diffPath,
+			},
+		},
+	})
+}
+
+func (i *tdImage) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (i *tdImage) String() string {
+	if i.err != nil {
+		return i.stringError()
+	}
+	return "Image(" + i.expectedDesc + ")"
+}
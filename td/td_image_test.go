@@ -0,0 +1,235 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func newImageTest(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(x * 255 / width),
+				G: uint8(y * 255 / height),
+				B: 0x80,
+				A: 0xff,
+			})
+		}
+	}
+	return img
+}
+
+// imageTestTB is a [testing.TB] recording the failure message
+// instead of failing.
+type imageTestTB struct {
+	*testing.T
+	message string
+}
+
+func (t *imageTestTB) Error(args ...any) {
+	t.message = fmt.Sprint(args...)
+}
+
+func encodeImageTest(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestImage(t *testing.T) {
+	expected := newImageTest(10, 10)
+
+	dir := t.TempDir()
+	expectedFile := filepath.Join(dir, "expected.png")
+	if err := os.WriteFile(expectedFile, encodeImageTest(t, expected), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same image using several got types
+	checkOK(t, expected, td.Image(expected))
+	checkOK(t, encodeImageTest(t, expected), td.Image(expected))
+	checkOK(t, expectedFile, td.Image(expected))
+	checkOK(t, expected, td.Image(expectedFile))
+	// io.Reader, so read once
+	test.IsTrue(t, td.Cmp(t, bytes.NewReader(encodeImageTest(t, expected)), td.Image(expectedFile)))
+
+	// Same pixels, other bounds
+	moved := image.NewNRGBA(image.Rect(5, 5, 15, 15))
+	copy(moved.Pix, expected.Pix)
+	checkOK(t, moved, td.Image(expected))
+
+	// Other formats
+	var gifBuf bytes.Buffer
+	gray := image.NewGray(image.Rect(0, 0, 4, 4))
+	if err := gif.Encode(&gifBuf, gray, nil); err != nil {
+		t.Fatal(err)
+	}
+	checkOK(t, gifBuf.Bytes(), td.Image(gray))
+
+	var jpegBuf bytes.Buffer
+	if err := jpeg.Encode(&jpegBuf, expected, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	checkOK(t, jpegBuf.Bytes(), td.Image(expected, td.ImageOptions{
+		Tolerance:    32,
+		MaxDiffRatio: 0.1,
+	}))
+
+	// Tolerance & max diff ratio
+	got := image.NewNRGBA(expected.Bounds())
+	copy(got.Pix, expected.Pix)
+	got.SetNRGBA(1, 2, color.NRGBA{R: 0x10, G: 0x3c, B: 0x84, A: 0xff}) // slightly different
+	got.SetNRGBA(3, 4, color.NRGBA{A: 0xff})                            // totally different
+
+	checkOK(t, got, td.Image(expected, td.ImageOptions{
+		Tolerance:    10,
+		MaxDiffRatio: 0.01,
+	}))
+	checkOK(t, got, td.Image(expected, td.ImageOptions{MaxDiffRatio: 0.02}))
+
+	checkError(t, got, td.Image(expected, td.ImageOptions{DiffDir: dir}),
+		expectedError{
+			Message: mustBe("images differ"),
+			Path:    mustBe("DATA"),
+			Summary: mustMatch(`(?s)^differing pixels: 2 of 100 \(2\.00%\)
+first at \(1,2\): got RGBA\[16 60 132 255\], expected RGBA\[25 51 128 255\]
+     max allowed: 0\.00% with a tolerance of 0 per channel
+      diff image: ` + regexp.QuoteMeta(dir) + `/diff-\d+\.png\z`),
+		})
+
+	checkError(t, got, td.Image(expected, td.ImageOptions{Tolerance: 10, DiffDir: dir}),
+		expectedError{
+			Message: mustBe("images differ"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("differing pixels: 1 of 100 (1.00%)"),
+		})
+
+	// Check the diff image
+	diffs, err := filepath.Glob(filepath.Join(dir, "diff-*.png"))
+	if err != nil || len(diffs) == 0 {
+		t.Fatalf("no diff image found: %v", err)
+	}
+	diffFile, err := os.Open(diffs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer diffFile.Close()
+	diff, err := png.Decode(diffFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.EqualInt(t, diff.Bounds().Dx(), 10)
+	test.EqualInt(t, diff.Bounds().Dy(), 10)
+	td.Cmp(t, color.NRGBAModel.Convert(diff.At(3, 4)), color.NRGBA{R: 0xff, A: 0xff})
+	td.Cmp(t, color.NRGBAModel.Convert(diff.At(0, 0)),
+		td.Struct(color.NRGBA{A: 0xff}, td.StructFields{"R": td.Gt(uint8(0xbf))}))
+
+	// Diff image written in t.TempDir() by default
+	tb := &imageTestTB{T: t}
+	test.IsFalse(t, td.Cmp(tb, got, td.Image(expected)))
+	test.IsTrue(t, strings.Contains(tb.message, "diff image: "+filepath.Dir(t.TempDir())))
+
+	checkError(t, newImageTest(10, 11), td.Image(expected),
+		expectedError{
+			Message:  mustBe("image dimensions differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("10x11"),
+			Expected: mustBe("10x10"),
+		})
+
+	checkError(t, []byte("not an image"), td.Image(expected),
+		expectedError{
+			Message: mustBe("cannot decode image"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("image: unknown format"),
+		})
+
+	checkError(t, filepath.Join(dir, "unknown.png"), td.Image(expected),
+		expectedError{
+			Message: mustBe("cannot decode image"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("no such file or directory"),
+		})
+
+	checkError(t, 12, td.Image(expected),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("image.Image OR []byte OR io.Reader OR string (file path)"),
+		})
+
+	checkError(t, nil, td.Image(expected),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("image.Image OR []byte OR io.Reader OR string (file path)"),
+		})
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		td.Image(expected, td.ImageOptions{}, td.ImageOptions{}),
+		expectedError{
+			Message: mustBe("bad usage of Image operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: Image(IMAGE|STRING_FILENAME[, IMAGE_OPTIONS]), too many parameters"),
+		})
+
+	checkError(t, "never tested",
+		td.Image(expected, td.ImageOptions{MaxDiffRatio: 1.5}),
+		expectedError{
+			Message: mustBe("bad usage of Image operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Image(IMAGE|STRING_FILENAME[, IMAGE_OPTIONS]): MaxDiffRatio must be between 0 and 1, not 1.5"),
+		})
+
+	checkError(t, "never tested", td.Image(filepath.Join(dir, "unknown.png")),
+		expectedError{
+			Message: mustBe("bad usage of Image operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustMatch(`^Image\(IMAGE\|STRING_FILENAME\[, IMAGE_OPTIONS\]\): open .*unknown\.png: no such file or directory\z`),
+		})
+
+	checkError(t, "never tested", td.Image(42),
+		expectedError{
+			Message: mustBe("bad usage of Image operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: Image(IMAGE|STRING_FILENAME[, IMAGE_OPTIONS]), but received int as 1st parameter"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.Image(expected).String(), "Image(*image.NRGBA 10x10)")
+	test.EqualStr(t, td.Image(expectedFile).String(), `Image("`+expectedFile+`")`)
+	test.EqualStr(t, td.Image(42).String(), "Image(<ERROR>)")
+}
+
+func TestImageTypeBehind(t *testing.T) {
+	equalTypes(t, td.Image(image.NewGray(image.Rect(0, 0, 1, 1))), nil)
+}
@@ -29,30 +29,47 @@ import (
 // SubJSONOf or SuperJSONOf, optionally with an alternative to help
 // the user.
 var forbiddenOpsInJSON = map[string]string{
-	"Array":        "literal []",
-	"Cap":          "",
-	"Catch":        "",
-	"Code":         "",
-	"Delay":        "",
-	"ErrorIs":      "",
-	"Isa":          "",
-	"JSON":         "literal JSON",
-	"Lax":          "",
-	"Map":          "literal {}",
-	"PPtr":         "",
-	"Ptr":          "",
-	"Recv":         "",
-	"SStruct":      "",
-	"Shallow":      "",
-	"Slice":        "literal []",
-	"Smuggle":      "",
-	"String":       `literal ""`,
-	"SubJSONOf":    "SubMapOf operator",
-	"SuperJSONOf":  "SuperMapOf operator",
-	"SuperSliceOf": "All and JSONPointer operators",
-	"Struct":       "",
-	"Tag":          "",
-	"TruncTime":    "",
+	"Array":          "literal []",
+	"Cap":            "",
+	"Catch":          "",
+	"Code":           "",
+	"ContainsNorm":   "",
+	"Delay":          "",
+	"ErrorIs":        "",
+	"FS":             "",
+	"File":           "",
+	"HasPrefixNorm":  "",
+	"HasSuffixNorm":  "",
+	"Image":          "",
+	"Isa":            "",
+	"JSON":           "literal JSON",
+	"Lax":            "",
+	"Map":            "literal {}",
+	"PPtr":           "",
+	"Ptr":            "",
+	"ReaderContent":  "",
+	"Recent":         "",
+	"Recv":           "",
+	"RecvAll":        "",
+	"RecvN":          "",
+	"RecvWithin":     "",
+	"SStruct":        "",
+	"SameInstant":    "",
+	"Shallow":        "",
+	"Slice":          "literal []",
+	"Smuggle":        "",
+	"String":         `literal ""`,
+	"StringNorm":     "",
+	"SubJSONOf":      "SubMapOf operator",
+	"SuperJSONOf":    "SuperMapOf operator",
+	"SuperSliceOf":   "All and JSONPointer operators",
+	"Struct":         "",
+	"Switch":         "",
+	"Tag":            "",
+	"Tar":            "",
+	"TruncTime":      "",
+	"WithinDuration": "",
+	"Zip":            "",
 }
 
 // tdJSONUnmarshaler handles the JSON unmarshaling of JSON, SubJSONOf
@@ -220,6 +237,28 @@ func (u tdJSONUnmarshaler) resolveOp() func(json.Operator, json.Position) (any,
 			}
 		case "N", "Re":
 			min, max = 1, 2
+		case "Ref":
+			if len(jop.Params) > 0 {
+				switch p := jop.Params[0].(type) {
+				case string:
+					// got only contains maps and slices, so fields are map keys
+					jop.Params[0] = jsonRefPath(p)
+				case *tdJSONEmbedded:
+					// "$.path" or "$[key]" string already lexed as Ref
+					if ref := refOf(p); ref != nil {
+						jop.Params[0] = ref.path
+					}
+				}
+			}
+			min, max = 1, 1
+		case "UniqueBy":
+			// got only contains maps and slices, so fields are map keys
+			if len(jop.Params) > 0 {
+				if path, ok := jop.Params[0].(string); ok {
+					jop.Params[0] = jsonFieldsPath(path)
+				}
+			}
+			min, max = 1, 1
 		case "SubMapOf", "SuperMapOf":
 			min, max, addNilParam = 1, 1, true
 		default:
@@ -285,6 +324,19 @@ func (u tdJSONUnmarshaler) resolveOp() func(json.Operator, json.Position) (any,
 	}
 }
 
+// jsonFieldsPath returns path where all fields are replaced by map
+// keys, as in JSON, got only contains maps and slices.
+func jsonFieldsPath(path string) string {
+	parts, err := splitFieldsPath(path)
+	if err != nil {
+		return path // let the operator report the error
+	}
+	for i := range parts {
+		parts[i].Indexed = true
+	}
+	return joinFieldsPath(parts)
+}
+
 // tdJSONSmuggler is the base type for tdJSONPlaceholder & tdJSONEmbedded.
 type tdJSONSmuggler struct {
 	tdSmugglerBase // ignored by tools/gen_funcs.pl
@@ -528,6 +580,23 @@ func jsonify(ctx ctxerr.Context, got reflect.Value) (any, *ctxerr.Error) {
 // For the "details" key, the raw value "$info" is expected, no
 // placeholders are involved here.
 //
+// A string starting with "$." or "$[" is not a placeholder, but a
+// reference to another value of got, resolved at match time. It is a
+// shortcut for [Ref] operator, "$" being the root of the JSON
+// representation of got, and fields being JSON object keys:
+//
+//	td.Cmp(t, gotValue, td.JSON(`{"start": NotZero, "end": Gt("$.start"), "max": "$.end"}`))
+//
+// Here, "end" has to be greater than "start", and "max" equal to
+// "end". [Ref] can also be embedded directly, as other operators
+// (see below), to reference a value relative to the enclosing JSON
+// object or array. So the previous example is the same as:
+//
+//	td.Cmp(t, gotValue, td.JSON(`{"start": NotZero, "end": Gt(Ref("start")), "max": Ref("$[end]")}`))
+//
+// As for placeholders, the "$" has to be doubled to expect a raw
+// string starting with "$." or "$[".
+//
 // Note that [Lax] mode is automatically enabled by JSON operator to
 // simplify numeric tests.
 //
@@ -584,15 +653,19 @@ func jsonify(ctx ctxerr.Context, got reflect.Value) (any, *ctxerr.Error) {
 //   - the optional 3rd parameter of [Between] has to be specified as a string
 //     and can be: "[]" or "BoundsInIn" (default), "[[" or "BoundsInOut",
 //     "]]" or "BoundsOutIn", "][" or "BoundsOutOut";
+//   - the fields-path of [UniqueBy] designates JSON object keys, as in
+//     UniqueBy("id") or UniqueBy("user.id");
 //   - not all operators are embeddable only the following are: [All],
-//     [Any], [ArrayEach], [Bag], [Between], [Contains],
-//     [ContainsKey], [Empty], [First], [Grep], [Gt], [Gte],
-//     [HasPrefix], [HasSuffix], [Ignore], [JSONPointer], [Keys],
-//     [Last], [Len], [Lt], [Lte], [MapEach], [N], [NaN], [Nil],
-//     [None], [Not], [NotAny], [NotEmpty], [NotNaN], [NotNil],
-//     [NotZero], [Re], [ReAll], [Set], [SubBagOf], [SubMapOf],
-//     [SubSetOf], [SuperBagOf], [SuperMapOf], [SuperSetOf], [Values]
-//     and [Zero].
+//     [Any], [ArrayEach], [Avg], [Bag], [Between], [Contains],
+//     [ContainsKey], [ContainsSeq], [Count], [Empty], [First], [Grep],
+//     [Gt], [Gte], [HasPrefix], [HasSuffix], [If], [Ignore],
+//     [JSONPointer], [Keys], [Last], [Len], [Lt], [Lte], [MapEach],
+//     [Max], [Min], [N], [NaN], [Nil], [None], [Not], [NotAny],
+//     [NotEmpty], [NotNaN], [NotNil], [NotZero], [Re], [ReAll], [Ref],
+//     [Set], [SliceHasPrefix], [SliceHasSuffix], [StringFold],
+//     [SubBagOf], [SubMapOf], [SubSetOf], [Subsequence], [Sum],
+//     [SuperBagOf], [SuperMapOf], [SuperSetOf], [Unique], [UniqueBy],
+//     [Values] and [Zero].
 //
 // It is also possible to embed operators in JSON strings. This way,
 // the JSON specification can be fulfilled. To avoid collision with
@@ -704,6 +777,7 @@ func (j *tdJSON) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 	if err != nil {
 		return ctx.CollectError(err)
 	}
+	ctx.Root, ctx.Parent = got, reflect.Value{}
 
 	ctx.BeLax = true
 
@@ -854,6 +928,23 @@ var _ TestDeep = &tdMapJSON{}
 // For the "details" key, the raw value "$info" is expected, no
 // placeholders are involved here.
 //
+// A string starting with "$." or "$[" is not a placeholder, but a
+// reference to another value of got, resolved at match time. It is a
+// shortcut for [Ref] operator, "$" being the root of the JSON
+// representation of got, and fields being JSON object keys:
+//
+//	td.Cmp(t, gotValue, td.JSON(`{"start": NotZero, "end": Gt("$.start"), "max": "$.end"}`))
+//
+// Here, "end" has to be greater than "start", and "max" equal to
+// "end". [Ref] can also be embedded directly, as other operators
+// (see below), to reference a value relative to the enclosing JSON
+// object or array. So the previous example is the same as:
+//
+//	td.Cmp(t, gotValue, td.JSON(`{"start": NotZero, "end": Gt(Ref("start")), "max": Ref("$[end]")}`))
+//
+// As for placeholders, the "$" has to be doubled to expect a raw
+// string starting with "$." or "$[".
+//
 // Note that [Lax] mode is automatically enabled by SubJSONOf operator to
 // simplify numeric tests.
 //
@@ -911,15 +1002,19 @@ var _ TestDeep = &tdMapJSON{}
 //   - the optional 3rd parameter of [Between] has to be specified as a string
 //     and can be: "[]" or "BoundsInIn" (default), "[[" or "BoundsInOut",
 //     "]]" or "BoundsOutIn", "][" or "BoundsOutOut";
+//   - the fields-path of [UniqueBy] designates JSON object keys, as in
+//     UniqueBy("id") or UniqueBy("user.id");
 //   - not all operators are embeddable only the following are: [All],
-//     [Any], [ArrayEach], [Bag], [Between], [Contains],
-//     [ContainsKey], [Empty], [First], [Grep], [Gt], [Gte],
-//     [HasPrefix], [HasSuffix], [Ignore], [JSONPointer], [Keys],
-//     [Last], [Len], [Lt], [Lte], [MapEach], [N], [NaN], [Nil],
-//     [None], [Not], [NotAny], [NotEmpty], [NotNaN], [NotNil],
-//     [NotZero], [Re], [ReAll], [Set], [SubBagOf], [SubMapOf],
-//     [SubSetOf], [SuperBagOf], [SuperMapOf], [SuperSetOf], [Values]
-//     and [Zero].
+//     [Any], [ArrayEach], [Avg], [Bag], [Between], [Contains],
+//     [ContainsKey], [ContainsSeq], [Count], [Empty], [First], [Grep],
+//     [Gt], [Gte], [HasPrefix], [HasSuffix], [If], [Ignore],
+//     [JSONPointer], [Keys], [Last], [Len], [Lt], [Lte], [MapEach],
+//     [Max], [Min], [N], [NaN], [Nil], [None], [Not], [NotAny],
+//     [NotEmpty], [NotNaN], [NotNil], [NotZero], [Re], [ReAll], [Ref],
+//     [Set], [SliceHasPrefix], [SliceHasSuffix], [StringFold],
+//     [SubBagOf], [SubMapOf], [SubSetOf], [Subsequence], [Sum],
+//     [SuperBagOf], [SuperMapOf], [SuperSetOf], [Unique], [UniqueBy],
+//     [Values] and [Zero].
 //
 // It is also possible to embed operators in JSON strings. This way,
 // the JSON specification can be fulfilled. To avoid collision with
@@ -1135,6 +1230,23 @@ func SubJSONOf(expectedJSON any, params ...any) TestDeep {
 // For the "details" key, the raw value "$info" is expected, no
 // placeholders are involved here.
 //
+// A string starting with "$." or "$[" is not a placeholder, but a
+// reference to another value of got, resolved at match time. It is a
+// shortcut for [Ref] operator, "$" being the root of the JSON
+// representation of got, and fields being JSON object keys:
+//
+//	td.Cmp(t, gotValue, td.JSON(`{"start": NotZero, "end": Gt("$.start"), "max": "$.end"}`))
+//
+// Here, "end" has to be greater than "start", and "max" equal to
+// "end". [Ref] can also be embedded directly, as other operators
+// (see below), to reference a value relative to the enclosing JSON
+// object or array. So the previous example is the same as:
+//
+//	td.Cmp(t, gotValue, td.JSON(`{"start": NotZero, "end": Gt(Ref("start")), "max": Ref("$[end]")}`))
+//
+// As for placeholders, the "$" has to be doubled to expect a raw
+// string starting with "$." or "$[".
+//
 // Note that [Lax] mode is automatically enabled by SuperJSONOf operator to
 // simplify numeric tests.
 //
@@ -1191,15 +1303,19 @@ func SubJSONOf(expectedJSON any, params ...any) TestDeep {
 //   - the optional 3rd parameter of [Between] has to be specified as a string
 //     and can be: "[]" or "BoundsInIn" (default), "[[" or "BoundsInOut",
 //     "]]" or "BoundsOutIn", "][" or "BoundsOutOut";
+//   - the fields-path of [UniqueBy] designates JSON object keys, as in
+//     UniqueBy("id") or UniqueBy("user.id");
 //   - not all operators are embeddable only the following are: [All],
-//     [Any], [ArrayEach], [Bag], [Between], [Contains],
-//     [ContainsKey], [Empty], [First], [Grep], [Gt], [Gte],
-//     [HasPrefix], [HasSuffix], [Ignore], [JSONPointer], [Keys],
-//     [Last], [Len], [Lt], [Lte], [MapEach], [N], [NaN], [Nil],
-//     [None], [Not], [NotAny], [NotEmpty], [NotNaN], [NotNil],
-//     [NotZero], [Re], [ReAll], [Set], [SubBagOf], [SubMapOf],
-//     [SubSetOf], [SuperBagOf], [SuperMapOf], [SuperSetOf], [Values]
-//     and [Zero].
+//     [Any], [ArrayEach], [Avg], [Bag], [Between], [Contains],
+//     [ContainsKey], [ContainsSeq], [Count], [Empty], [First], [Grep],
+//     [Gt], [Gte], [HasPrefix], [HasSuffix], [If], [Ignore],
+//     [JSONPointer], [Keys], [Last], [Len], [Lt], [Lte], [MapEach],
+//     [Max], [Min], [N], [NaN], [Nil], [None], [Not], [NotAny],
+//     [NotEmpty], [NotNaN], [NotNil], [NotZero], [Re], [ReAll], [Ref],
+//     [Set], [SliceHasPrefix], [SliceHasSuffix], [StringFold],
+//     [SubBagOf], [SubMapOf], [SubSetOf], [Subsequence], [Sum],
+//     [SuperBagOf], [SuperMapOf], [SuperSetOf], [Unique], [UniqueBy],
+//     [Values] and [Zero].
 //
 // It is also possible to embed operators in JSON strings. This way,
 // the JSON specification can be fulfilled. To avoid collision with
@@ -1323,6 +1439,7 @@ func (m *tdMapJSON) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 	if err != nil {
 		return ctx.CollectError(err)
 	}
+	ctx.Root, ctx.Parent = got, reflect.Value{}
 
 	// nil case
 	if !got.IsValid() {
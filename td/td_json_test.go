@@ -810,6 +810,131 @@ func TestJSONInside(t *testing.T) {
 			})
 	})
 
+	// Subsequence, ContainsSeq, SliceHasPrefix & SliceHasSuffix
+	t.Run("Subsequence", func(t *testing.T) {
+		got := map[string][]int{"val": {1, 2, 3, 4}}
+
+		checkOK(t, got, td.JSON(`{"val": Subsequence(1, Gt(2), 4)}`))
+		checkOK(t, got, td.JSON(`{"val": ContainsSeq(2, 3)}`))
+		checkOK(t, got, td.JSON(`{"val": SliceHasPrefix(1, 2)}`))
+		checkOK(t, got, td.JSON(`{"val": SliceHasSuffix(3, 4)}`))
+
+		checkError(t, got, td.JSON(`{"val": ContainsSeq(2, 4)}`),
+			expectedError{
+				Message: mustBe("sequence not found"),
+				Path:    mustBe(`DATA["val"]`),
+				Under:   mustContain("under operator ContainsSeq at line 1:8 (pos 8)" + insideOpJSON),
+			})
+	})
+
+	// Unique & UniqueBy
+	t.Run("Unique", func(t *testing.T) {
+		got := map[string]any{
+			"ids":   []int{1, 2, 3},
+			"users": []map[string]any{{"id": 1, "name": "Bob"}, {"id": 2, "name": "Bob"}},
+		}
+
+		checkOK(t, got, td.SuperJSONOf(`{"ids": Unique}`))
+		checkOK(t, got, td.SuperJSONOf(`{"users": UniqueBy("id")}`))
+		checkOK(t, got, td.SuperJSONOf(`{"users": UniqueBy("[id]")}`))
+
+		// Plain keys, not Ref paths
+		orders := []map[string]any{
+			{"id": 1, "user": map[string]int{"id": 7}},
+			{"id": 2, "user": map[string]int{"id": 8}},
+		}
+		checkOK(t, orders, td.JSON(`UniqueBy("id")`))
+		checkOK(t, orders, td.JSON(`UniqueBy("user.id")`))
+		checkOK(t, orders, td.JSON(`UniqueBy("[user][id]")`))
+
+		checkError(t, got, td.SuperJSONOf(`{"users": UniqueBy("name")}`),
+			expectedError{
+				Message: mustBe("duplicate keys found"),
+				Path:    mustBe(`DATA["users"]`),
+				Summary: mustBe(`indexes 0, 1: "Bob"`),
+				Under:   mustContain("under operator UniqueBy at line 1:10 (pos 10)"),
+			})
+	})
+
+	t.Run("StringFold", func(t *testing.T) {
+		got := map[string]string{"name": "Bob", "city": "Paris"}
+
+		checkOK(t, got, td.JSON(`{"name": StringFold("BOB"), "city": StringFold("pARIS")}`))
+
+		checkError(t, got, td.SuperJSONOf(`{"name": StringFold("Alice")}`),
+			expectedError{
+				Message:  mustBe("does not match"),
+				Path:     mustBe(`DATA["name"]`),
+				Got:      mustBe(`"Bob"` + "\nnormalized to:\n" + `"BOB"`),
+				Expected: mustBe(`StringFold("Alice"), normalized to "ALICE"`),
+				Under:    mustContain("under operator StringFold at line 1:9 (pos 9)"),
+			})
+	})
+
+	t.Run("If", func(t *testing.T) {
+		got := map[string]any{"status": "error", "error": "not found"}
+
+		checkOK(t, got, td.JSON(`{"status": "error", "error": If(Re("^not "), NotEmpty(), Empty())}`))
+		checkOK(t, got,
+			td.SuperJSONOf(`{"error": If("not found", HasSuffix("found"), Empty)}`))
+		checkOK(t, map[string]int{"val": 12},
+			td.JSON(`{"val": If(Gt(10), Lt(20), Gte(0))}`))
+
+		checkError(t, map[string]int{"val": -1},
+			td.JSON(`{"val": If(Gt(10), Lt(20), Gte(0))}`),
+			expectedError{
+				Message: mustBe("condition did not match, and else branch failed"),
+				Path:    mustBe(`DATA["val"]`),
+				Summary: mustBe("condition: > 10.0"),
+				Under:   mustContain("under operator If at line 1:8 (pos 8)"),
+				Origin: &expectedError{
+					Message:  mustBe("values differ"),
+					Path:     mustBe(`DATA["val"]<If→else>`),
+					Got:      mustBe("-1.0"),
+					Expected: mustBe("≥ 0.0"),
+					Under:    mustContain("under operator Gte at line 1:27 (pos 27)"),
+				},
+			})
+	})
+
+	t.Run("Ref", func(t *testing.T) {
+		got := map[string]any{
+			"start": 1,
+			"end":   2,
+			"max":   2,
+			"items": []map[string]int{{"min": 3, "max": 4}},
+			"raw":   "$.start",
+		}
+
+		// Ref() call form
+		checkOK(t, got, td.SuperJSONOf(`{"end": Gt(Ref("start")), "max": Ref("$[end]")}`))
+		checkOK(t, got, td.SuperJSONOf(`{"items": [{"min": 3, "max": Gt(Ref("min"))}]}`))
+		checkOK(t, got, td.SuperJSONOf(`{"items": [{"min": Gt(Ref("$.end")), "max": 4}]}`))
+
+		// "$.path" string form
+		checkOK(t, got, td.SuperJSONOf(`{"end": Gt("$.start"), "max": "$.end"}`))
+		checkOK(t, got, td.SuperJSONOf(`{"max": "$[end]", "items": [{"min": 3, "max": Between("$.max", 5)}]}`))
+
+		// "$$" escape
+		checkOK(t, got, td.SuperJSONOf(`{"raw": "$$.start"}`))
+
+		checkError(t, got, td.SuperJSONOf(`{"start": "$.end"}`),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe(`DATA["start"]`),
+				Got:      mustBe("1.0"),
+				Expected: mustBe("2.0"),
+			})
+
+		checkError(t, got, td.SuperJSONOf(`{"start": Gt(Ref("end"))}`),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe(`DATA["start"]`),
+				Got:      mustBe("1.0"),
+				Expected: mustBe("> 2.0"),
+			})
+	})
+
 	// errors
 	t.Run("Errors", func(t *testing.T) {
 		checkError(t, "never tested",
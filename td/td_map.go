@@ -314,6 +314,7 @@ func (m *tdMap) match(ctx ctxerr.Context, got reflect.Value) (err *ctxerr.Error)
 	var notFoundKeys []reflect.Value
 	foundKeys := map[any]bool{}
 
+	ctx.Parent = got
 	for _, entryInfo := range m.expectedEntries {
 		gotValue := got.MapIndex(entryInfo.key)
 		if !gotValue.IsValid() {
@@ -70,6 +70,7 @@ func (m *tdMapEach) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 
 	case reflect.Map:
 		var err *ctxerr.Error
+		ctx.Parent = got
 		tdutil.MapEach(got, func(k, v reflect.Value) bool {
 			err = deepValueEqual(ctx.AddMapKey(k), v, m.expected)
 			return err == nil
@@ -0,0 +1,139 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+const contentLevel = "<content>"
+
+// contentValue returns content as a value of the type expected by
+// expectedValue:
+//   - a json.RawMessage for [JSON], [SubJSONOf] and [SuperJSONOf]
+//     operators, so they can parse it;
+//   - a string or a []byte (or a type convertible to them) if it is
+//     the type of expectedValue or the type behind this operator;
+//   - a []byte otherwise.
+func contentValue(content []byte, expectedValue reflect.Value) reflect.Value {
+	var typ reflect.Type
+	if expectedValue.IsValid() {
+		switch op := expectedValue.Interface().(type) {
+		case *tdJSON, *tdMapJSON:
+			return reflect.ValueOf(json.RawMessage(content))
+		case TestDeep:
+			typ = op.TypeBehind()
+		default:
+			typ = expectedValue.Type()
+		}
+	}
+
+	if typ != nil {
+		switch typ.Kind() {
+		case reflect.String:
+			return reflect.ValueOf(string(content)).Convert(typ)
+		case reflect.Slice:
+			if typ.Elem().Kind() == reflect.Uint8 {
+				return reflect.ValueOf(content).Convert(typ)
+			}
+		}
+	}
+	return reflect.ValueOf(content)
+}
+
+type tdReaderContent struct {
+	tdSmugglerBase
+}
+
+var _ TestDeep = &tdReaderContent{}
+
+// summary(ReaderContent): reads an io.Reader until EOF then compares
+// its content
+// input(ReaderContent): if(io.Reader),ptr(implementing io.Reader),struct(implementing io.Reader)
+
+// ReaderContent is a smuggler operator. It reads got, an
+// [io.Reader], until EOF and compares the read content to
+// expectedValue.
+//
+// The content is compared as a string if expectedValue is a string
+// or a [TestDeep] operator whose TypeBehind method returns string, as
+// a [json.RawMessage] if expectedValue is a [JSON], [SubJSONOf] or
+// [SuperJSONOf] operator, and as a []byte otherwise.
+//
+//	td.Cmp(t, strings.NewReader("foobar"), td.ReaderContent("foobar"))            // succeeds
+//	td.Cmp(t, strings.NewReader("foobar"), td.ReaderContent([]byte("foobar")))    // succeeds
+//	td.Cmp(t, strings.NewReader("foobar"), td.ReaderContent(td.HasPrefix("foo"))) // succeeds
+//	td.Cmp(t, body, td.ReaderContent(td.JSON(`{"id": NotZero()}`)))               // succeeds
+//
+// Note that got is read until EOF, so it cannot be read again after
+// the comparison.
+//
+// See also [File], [FS] and [Smuggle].
+func ReaderContent(expectedValue any) TestDeep {
+	r := tdReaderContent{
+		tdSmugglerBase: newSmugglerBase(expectedValue),
+	}
+	if !r.isTestDeeper {
+		r.expectedValue = reflect.ValueOf(expectedValue)
+	}
+	return &r
+}
+
+func (r *tdReaderContent) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	var reader io.Reader
+	if got.IsValid() {
+		gotIf, _ := dark.GetInterface(got, true)
+		reader, _ = gotIf.(io.Reader)
+	}
+	if reader == nil {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		gotType := types.RawString("nil")
+		if got.IsValid() {
+			gotType = types.RawString(got.Type().String())
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message:  "bad type",
+			Got:      gotType,
+			Expected: types.RawString("io.Reader"),
+		})
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		return ctx.CollectError(&ctxerr.Error{
+			Message: "an error occurred while reading from io.Reader",
+			Summary: ctxerr.NewSummary(err.Error()),
+		})
+	}
+
+	return deepValueEqual(ctx.AddCustomLevel(contentLevel),
+		contentValue(content, r.expectedValue), r.expectedValue)
+}
+
+func (r *tdReaderContent) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (r *tdReaderContent) String() string {
+	return "ReaderContent(" + util.ToString(r.expectedValue) + ")"
+}
+
+func (r *tdReaderContent) TypeBehind() reflect.Type {
+	return types.Reader
+}
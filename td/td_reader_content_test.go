@@ -0,0 +1,104 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+type readerContentErrReader struct{}
+
+func (readerContentErrReader) Read([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+func TestReaderContent(t *testing.T) {
+	// As reader is drained by the check, checkOK & checkError cannot
+	// be used here
+	type myBytes []byte
+	type myString string
+
+	for _, expected := range []any{
+		"foobar",
+		[]byte("foobar"),
+		myBytes("foobar"),
+		myString("foobar"),
+		td.HasPrefix("foo"),
+		td.Contains("oba"),
+		td.Re(`^fo+bar\z`),
+		td.String("foobar"),
+		td.Len(6),
+		td.Smuggle(strings.ToUpper, "FOOBAR"),
+	} {
+		test.IsTrue(t, td.Cmp(t, strings.NewReader("foobar"), td.ReaderContent(expected)),
+			"expected: ", expected)
+	}
+
+	test.IsTrue(t, td.Cmp(t, bytes.NewBufferString(`{"id":12,"name":"Bob"}`),
+		td.ReaderContent(td.JSON(`{"id": NotZero(), "name": "Bob"}`))))
+	test.IsTrue(t, td.Cmp(t, bytes.NewBufferString(`{"id":12,"name":"Bob"}`),
+		td.ReaderContent(td.SuperJSONOf(`{"name": "Bob"}`))))
+
+	type Resp struct{ Body io.Reader }
+	test.IsTrue(t, td.Cmp(t, Resp{Body: strings.NewReader("foo")},
+		td.Struct(Resp{}, td.StructFields{"Body": td.ReaderContent("foo")})))
+
+	tt := test.NewTestingT()
+	test.IsFalse(t, td.Cmp(tt, strings.NewReader("foobar"), td.ReaderContent("foo")))
+	test.IsTrue(t, strings.Contains(tt.LastMessage(), "DATA<content>: values differ"),
+		tt.LastMessage())
+
+	// Errors
+	checkError(t, 12, td.ReaderContent("foo"),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("io.Reader"),
+		})
+
+	checkError(t, nil, td.ReaderContent("foo"),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("io.Reader"),
+		})
+
+	checkError(t, readerContentErrReader{}, td.ReaderContent("foo"),
+		expectedError{
+			Message: mustBe("an error occurred while reading from io.Reader"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("boom"),
+		})
+
+	_checkError(t, strings.NewReader("foo"), td.ReaderContent(td.Len(4)),
+		expectedError{
+			Message:  mustBe("bad length"),
+			Path:     mustBe("DATA<content>"),
+			Got:      mustBe("3"),
+			Expected: mustBe("4"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.ReaderContent("foo").String(), `ReaderContent("foo")`)
+	test.EqualStr(t, td.ReaderContent(td.HasPrefix("foo")).String(),
+		`ReaderContent(HasPrefix("foo"))`)
+}
+
+func TestReaderContentTypeBehind(t *testing.T) {
+	equalTypes(t, td.ReaderContent("foo"), reflect.TypeOf((*io.Reader)(nil)).Elem())
+}
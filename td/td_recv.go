@@ -164,68 +164,100 @@ func (r *tdRecv) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
 		return ctx.CollectError(r.err)
 	}
 
+	ch, err := recvChan(ctx, got)
+	if !ch.IsValid() {
+		return err
+	}
+
+	var timerC reflect.Value
+	if r.timeout > 0 {
+		timer := time.NewTimer(r.timeout)
+		defer timer.Stop()
+		timerC = reflect.ValueOf(timer.C)
+	}
+
+	recv, state := recvOnce(ch, timerC)
+	switch state {
+	case recvNothing:
+		recv = reflect.ValueOf(RecvNothing)
+	case recvClosed:
+		recv = reflect.ValueOf(RecvClosed)
+	}
+	return deepValueEqual(ctx.AddFunctionCall("recv"), recv, r.expectedValue)
+}
+
+// recvChan returns the channel got is or points to. If got is not a
+// channel nor a pointer on a channel, the returned [reflect.Value] is
+// invalid and the error, if any, has to be returned by the caller.
+func recvChan(ctx ctxerr.Context, got reflect.Value) (reflect.Value, *ctxerr.Error) {
 	switch got.Kind() {
 	case reflect.Ptr:
 		gotElem := got.Elem()
 		if !gotElem.IsValid() {
 			if ctx.BooleanError {
-				return ctxerr.BooleanError
+				return reflect.Value{}, ctxerr.BooleanError
 			}
-			return ctx.CollectError(ctxerr.NilPointer(got, "non-nil *chan"))
+			return reflect.Value{}, ctx.CollectError(ctxerr.NilPointer(got, "non-nil *chan"))
 		}
-		if gotElem.Kind() != reflect.Chan {
-			break
+		if gotElem.Kind() == reflect.Chan {
+			return gotElem, nil
 		}
-		got = gotElem
-		fallthrough
 
 	case reflect.Chan:
-		cases := [2]reflect.SelectCase{
-			{
-				Dir:  reflect.SelectRecv,
-				Chan: got,
-			},
-		}
-		var timer *time.Timer
-		if r.timeout > 0 {
-			timer = time.NewTimer(r.timeout)
-			cases[1] = reflect.SelectCase{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(timer.C),
-			}
-		} else {
-			cases[1] = reflect.SelectCase{
-				Dir: reflect.SelectDefault,
-			}
-		}
+		return got, nil
+	}
 
-		chosen, recv, recvOK := reflect.Select(cases[:])
-		if chosen == 1 && timer != nil {
-			// check quickly both timeout & expected case didn't occur
-			// concurrently and timeout masked the expected case
-			cases[1] = reflect.SelectCase{
-				Dir: reflect.SelectDefault,
-			}
-			chosen, recv, recvOK = reflect.Select(cases[:])
+	if ctx.BooleanError {
+		return reflect.Value{}, ctxerr.BooleanError
+	}
+	return reflect.Value{}, ctx.CollectError(ctxerr.BadKind(got, "chan OR *chan"))
+}
+
+type recvState uint8
+
+const (
+	recvValue recvState = iota
+	recvNothing
+	recvClosed
+)
+
+// recvOnce tries to receive one value from channel ch. If timerC is
+// valid, it waits for a value until timerC fires. Otherwise it gives
+// up instantly if no value is available on ch.
+func recvOnce(ch, timerC reflect.Value) (reflect.Value, recvState) {
+	cases := [2]reflect.SelectCase{
+		{
+			Dir:  reflect.SelectRecv,
+			Chan: ch,
+		},
+	}
+	if timerC.IsValid() {
+		cases[1] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: timerC,
 		}
-		if chosen == 0 {
-			if !recvOK {
-				recv = reflect.ValueOf(RecvClosed)
-			}
-			if timer != nil {
-				timer.Stop()
-			}
-		} else {
-			recv = reflect.ValueOf(RecvNothing)
+	} else {
+		cases[1] = reflect.SelectCase{
+			Dir: reflect.SelectDefault,
 		}
-
-		return deepValueEqual(ctx.AddFunctionCall("recv"), recv, r.expectedValue)
 	}
 
-	if ctx.BooleanError {
-		return ctxerr.BooleanError
+	chosen, recv, recvOK := reflect.Select(cases[:])
+	if chosen == 1 && timerC.IsValid() {
+		// check quickly both timeout & expected case didn't occur
+		// concurrently and timeout masked the expected case
+		cases[1] = reflect.SelectCase{
+			Dir: reflect.SelectDefault,
+		}
+		chosen, recv, recvOK = reflect.Select(cases[:])
+	}
+	if chosen != 0 {
+		return reflect.Value{}, recvNothing
+	}
+	if !recvOK {
+		return reflect.Value{}, recvClosed
 	}
-	return ctx.CollectError(ctxerr.BadKind(got, "chan OR *chan"))
+	return recv, recvValue
 }
 
 func (r *tdRecv) HandleInvalid() bool {
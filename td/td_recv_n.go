@@ -0,0 +1,242 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type tdRecvN struct {
+	tdSmugglerBase
+	n       int // < 0 means until the channel is closed
+	timeout time.Duration
+}
+
+var _ TestDeep = &tdRecvN{}
+
+func newRecvN(n int, expectedValue any, timeout []time.Duration, usage string) *tdRecvN {
+	r := tdRecvN{n: n}
+	r.tdSmugglerBase = newSmugglerBase(expectedValue, 1)
+
+	if !r.isTestDeeper {
+		r.expectedValue = reflect.ValueOf(expectedValue)
+	}
+
+	switch len(timeout) {
+	case 0:
+	case 1:
+		r.timeout = timeout[0]
+	default:
+		r.err = ctxerr.OpTooManyParams(r.location.Func, usage)
+	}
+	return &r
+}
+
+// summary(RecvN): checks the n values read from a channel
+// input(RecvN): chan,ptr(ptr on chan)
+
+// RecvN is a smuggler operator. It reads n values from a channel or
+// a pointer to a channel and compares the slice of these values to
+// expectedValue.
+//
+// expectedValue can be any value including a [TestDeep] operator, so
+// [Bag], [Set], [SuperBagOf] or [Len] can be used to ignore the order of
+// the received values or to only check some of them.
+//
+// If timeout is passed it should be only one item. It means: try to
+// read the n values of the channel during this duration before
+// giving up. If timeout is missing or ≤ 0, it defaults to 0 meaning
+// RecvN does not wait for values but gives up instantly if less than
+// n values are available on the channel.
+//
+// If less than n values are received before the channel is closed or
+// the timeout elapses, RecvN fails without comparing the received
+// values to expectedValue.
+//
+//	ch := make(chan int, 6)
+//	ch <- 3
+//	ch <- 1
+//	ch <- 2
+//	td.Cmp(t, ch, td.RecvN(2, []int{3, 1}))  // succeeds
+//	td.Cmp(t, ch, td.RecvN(2, td.Bag(2, 1))) // fails, only 1 value left
+//	// recvN(DATA): only 1 value received out of 2
+//	//      got: ([]int) (len=1 cap=1) {
+//	//             (int) 2
+//	//           }
+//	// expected: 2 values
+//
+//	ch <- 4
+//	ch <- 5
+//	td.Cmp(t, ch, td.RecvN(2, td.Bag(5, 4))) // succeeds
+//	td.Cmp(t, ch, td.RecvN(0, td.Empty()))   // succeeds
+//
+// To wait up to 100ms for 3 values, received in any order:
+//
+//	td.Cmp(t, ch, td.RecvN(3, td.Bag(1, 2, 3), 100*time.Millisecond))
+//
+// note that in case of success, the above [Cmp] call lasts less than 100ms.
+//
+// TypeBehind method returns the [reflect.Type] of expectedValue,
+// except if expectedValue is a [TestDeep] operator. In this case, it
+// delegates TypeBehind() to the operator.
+//
+// See also [Recv], [RecvAll] and [RecvWithin].
+func RecvN(n int, expectedValue any, timeout ...time.Duration) TestDeep {
+	r := newRecvN(n, expectedValue, timeout, "(N, EXPECTED[, TIMEOUT])")
+	if r.err == nil && n < 0 {
+		r.err = ctxerr.OpBad(r.location.Func, "n cannot be negative (%d)", n)
+	}
+	return r
+}
+
+// summary(RecvAll): checks all the values read from a channel
+// until it is closed
+// input(RecvAll): chan,ptr(ptr on chan)
+
+// RecvAll is a smuggler operator. It reads all the values of a
+// channel or a pointer to a channel until it is closed and compares
+// the slice of these values to expectedValue.
+//
+// expectedValue can be any value including a [TestDeep] operator, so
+// [Bag], [Set], [SuperBagOf] or [Len] can be used to ignore the order of
+// the received values or to only check some of them.
+//
+// If timeout is passed it should be only one item. It means: try to
+// drain the channel until it is closed during this duration before
+// giving up. If timeout is missing or ≤ 0, it defaults to 0 meaning
+// RecvAll does not wait for values but gives up instantly if no
+// value is available on the channel and it is not closed.
+//
+// If the channel is not closed before the timeout elapses, RecvAll
+// fails without comparing the received values to expectedValue.
+//
+//	ch := make(chan int, 6)
+//	ch <- 3
+//	ch <- 1
+//	ch <- 2
+//	td.Cmp(t, ch, td.RecvAll(td.Bag(1, 2, 3))) // fails, ch is not closed
+//	// recvAll(DATA): channel not closed after receiving 3 values
+//	//      got: ([]int) (len=3 cap=3) {
+//	//             (int) 3,
+//	//             (int) 1,
+//	//             (int) 2
+//	//           }
+//	// expected: values until channel is closed
+//
+//	ch <- 4
+//	close(ch)
+//	td.Cmp(t, ch, td.RecvAll([]int{4}))   // succeeds
+//	td.Cmp(t, ch, td.RecvAll(td.Empty())) // succeeds, ch is still closed
+//
+// To drain a channel and check it is closed during the next 100ms:
+//
+//	td.Cmp(t, ch, td.RecvAll(td.Bag(1, 2, 3), 100*time.Millisecond))
+//
+// note that in case of success, the above [Cmp] call lasts less than 100ms.
+//
+// TypeBehind method returns the [reflect.Type] of expectedValue,
+// except if expectedValue is a [TestDeep] operator. In this case, it
+// delegates TypeBehind() to the operator.
+//
+// See also [Recv], [RecvN] and [RecvWithin].
+func RecvAll(expectedValue any, timeout ...time.Duration) TestDeep {
+	return newRecvN(-1, expectedValue, timeout, "(EXPECTED[, TIMEOUT])")
+}
+
+func (r *tdRecvN) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if r.err != nil {
+		return ctx.CollectError(r.err)
+	}
+
+	ch, err := recvChan(ctx, got)
+	if !ch.IsValid() {
+		return err
+	}
+
+	var timerC reflect.Value
+	if r.timeout > 0 {
+		timer := time.NewTimer(r.timeout)
+		defer timer.Stop()
+		timerC = reflect.ValueOf(timer.C)
+	}
+
+	values := reflect.MakeSlice(reflect.SliceOf(ch.Type().Elem()), 0, 0)
+	state := recvValue
+	for r.n < 0 || values.Len() < r.n {
+		var recv reflect.Value
+		recv, state = recvOnce(ch, timerC)
+		if state != recvValue {
+			break
+		}
+		values = reflect.Append(values, recv)
+	}
+	values = values.Slice3(0, values.Len(), values.Len()) // cap == len
+
+	var message string
+	if r.n < 0 {
+		if state != recvClosed {
+			message = fmt.Sprintf("channel not closed after receiving %s",
+				plural(values.Len(), "value"))
+		}
+	} else if values.Len() < r.n {
+		message = fmt.Sprintf("only %s received out of %d",
+			plural(values.Len(), "value"), r.n)
+		if state == recvClosed {
+			message += ", channel is closed"
+		}
+	}
+	if message != "" {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		expected := "values until channel is closed"
+		if r.n >= 0 {
+			expected = plural(r.n, "value")
+		}
+		return ctx.AddFunctionCall(r.funcName()).CollectError(&ctxerr.Error{
+			Message:  message,
+			Got:      values.Interface(),
+			Expected: types.RawString(expected),
+		})
+	}
+
+	return deepValueEqual(ctx.AddFunctionCall(r.funcName()), values, r.expectedValue)
+}
+
+func (r *tdRecvN) funcName() string {
+	if r.n < 0 {
+		return "recvAll"
+	}
+	return "recvN"
+}
+
+func (r *tdRecvN) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (r *tdRecvN) String() string {
+	if r.err != nil {
+		return r.stringError()
+	}
+	if r.n < 0 {
+		return "recvAll: " + util.ToString(r.expectedValue)
+	}
+	return fmt.Sprintf("recvN(%d): %s", r.n, util.ToString(r.expectedValue))
+}
+
+func (r *tdRecvN) TypeBehind() reflect.Type {
+	if r.err != nil {
+		return nil
+	}
+	return r.internalTypeBehind()
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestRecvN(t *testing.T) {
+	// checkOK & checkError call the operator 6 times
+	fillCh := func(ch chan int, vals ...int) {
+		for i := 0; i < 6; i++ {
+			for _, v := range vals {
+				ch <- v
+			}
+		}
+	}
+
+	t.Run("all good", func(t *testing.T) {
+		ch := make(chan int, 18)
+		fillCh(ch, 3, 1, 2)
+		checkOK(t, ch, td.RecvN(3, []int{3, 1, 2}))
+
+		fillCh(ch, 3, 1, 2)
+		checkOK(t, &ch, td.RecvN(3, td.Bag(1, 2, 3)))
+
+		fillCh(ch, 3, 1)
+		checkOK(t, ch, td.RecvN(2, td.Len(2)))
+		fillCh(ch, 2)
+		checkOK(t, ch, td.RecvN(1, []int{2}))
+
+		checkOK(t, ch, td.RecvN(0, []int{}))
+		checkOK(t, ch, td.RecvN(0, td.Empty(), 10*time.Microsecond))
+	})
+
+	t.Run("with timeout", func(t *testing.T) {
+		ch := make(chan int)
+		go func() {
+			fillCh(ch, 1, 2)
+		}()
+		checkOK(t, ch, td.RecvN(2, td.Bag(2, 1), time.Second))
+	})
+
+	t.Run("not enough values", func(t *testing.T) {
+		ch := make(chan int, 1)
+		checkError(t, ch, td.RecvN(2, []int{1, 2}),
+			expectedError{
+				Message:  mustBe("only 0 values received out of 2"),
+				Path:     mustBe("recvN(DATA)"),
+				Got:      mustBe("([]int) {\n}"),
+				Expected: mustBe("2 values"),
+			})
+
+		checkError(t, ch, td.RecvN(1, []int{1}, 10*time.Microsecond),
+			expectedError{
+				Message:  mustBe("only 0 values received out of 1"),
+				Path:     mustBe("recvN(DATA)"),
+				Got:      mustBe("([]int) {\n}"),
+				Expected: mustBe("1 value"),
+			})
+
+		close(ch)
+		checkError(t, ch, td.RecvN(2, []int{1, 2}),
+			expectedError{
+				Message:  mustBe("only 0 values received out of 2, channel is closed"),
+				Path:     mustBe("recvN(DATA)"),
+				Got:      mustBe("([]int) {\n}"),
+				Expected: mustBe("2 values"),
+			})
+
+		ch = make(chan int, 2)
+		ch <- 42
+		err := td.EqDeeplyError(ch, td.RecvN(2, []int{42, 43}))
+		td.Cmp(t, err.Error(), td.HasPrefix(`recvN(DATA): only 1 value received out of 2
+	     got: ([]int) (len=1 cap=1) {
+	           (int) 42
+	          }
+	expected: 2 values
+[under operator RecvN at td_recv_n_test.go:`))
+	})
+
+	t.Run("values differ", func(t *testing.T) {
+		ch := make(chan int, 12)
+		fillCh(ch, 1, 2)
+		checkError(t, ch, td.RecvN(2, []int{1, 3}),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("recvN(DATA)[1]"),
+				Got:      mustBe("2"),
+				Expected: mustBe("3"),
+			})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, "never tested",
+			td.RecvN(2, 23, time.Second, time.Second),
+			expectedError{
+				Message: mustBe("bad usage of RecvN operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: RecvN(N, EXPECTED[, TIMEOUT]), too many parameters"),
+			})
+
+		checkError(t, "never tested",
+			td.RecvN(-1, 23),
+			expectedError{
+				Message: mustBe("bad usage of RecvN operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("n cannot be negative (-1)"),
+			})
+
+		checkError(t, 42, td.RecvN(1, 33),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("int"),
+				Expected: mustBe("chan OR *chan"),
+			})
+
+		checkError(t, (*chan int)(nil), td.RecvN(1, 33),
+			expectedError{
+				Message:  mustBe("nil pointer"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("nil *chan (*chan int type)"),
+				Expected: mustBe("non-nil *chan"),
+			})
+	})
+}
+
+func TestRecvAll(t *testing.T) {
+	t.Run("all good", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 3
+		ch <- 1
+		ch <- 2
+		close(ch)
+		test.IsTrue(t, td.EqDeeply(ch, td.RecvAll([]int{3, 1, 2})))
+
+		// closed and empty
+		checkOK(t, ch, td.RecvAll([]int{}))
+		checkOK(t, &ch, td.RecvAll(td.Empty(), time.Millisecond))
+
+		ch = make(chan int)
+		go func() {
+			ch <- 2
+			ch <- 1
+			close(ch)
+		}()
+		test.IsTrue(t, td.EqDeeply(ch, td.RecvAll(td.Bag(1, 2), time.Second)))
+	})
+
+	t.Run("not closed", func(t *testing.T) {
+		ch := make(chan int, 1)
+		checkError(t, ch, td.RecvAll([]int{}),
+			expectedError{
+				Message:  mustBe("channel not closed after receiving 0 values"),
+				Path:     mustBe("recvAll(DATA)"),
+				Got:      mustBe("([]int) {\n}"),
+				Expected: mustBe("values until channel is closed"),
+			})
+		checkError(t, ch, td.RecvAll([]int{}, 10*time.Microsecond),
+			expectedError{
+				Message:  mustBe("channel not closed after receiving 0 values"),
+				Path:     mustBe("recvAll(DATA)"),
+				Got:      mustBe("([]int) {\n}"),
+				Expected: mustBe("values until channel is closed"),
+			})
+	})
+
+	t.Run("values differ", func(t *testing.T) {
+		ch := make(chan string, 2)
+		ch <- "foo"
+		close(ch)
+		err := td.EqDeeplyError(ch, td.RecvAll([]string{"bar"}))
+		td.Cmp(t, err.Error(), td.HasPrefix(`recvAll(DATA)[0]: values differ
+	     got: "foo"
+	expected: "bar"
+[under operator RecvAll at td_recv_n_test.go:`))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, "never tested",
+			td.RecvAll(23, time.Second, time.Second),
+			expectedError{
+				Message: mustBe("bad usage of RecvAll operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: RecvAll(EXPECTED[, TIMEOUT]), too many parameters"),
+			})
+
+		checkError(t, nil, td.RecvAll(33),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("nil"),
+				Expected: mustBe("chan OR *chan"),
+			})
+	})
+}
+
+func TestRecvNString(t *testing.T) {
+	test.EqualStr(t, td.RecvN(2, []int{1, 2}).String(),
+		"recvN(2): ([]int) (len=2 cap=2) {\n (int) 1,\n (int) 2\n}")
+	test.EqualStr(t, td.RecvN(3, td.Len(3)).String(), "recvN(3): len=3")
+	test.EqualStr(t, td.RecvAll(td.Len(3)).String(), "recvAll: len=3")
+
+	// Erroneous op
+	test.EqualStr(t, td.RecvN(-1, 3).String(), "RecvN(<ERROR>)")
+	test.EqualStr(t, td.RecvAll(3, 0, 0).String(), "RecvAll(<ERROR>)")
+}
+
+func TestRecvNTypeBehind(t *testing.T) {
+	equalTypes(t, td.RecvN(2, []int{}), []int{})
+	equalTypes(t, td.RecvAll([]int{}), []int{})
+	equalTypes(t, td.RecvAll(td.Len(3)), nil)
+
+	// Erroneous op
+	equalTypes(t, td.RecvN(-1, 3), nil)
+	equalTypes(t, td.RecvAll(3, 0, 0), nil)
+}
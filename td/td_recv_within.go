@@ -0,0 +1,170 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type tdRecvWithin struct {
+	tdSmugglerBase
+	min, max    time.Duration
+	hasExpected bool
+}
+
+var _ TestDeep = &tdRecvWithin{}
+
+// summary(RecvWithin): checks a value is read from a channel
+// within a time window
+// input(RecvWithin): chan,ptr(ptr on chan)
+
+// RecvWithin is a smuggler operator. It reads a value from a channel
+// or a pointer to a channel and checks it is received no sooner than
+// min and no later than max after the comparison starts.
+//
+// If expectedValue is passed it should be only one item. It can be
+// any value including a [TestDeep] operator, it is then compared to
+// the received value as [Recv] does, so [RecvClosed] can be used to
+// check the channel is closed during the time window. If
+// expectedValue is missing, any received value is accepted,
+// including a channel close.
+//
+//	ch := make(chan int, 1)
+//	go func() {
+//	  time.Sleep(20 * time.Millisecond)
+//	  ch <- 42
+//	}()
+//	td.Cmp(t, ch, td.RecvWithin(10*time.Millisecond, 50*time.Millisecond)) // succeeds
+//
+// To also check the received value:
+//
+//	td.Cmp(t, ch, td.RecvWithin(0, 50*time.Millisecond, td.Between(40, 45)))
+//
+// If nothing is received within max, or if a value is received
+// before min, RecvWithin fails:
+//
+//	ch <- 42
+//	td.Cmp(t, ch, td.RecvWithin(10*time.Millisecond, 50*time.Millisecond))
+//	// recvWithin(DATA): received too early
+//	//      got: received after 3.142µs
+//	// expected: 10ms ≤ reception delay ≤ 50ms
+//
+// TypeBehind method returns the [reflect.Type] of expectedValue,
+// except if expectedValue is a [TestDeep] operator. In this case, it
+// delegates TypeBehind() to the operator. If expectedValue is
+// missing, it returns nil.
+//
+// See also [Recv], [RecvN] and [RecvAll].
+func RecvWithin(min, max time.Duration, expectedValue ...any) TestDeep {
+	r := tdRecvWithin{min: min, max: max}
+
+	switch len(expectedValue) {
+	case 0:
+		r.tdSmugglerBase = newSmugglerBase(nil)
+	case 1:
+		r.tdSmugglerBase = newSmugglerBase(expectedValue[0])
+		r.hasExpected = true
+		if !r.isTestDeeper {
+			r.expectedValue = reflect.ValueOf(expectedValue[0])
+		}
+	default:
+		r.tdSmugglerBase = newSmugglerBase(nil)
+		r.err = ctxerr.OpTooManyParams(r.location.Func, "(MIN, MAX[, EXPECTED])")
+		return &r
+	}
+
+	if min < 0 || max < min {
+		r.err = ctxerr.OpBad(r.location.Func,
+			"0 ≤ min ≤ max condition not satisfied (min=%s, max=%s)", min, max)
+	}
+	return &r
+}
+
+func (r *tdRecvWithin) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if r.err != nil {
+		return ctx.CollectError(r.err)
+	}
+
+	ch, err := recvChan(ctx, got)
+	if !ch.IsValid() {
+		return err
+	}
+
+	var timerC reflect.Value
+	if r.max > 0 {
+		timer := time.NewTimer(r.max)
+		defer timer.Stop()
+		timerC = reflect.ValueOf(timer.C)
+	}
+
+	start := time.Now()
+	recv, state := recvOnce(ch, timerC)
+	delay := time.Since(start)
+
+	var message string
+	switch {
+	case state == recvNothing:
+		message = "nothing received in time"
+	case delay < r.min:
+		message = "received too early"
+	}
+	if message != "" {
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+		gotStr := "nothing received on channel"
+		if state != recvNothing {
+			gotStr = "received after " + delay.String()
+		}
+		return ctx.AddFunctionCall("recvWithin").CollectError(&ctxerr.Error{
+			Message:  message,
+			Got:      types.RawString(gotStr),
+			Expected: types.RawString(r.window()),
+		})
+	}
+
+	if !r.hasExpected {
+		return nil
+	}
+
+	if state == recvClosed {
+		recv = reflect.ValueOf(RecvClosed)
+	}
+	return deepValueEqual(ctx.AddFunctionCall("recvWithin"), recv, r.expectedValue)
+}
+
+func (r *tdRecvWithin) window() string {
+	return fmt.Sprintf("%s ≤ reception delay ≤ %s", r.min, r.max)
+}
+
+func (r *tdRecvWithin) HandleInvalid() bool {
+	return true // Knows how to handle untyped nil values (aka invalid values)
+}
+
+func (r *tdRecvWithin) String() string {
+	if r.err != nil {
+		return r.stringError()
+	}
+	s := "recvWithin(" + r.window() + ")"
+	if r.hasExpected {
+		s += ": " + util.ToString(r.expectedValue)
+	}
+	return s
+}
+
+func (r *tdRecvWithin) TypeBehind() reflect.Type {
+	if r.err != nil || !r.hasExpected {
+		return nil
+	}
+	return r.internalTypeBehind()
+}
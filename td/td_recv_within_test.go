@@ -0,0 +1,148 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestRecvWithin(t *testing.T) {
+	t.Run("all good", func(t *testing.T) {
+		ch := make(chan int, 6)
+		for i := 0; i < 6; i++ {
+			ch <- 42
+		}
+		checkOK(t, ch, td.RecvWithin(0, time.Second))
+
+		for i := 0; i < 6; i++ {
+			ch <- 42
+		}
+		checkOK(t, &ch, td.RecvWithin(0, 0, td.Between(40, 45)))
+
+		close(ch)
+		checkOK(t, ch, td.RecvWithin(0, time.Second)) // close is accepted
+		checkOK(t, ch, td.RecvWithin(0, time.Second, td.RecvClosed))
+	})
+
+	t.Run("delayed", func(t *testing.T) {
+		ch := make(chan int)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			ch <- 42
+		}()
+		test.IsTrue(t,
+			td.EqDeeply(ch, td.RecvWithin(10*time.Millisecond, 10*time.Second, 42)))
+	})
+
+	t.Run("too early", func(t *testing.T) {
+		ch := make(chan int, 6)
+		for i := 0; i < 6; i++ {
+			ch <- 42
+		}
+		checkError(t, ch, td.RecvWithin(time.Hour, 2*time.Hour),
+			expectedError{
+				Message:  mustBe("received too early"),
+				Path:     mustBe("recvWithin(DATA)"),
+				Got:      mustMatch(`^received after \d`),
+				Expected: mustBe("1h0m0s ≤ reception delay ≤ 2h0m0s"),
+			})
+	})
+
+	t.Run("too late", func(t *testing.T) {
+		ch := make(chan int)
+		checkError(t, ch, td.RecvWithin(0, 10*time.Microsecond),
+			expectedError{
+				Message:  mustBe("nothing received in time"),
+				Path:     mustBe("recvWithin(DATA)"),
+				Got:      mustBe("nothing received on channel"),
+				Expected: mustBe("0s ≤ reception delay ≤ 10µs"),
+			})
+	})
+
+	t.Run("values differ", func(t *testing.T) {
+		ch := make(chan int, 6)
+		for i := 0; i < 6; i++ {
+			ch <- 42
+		}
+		checkError(t, ch, td.RecvWithin(0, time.Second, 12),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("recvWithin(DATA)"),
+				Got:      mustBe("42"),
+				Expected: mustBe("12"),
+			})
+
+		ch = make(chan int)
+		close(ch)
+		checkError(t, ch, td.RecvWithin(0, time.Second, 12),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("recvWithin(DATA)"),
+				Got:      mustBe("channel is closed"),
+				Expected: mustBe("12"),
+			})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, "never tested",
+			td.RecvWithin(0, time.Second, 1, 2),
+			expectedError{
+				Message: mustBe("bad usage of RecvWithin operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: RecvWithin(MIN, MAX[, EXPECTED]), too many parameters"),
+			})
+
+		checkError(t, "never tested",
+			td.RecvWithin(time.Second, time.Millisecond),
+			expectedError{
+				Message: mustBe("bad usage of RecvWithin operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("0 ≤ min ≤ max condition not satisfied (min=1s, max=1ms)"),
+			})
+
+		checkError(t, "never tested",
+			td.RecvWithin(-time.Second, time.Millisecond),
+			expectedError{
+				Message: mustBe("bad usage of RecvWithin operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("0 ≤ min ≤ max condition not satisfied (min=-1s, max=1ms)"),
+			})
+
+		checkError(t, 42, td.RecvWithin(0, time.Second),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("int"),
+				Expected: mustBe("chan OR *chan"),
+			})
+	})
+}
+
+func TestRecvWithinString(t *testing.T) {
+	test.EqualStr(t, td.RecvWithin(0, time.Second).String(),
+		"recvWithin(0s ≤ reception delay ≤ 1s)")
+	test.EqualStr(t, td.RecvWithin(0, time.Second, 42).String(),
+		"recvWithin(0s ≤ reception delay ≤ 1s): 42")
+	test.EqualStr(t, td.RecvWithin(0, time.Second, td.Gt(8)).String(),
+		"recvWithin(0s ≤ reception delay ≤ 1s): > 8")
+
+	// Erroneous op
+	test.EqualStr(t, td.RecvWithin(time.Second, 0).String(), "RecvWithin(<ERROR>)")
+}
+
+func TestRecvWithinTypeBehind(t *testing.T) {
+	equalTypes(t, td.RecvWithin(0, time.Second), nil)
+	equalTypes(t, td.RecvWithin(0, time.Second, 3), 0)
+	equalTypes(t, td.RecvWithin(0, time.Second, td.Between(3, 4)), 0)
+
+	// Erroneous op
+	equalTypes(t, td.RecvWithin(time.Second, 0, 3), nil)
+}
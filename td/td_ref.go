@@ -0,0 +1,261 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/dark"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type tdRef struct {
+	baseOKNil
+	path     string
+	fromRoot bool
+	fieldsFn func(any) (smuggleValue, error) // nil if path is "$"
+}
+
+var _ TestDeep = &tdRef{}
+
+// summary(Ref): references another value of got, resolved at match
+// time
+// input(Ref): all
+
+// Ref operator references a value elsewhere in got, designated by
+// path, and resolved at match time. Used directly as an expected
+// value, got has to be equal to the referenced value:
+//
+//	type Period struct {
+//	  Start, End, Max time.Time
+//	}
+//	td.Cmp(t, period, td.Struct(Period{}, td.StructFields{
+//	  "Max": td.Ref("End"), // Max has to be equal to End
+//	}))
+//
+// But Ref is mostly useful as parameter of [Between], [Gt], [Gte],
+// [Lt], [Lte] and [N] operators, that resolve it just before
+// comparing:
+//
+//	td.Cmp(t, period, td.Struct(Period{}, td.StructFields{
+//	  "End": td.Gt(td.Ref("Start")), // End has to be after Start
+//	}))
+//
+// path is a fields-path as [Smuggle] operator accepts, like
+// "Start", "Items[0].Price" or "Tags[name]". If path starts with
+// "$", it is resolved relative to the root of got, "$" alone being
+// the root itself:
+//
+//	td.Cmp(t, order, td.Struct(Order{}, td.StructFields{
+//	  "Lines": td.ArrayEach(td.Struct(Line{}, td.StructFields{
+//	    "Currency": td.Ref("$.Currency"), // same currency as the order
+//	  })),
+//	}))
+//
+// Otherwise, it is resolved relative to the parent of the compared
+// value, that is the nearest struct, map, array or slice containing
+// it, as compared by [Cmp] itself or by [Struct], [SStruct], [Map],
+// [SubMapOf], [SuperMapOf], [Array], [Slice], [SuperSliceOf],
+// [ArrayEach] or [MapEach] operators.
+//
+// Inside [JSON], [SubJSONOf] and [SuperJSONOf], the root is the JSON
+// representation of the data compared by this operator, so keys are
+// JSON ones. Note that in JSON, a string starting with "$." or "$["
+// is a shortcut for Ref operator:
+//
+//	td.Cmp(t, period, td.JSON(`{
+//	  "start": NotZero(),
+//	  "end":   Gt(Ref("start")),
+//	  "max":   "$.end", // same as Ref("$.end")
+//	}`))
+//
+// See also [Smuggle] and [Lax].
+func Ref(path string) TestDeep {
+	r := tdRef{
+		baseOKNil: newBaseOKNil(3),
+		path:      path,
+	}
+
+	if strings.HasPrefix(path, "$") {
+		r.fromRoot = true
+		path = strings.TrimPrefix(path[1:], ".")
+		if path == "" {
+			return &r
+		}
+	}
+
+	fn, err := buildFieldsPathFn(path)
+	if err != nil {
+		r.err = ctxerr.OpBad("Ref", "Ref(FIELDS_PATH): %s", err)
+		return &r
+	}
+	r.fieldsFn = fn
+	return &r
+}
+
+// resolve returns the value referenced by r.
+func (r *tdRef) resolve(ctx ctxerr.Context) (any, error) {
+	from := ctx.Root
+	if !r.fromRoot {
+		if !ctx.Parent.IsValid() {
+			return nil, fmt.Errorf(
+				"no parent struct, map, array or slice to resolve %q from, use \"$\" prefix instead",
+				r.path)
+		}
+		from = ctx.Parent
+	}
+
+	value, _ := dark.GetInterface(from, true)
+	if r.fieldsFn == nil {
+		return value, nil
+	}
+
+	sv, err := r.fieldsFn(value)
+	if err != nil {
+		return nil, err
+	}
+	value, _ = dark.GetInterface(sv.Value, true)
+	return value, nil
+}
+
+func refResolveError(ctx ctxerr.Context, err error) *ctxerr.Error {
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message: "cannot resolve Ref",
+		Summary: ctxerr.NewSummary(err.Error()),
+	})
+}
+
+func (r *tdRef) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if r.err != nil {
+		return ctx.CollectError(r.err)
+	}
+
+	value, err := r.resolve(ctx)
+	if err != nil {
+		return refResolveError(ctx, err)
+	}
+	return deepValueEqual(ctx, got, reflect.ValueOf(value))
+}
+
+func (r *tdRef) String() string {
+	if r.err != nil {
+		return r.stringError()
+	}
+	return "Ref(" + strconv.Quote(r.path) + ")"
+}
+
+// tdRefOperand is used by operators accepting [Ref] as parameter. As
+// the parameters can only be resolved at match time, the real
+// operator is built at this time, using build.
+type tdRefOperand struct {
+	base
+	build  func(params []any) TestDeep
+	params []any
+}
+
+var _ TestDeep = &tdRefOperand{}
+
+// refOf returns the [Ref] behind p, or nil if p is not a [Ref].
+func refOf(p any) *tdRef {
+	switch p := p.(type) {
+	case *tdRef:
+		return p
+	case *tdJSONEmbedded: // Ref used inside JSON
+		ref, _ := p.expectedValue.Interface().(*tdRef)
+		return ref
+	}
+	return nil
+}
+
+// hasRef returns true if at least one of params is a [Ref].
+func hasRef(params ...any) bool {
+	for _, p := range params {
+		if refOf(p) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonRefPath returns path where all fields are replaced by map
+// keys, as [jsonFieldsPath] does, but keeping the "$" root prefix.
+func jsonRefPath(path string) string {
+	var prefix string
+	if strings.HasPrefix(path, "$") {
+		prefix = "$"
+		path = strings.TrimPrefix(path[1:], ".")
+		if path == "" {
+			return prefix
+		}
+	}
+
+	return prefix + jsonFieldsPath(path)
+}
+
+// newRefOperand returns an operator calling build with params, once
+// the [Ref]s they contain are resolved. It must be called directly
+// from the operator function.
+func newRefOperand(build func(params []any) TestDeep, params ...any) TestDeep {
+	o := tdRefOperand{
+		base:   newBase(4),
+		build:  build,
+		params: params,
+	}
+	for _, p := range params {
+		if ref := refOf(p); ref != nil && ref.err != nil {
+			o.err = ref.err
+			break
+		}
+	}
+	return &o
+}
+
+func (o *tdRefOperand) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if o.err != nil {
+		return ctx.CollectError(o.err)
+	}
+
+	params := make([]any, len(o.params))
+	for i, p := range o.params {
+		if ref := refOf(p); ref != nil {
+			value, err := ref.resolve(ctx)
+			if err == nil && value == nil {
+				err = fmt.Errorf("%s is nil", ref)
+			}
+			if err != nil {
+				return refResolveError(ctx, err)
+			}
+			p = value
+		}
+		params[i] = p
+	}
+
+	op := o.build(params)
+	op.replaceLocation(o.location)
+	return deepValueEqual(ctx, got, reflect.ValueOf(op))
+}
+
+func (o *tdRefOperand) String() string {
+	if o.err != nil {
+		return o.stringError()
+	}
+
+	var b strings.Builder
+	b.WriteString(o.location.Func)
+	params := make([]reflect.Value, len(o.params))
+	for i, p := range o.params {
+		params[i] = reflect.ValueOf(p)
+	}
+	return util.SliceToString(&b, params).String()
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+type refPeriod struct {
+	Start time.Time
+	End   time.Time
+	Max   time.Time
+}
+
+type refLine struct {
+	Currency string
+	Price    int
+}
+
+type refOrder struct {
+	Currency string
+	Total    int
+	MinPrice int
+	Lines    []refLine
+	Tags     map[string]int
+}
+
+func TestRef(t *testing.T) {
+	now := time.Date(2023, time.March, 9, 12, 0, 0, 0, time.UTC)
+	period := refPeriod{
+		Start: now,
+		End:   now.Add(time.Hour),
+		Max:   now.Add(time.Hour),
+	}
+
+	t.Run("parent", func(t *testing.T) {
+		checkOK(t, period, td.Struct(refPeriod{}, td.StructFields{
+			"End": td.Gt(td.Ref("Start")),
+			"Max": td.Ref("End"),
+		}))
+		checkOK(t, &period, td.Struct(&refPeriod{}, td.StructFields{
+			"End": td.Between(td.Ref("Start"), td.Ref("Max")),
+		}))
+		checkOK(t, period, td.SStruct(refPeriod{}, td.StructFields{
+			"Start": td.Lt(td.Ref("End")),
+			"End":   td.Lte(td.Ref("Max")),
+			"Max":   td.Gte(td.Ref("End")),
+		}))
+
+		// Without operator
+		checkOK(t, []any{1, 2, 3}, []any{1, td.N(td.Ref("[2]"), 1), 3})
+		checkOK(t, map[string]any{"a": 1, "b": 1},
+			map[string]any{"a": 1, "b": td.Ref("[a]")})
+
+		checkOK(t, map[string]int{"min": 1, "max": 4, "cur": 3},
+			td.Map(map[string]int{}, td.MapEntries{
+				"min": 1,
+				"max": td.Gt(td.Ref("[min]")),
+				"cur": td.Between(td.Ref("[min]"), td.Ref("[max]")),
+			}))
+		checkOK(t, []int{4, 5, 6},
+			td.ArrayEach(td.Between(td.Ref("[0]"), td.Ref("[2]"))))
+		checkOK(t, map[string]int{"a": 3, "b": 4},
+			td.MapEach(td.N(td.Ref("[a]"), 1)))
+		checkOK(t, []int{4, 5}, td.SuperSliceOf([]int{}, td.ArrayEntries{
+			1: td.N(td.Ref("[0]"), td.Ref("[0]")),
+		}))
+
+		checkError(t, period, td.Struct(refPeriod{}, td.StructFields{
+			"End": td.Lt(td.Ref("Start")),
+		}),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA.End"),
+				Got:      mustBe("(time.Time) 2023-03-09 13:00:00 +0000 UTC"),
+				Expected: mustBe("< (time.Time) 2023-03-09 12:00:00 +0000 UTC"),
+			})
+
+		checkError(t, period, td.Struct(refPeriod{}, td.StructFields{
+			"Max": td.Ref("Start"),
+		}),
+			expectedError{
+				Message: mustBe("values differ"),
+				Path:    mustBe("DATA.Max.ext"),
+			})
+
+		checkError(t, []any{nil, 12}, []any{nil, td.Gt(td.Ref("[0]"))},
+			expectedError{
+				Message: mustBe("cannot resolve Ref"),
+				Path:    mustBe("DATA[1]"),
+				Summary: mustBe(`Ref("[0]") is nil`),
+				Located: true,
+			})
+
+		checkError(t, period, td.Struct(refPeriod{}, td.StructFields{
+			"End": td.Gt(td.Ref("Foo")),
+		}),
+			expectedError{
+				Message: mustBe("cannot resolve Ref"),
+				Path:    mustBe("DATA.End"),
+				Summary: mustBe(`field "Foo" not found`),
+			})
+
+		// Not wrapped in a struct, as it would be a parent
+		_checkError(t, 12, td.Gt(td.Ref("Foo")),
+			expectedError{
+				Message: mustBe("cannot resolve Ref"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`no parent struct, map, array or slice to resolve "Foo" from, use "$" prefix instead`),
+			})
+
+		// Bad type, detected at match time
+		checkError(t, period, td.Struct(refPeriod{}, td.StructFields{
+			"End": td.Gt(td.Ref("Start.Location")),
+		}),
+			expectedError{
+				Message: mustBe("cannot resolve Ref"),
+				Path:    mustBe("DATA.End"),
+				Summary: mustBe(`field "Start.Location" not found`),
+			})
+	})
+
+	// checkOK and checkError cannot be used here, as they also test
+	// got wrapped in a struct, so changing the root
+	t.Run("root", func(t *testing.T) {
+		order := refOrder{
+			Currency: "EUR",
+			Total:    30,
+			MinPrice: 10,
+			Lines: []refLine{
+				{Currency: "EUR", Price: 10},
+				{Currency: "EUR", Price: 20},
+			},
+			Tags: map[string]int{"max": 30},
+		}
+
+		_checkOK(t, order, td.Struct(refOrder{}, td.StructFields{
+			"Lines": td.ArrayEach(td.Struct(refLine{}, td.StructFields{
+				"Currency": td.Ref("$.Currency"),
+				"Price":    td.Between(td.Ref("$.MinPrice"), td.Ref("$.Total")),
+			})),
+			"Tags": td.MapEach(td.Ref("$.Total")),
+		}))
+		_checkOK(t, 12, td.Ref("$"))
+		_checkOK(t, []int{12, 12}, td.ArrayEach(td.Ref("$[0]")))
+		_checkOK(t, order, td.Smuggle("Total", td.Ref("$.Tags[max]")))
+
+		order.Lines[1].Currency = "USD"
+		_checkError(t, order, td.Struct(refOrder{}, td.StructFields{
+			"Lines": td.ArrayEach(td.Struct(refLine{}, td.StructFields{
+				"Currency": td.Ref("$.Currency"),
+			})),
+		}),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA.Lines[1].Currency"),
+				Got:      mustBe(`"USD"`),
+				Expected: mustBe(`"EUR"`),
+			})
+
+		_checkError(t, order, td.Struct(refOrder{}, td.StructFields{
+			"Total": td.Gt(td.Ref("$.Lines[2].Price")),
+		}),
+			expectedError{
+				Message: mustBe("cannot resolve Ref"),
+				Path:    mustBe("DATA.Total"),
+				Summary: mustBe(`field "Lines[2]", 2 is out of slice/array range (len 2)`),
+			})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		type Period struct {
+			Start int `json:"start"`
+			End   int `json:"end"`
+			Max   int `json:"max"`
+		}
+
+		checkOK(t, Period{Start: 1, End: 2, Max: 2}, td.JSON(`{
+  "start": 1,
+  "end":   Gt(Ref("start")),
+  "max":   "$.end"
+}`))
+		checkOK(t, Period{Start: 1, End: 2, Max: 2}, td.SubJSONOf(`{
+  "start": Lt("$[max]"),
+  "end":   Ref("max"),
+  "max":   N(Ref("end")),
+  "foo":   12
+}`))
+		checkOK(t, Period{Start: 1, End: 2, Max: 2}, td.SuperJSONOf(`{
+  "end": Between(Ref("start"), "$.max"),
+  "max": Gte("$.start")
+}`))
+
+		checkError(t, Period{Start: 1, End: 2, Max: 3}, td.JSON(`{
+  "start": 1,
+  "end":   Gt(Ref("start")),
+  "max":   "$.end"
+}`),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe(`DATA["max"]`),
+				Got:      mustBe("3.0"),
+				Expected: mustBe("2.0"),
+			})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, "never tested", td.Ref("Foo..Bar"),
+			expectedError{
+				Message: mustBe("bad usage of Ref operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`Ref(FIELDS_PATH): unexpected '.' after '.' in FIELD_PATH "Foo..Bar"`),
+			})
+
+		checkError(t, "never tested", td.Gt(td.Ref("$..Foo")),
+			expectedError{
+				Message: mustBe("bad usage of Ref operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`Ref(FIELDS_PATH): '.' cannot be the first rune in FIELD_PATH ".Foo"`),
+			})
+		test.EqualStr(t, td.Gt(td.Ref("$..Foo")).String(), "Gt(<ERROR>)")
+
+		_checkError(t, 12, td.Gt(td.Ref("$")),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("12"),
+				Expected: mustBe("> 12"),
+			})
+
+		checkError(t, refLine{Currency: "EUR"},
+			td.Struct(refLine{}, td.StructFields{"Price": td.Gt(td.Ref("Currency"))}),
+			expectedError{
+				Message: mustBe("type mismatch"),
+				Path:    mustBe("DATA.Price"),
+				Got:     mustBe("int"),
+			})
+
+		// Erroneous op
+		test.EqualStr(t, td.Ref("Foo..Bar").String(), "Ref(<ERROR>)")
+	})
+
+	//
+	// String
+	test.EqualStr(t, td.Ref("$.Foo[0]").String(), `Ref("$.Foo[0]")`)
+	test.EqualStr(t, td.Gt(td.Ref("Foo")).String(), `Gt(Ref("Foo"))`)
+	test.EqualStr(t, td.Between(td.Ref("Foo"), 12).String(), `Between(Ref("Foo"),
+        12)`)
+}
+
+func TestRefTypeBehind(t *testing.T) {
+	equalTypes(t, td.Ref("Foo"), nil)
+	equalTypes(t, td.Gt(td.Ref("Foo")), nil)
+	equalTypes(t, td.N(12, td.Ref("Foo")), nil)
+	equalTypes(t, td.Gt(12), 0)
+}
@@ -22,6 +22,8 @@ type tdSetResultKind uint8
 const (
 	itemsSetResult tdSetResultKind = iota
 	keysSetResult
+	fieldsSetResult
+	filesSetResult
 )
 
 // Implements fmt.Stringer.
@@ -31,6 +33,10 @@ func (k tdSetResultKind) String() string {
 		return "item"
 	case keysSetResult:
 		return "key"
+	case fieldsSetResult:
+		return "field"
+	case filesSetResult:
+		return "file"
 	default:
 		return "?"
 	}
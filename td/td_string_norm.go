@@ -0,0 +1,220 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type stringNormKind uint8
+
+const (
+	equalStringNorm stringNormKind = iota
+	prefixStringNorm
+	suffixStringNorm
+	containsStringNorm
+)
+
+type tdStringNorm struct {
+	tdStringBase
+	kind         stringNormKind
+	normalizers  []StringNormalizer
+	normExpected string
+}
+
+var _ TestDeep = &tdStringNorm{}
+
+func newStringNorm(kind stringNormKind, expected string, normalizers []StringNormalizer) *tdStringNorm {
+	s := tdStringNorm{
+		tdStringBase: tdStringBase{
+			base:     newBase(4),
+			expected: expected,
+		},
+		kind:        kind,
+		normalizers: normalizers,
+	}
+
+	for i, normalize := range normalizers {
+		if normalize == nil {
+			s.err = ctxerr.OpBad(s.location.Func,
+				"usage: %s(STRING, NORMALIZER...), NORMALIZER #%d is nil",
+				s.location.Func, i+1)
+			return &s
+		}
+	}
+
+	s.normExpected = normalizeString(expected, normalizers)
+	return &s
+}
+
+// summary(StringFold): checks a string, []byte, error or fmt.Stringer
+// interfaces string contents, ignoring case
+// input(StringFold): str,slice([]byte),if(✓ + fmt.Stringer/error)
+
+// StringFold operator allows to compare a string (or convertible),
+// []byte (or convertible), error or [fmt.Stringer] interface (error
+// interface is tested before [fmt.Stringer]) to expected, using
+// Unicode case folding as [strings.EqualFold] does.
+//
+//	td.Cmp(t, "Hello World!", td.StringFold("HELLO world!")) // succeeds
+//
+//	err := errors.New("Access Denied")
+//	td.Cmp(t, err, td.StringFold("access denied")) // succeeds
+//
+// It is a shortcut for:
+//
+//	td.StringNorm(expected, td.FoldCase)
+//
+// See also [FoldCase], [String] and [StringNorm].
+func StringFold(expected string) TestDeep {
+	return newStringNorm(equalStringNorm, expected, []StringNormalizer{FoldCase})
+}
+
+// summary(StringNorm): checks a string, []byte, error or fmt.Stringer
+// interfaces string contents, after normalizing it
+// input(StringNorm): str,slice([]byte),if(✓ + fmt.Stringer/error)
+
+// StringNorm operator allows to compare a string (or convertible),
+// []byte (or convertible), error or [fmt.Stringer] interface (error
+// interface is tested before [fmt.Stringer]) to expected, after
+// normalizing both of them using normalizers, each one being applied
+// in turn.
+//
+//	td.Cmp(t, "  Hello \n  World!  ",
+//	  td.StringNorm("hello world!", td.CollapseSpace, td.FoldCase)) // succeeds
+//
+// Any func(string) string function can be used as normalizer. To keep
+// go-testdeep free of dependencies, Unicode normalization (NFC, NFD,
+// …) is not provided and is left to a user-supplied normalizer, like
+// norm.NFC.String from [golang.org/x/text/unicode/norm] package:
+//
+//	// "é" is U+00E9 in expected, "e" followed by U+0301 in got
+//	td.Cmp(t, "cafe\u0301", td.StringNorm("caf\u00e9", norm.NFC.String)) // succeeds
+//
+// Without normalizers, StringNorm behaves like [String].
+//
+// See also [CollapseSpace], [FoldCase], [ContainsNorm],
+// [HasPrefixNorm], [HasSuffixNorm], [String] and [StringFold].
+//
+// [golang.org/x/text/unicode/norm]: https://pkg.go.dev/golang.org/x/text/unicode/norm
+func StringNorm(expected string, normalizers ...StringNormalizer) TestDeep {
+	return newStringNorm(equalStringNorm, expected, normalizers)
+}
+
+// summary(HasPrefixNorm): checks the prefix of a string, []byte,
+// error or fmt.Stringer interfaces, after normalizing it
+// input(HasPrefixNorm): str,slice([]byte),if(✓ + fmt.Stringer/error)
+
+// HasPrefixNorm operator works as [HasPrefix] but after normalizing
+// both got and expected strings using normalizers, each one being
+// applied in turn. See [StringNorm] for details.
+//
+//	td.Cmp(t, "HELLO  World!", td.HasPrefixNorm("hello w",
+//	  td.CollapseSpace, td.FoldCase)) // succeeds
+//
+// See also [CollapseSpace], [FoldCase], [ContainsNorm], [HasPrefix],
+// [HasSuffixNorm] and [StringNorm].
+func HasPrefixNorm(expected string, normalizers ...StringNormalizer) TestDeep {
+	return newStringNorm(prefixStringNorm, expected, normalizers)
+}
+
+// summary(HasSuffixNorm): checks the suffix of a string, []byte,
+// error or fmt.Stringer interfaces, after normalizing it
+// input(HasSuffixNorm): str,slice([]byte),if(✓ + fmt.Stringer/error)
+
+// HasSuffixNorm operator works as [HasSuffix] but after normalizing
+// both got and expected strings using normalizers, each one being
+// applied in turn. See [StringNorm] for details.
+//
+//	td.Cmp(t, "Hello  WORLD!\n", td.HasSuffixNorm("o world!",
+//	  td.CollapseSpace, td.FoldCase)) // succeeds
+//
+// See also [CollapseSpace], [FoldCase], [ContainsNorm], [HasPrefixNorm],
+// [HasSuffix] and [StringNorm].
+func HasSuffixNorm(expected string, normalizers ...StringNormalizer) TestDeep {
+	return newStringNorm(suffixStringNorm, expected, normalizers)
+}
+
+// summary(ContainsNorm): checks that a string, []byte, error or
+// fmt.Stringer interfaces contain a sub-string, after normalizing it
+// input(ContainsNorm): str,slice([]byte),if(✓ + fmt.Stringer/error)
+
+// ContainsNorm operator works as [Contains] does for strings, but
+// after normalizing both got and expected strings using normalizers,
+// each one being applied in turn. See [StringNorm] for details.
+//
+//	td.Cmp(t, "<p>\n  Hello\n  World!\n</p>",
+//	  td.ContainsNorm("hello world", td.CollapseSpace, td.FoldCase)) // succeeds
+//
+// Contrary to [Contains], only a string can be expected.
+//
+// See also [CollapseSpace], [FoldCase], [Contains], [HasPrefixNorm],
+// [HasSuffixNorm] and [StringNorm].
+func ContainsNorm(expected string, normalizers ...StringNormalizer) TestDeep {
+	return newStringNorm(containsStringNorm, expected, normalizers)
+}
+
+func (s *tdStringNorm) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if s.err != nil {
+		return ctx.CollectError(s.err)
+	}
+
+	str, err := getString(ctx, got)
+	if err != nil {
+		return err
+	}
+
+	normStr := normalizeString(str, s.normalizers)
+
+	var (
+		ok      bool
+		message string
+	)
+	switch s.kind {
+	case equalStringNorm:
+		ok, message = normStr == s.normExpected, "does not match"
+	case prefixStringNorm:
+		ok, message = strings.HasPrefix(normStr, s.normExpected), "has not prefix"
+	case suffixStringNorm:
+		ok, message = strings.HasSuffix(normStr, s.normExpected), "has not suffix"
+	default: // containsStringNorm
+		ok, message = strings.Contains(normStr, s.normExpected), "does not contain"
+	}
+	if ok {
+		return nil
+	}
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+
+	var gotErr any = str
+	if normStr != str {
+		gotErr = types.RawString(util.ToString(str) +
+			"\nnormalized to:\n" + util.ToString(normStr))
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message:  message,
+		Got:      gotErr,
+		Expected: s,
+	})
+}
+
+func (s *tdStringNorm) String() string {
+	if s.err != nil {
+		return s.stringError()
+	}
+	str := s.location.Func + "(" + util.ToString(s.expected) + ")"
+	if s.normExpected != s.expected {
+		str += ", normalized to " + util.ToString(s.normExpected)
+	}
+	return str
+}
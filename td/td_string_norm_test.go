@@ -0,0 +1,172 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestStringNormalizers(t *testing.T) {
+	test.EqualStr(t, td.CollapseSpace("  foo \t\n bar  "), "foo bar")
+	test.EqualStr(t, td.CollapseSpace(""), "")
+	test.EqualStr(t, td.CollapseSpace(" \n "), "")
+
+	for _, pair := range [][2]string{
+		{"Hello World", "hELLO wORLD"},
+		{"straße", "STRASSE"}, // no full case folding, as strings.EqualFold
+		{"K", "K"},            // KELVIN SIGN
+		{"σς", "ΣΣ"},
+		{"", ""},
+	} {
+		test.EqualBool(t,
+			td.FoldCase(pair[0]) == td.FoldCase(pair[1]),
+			strings.EqualFold(pair[0], pair[1]))
+	}
+}
+
+func TestStringFold(t *testing.T) {
+	checkOK(t, "Hello World!", td.StringFold("hello WORLD!"))
+	checkOK(t, []byte("Hello World!"), td.StringFold("hello WORLD!"))
+	checkOK(t, MyStringer{}, td.StringFold("PIPO Bingo"))
+	checkOK(t, errors.New("Access Denied"), td.StringFold("access denied"))
+	checkOK(t, "K", td.StringFold("k"))
+
+	checkError(t, "Hello", td.StringFold("hello!"),
+		expectedError{
+			Message:  mustBe("does not match"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe(`"Hello"` + "\nnormalized to:\n" + `"HELLO"`),
+			Expected: mustBe(`StringFold("hello!"), normalized to "HELLO!"`),
+		})
+
+	checkError(t, 12, td.StringFold("12"),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("string (convertible) OR []byte (convertible) OR fmt.Stringer OR error"),
+		})
+
+	test.EqualStr(t, td.StringFold("FOO").String(), `StringFold("FOO")`)
+}
+
+func TestStringNorm(t *testing.T) {
+	html := "<p>\n  Hello\n  World!\n</p>\n"
+	noDash := strings.NewReplacer("-", "").Replace
+
+	t.Run("StringNorm", func(t *testing.T) {
+		checkOK(t, "foo", td.StringNorm("foo"))
+		checkOK(t, html, td.StringNorm("<p> Hello World! </p>", td.CollapseSpace))
+		checkOK(t, html, td.StringNorm("<P> hello world! </P>", td.CollapseSpace, td.FoldCase))
+		checkOK(t, "2023-03-09", td.StringNorm("20230309", noDash))
+
+		// Unicode normalization is left to user-supplied normalizers
+		const (
+			cafeNFC = "caf\u00e9"  // é as one code point
+			cafeNFD = "cafe\u0301" // e + combining acute accent
+		)
+		nfc := strings.NewReplacer("e\u0301", "\u00e9").Replace
+		checkOK(t, cafeNFD, td.StringNorm(cafeNFC, nfc))
+		checkOK(t, cafeNFC, td.StringNorm(cafeNFD, nfc))
+		checkOK(t, cafeNFD, td.ContainsNorm("f\u00e9", nfc))
+		checkError(t, cafeNFD, td.StringNorm(cafeNFC),
+			expectedError{
+				Message: mustBe("does not match"),
+				Path:    mustBe("DATA"),
+			})
+
+		checkError(t, "foo", td.StringNorm("bar"),
+			expectedError{
+				Message:  mustBe("does not match"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe(`"foo"`),
+				Expected: mustBe(`StringNorm("bar")`),
+			})
+
+		checkError(t, html, td.StringNorm("<p>Hello World!</p>", td.CollapseSpace),
+			expectedError{
+				Message: mustBe("does not match"),
+				Path:    mustBe("DATA"),
+				Got: mustBe("`<p>\n  Hello\n  World!\n</p>\n`" +
+					"\nnormalized to:\n" + `"<p> Hello World! </p>"`),
+				Expected: mustBe(`StringNorm("<p>Hello World!</p>")`),
+			})
+
+		checkError(t, "never tested", td.StringNorm("foo", td.FoldCase, nil),
+			expectedError{
+				Message: mustBe("bad usage of StringNorm operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: StringNorm(STRING, NORMALIZER...), NORMALIZER #2 is nil"),
+			})
+
+		// Erroneous op
+		test.EqualStr(t, td.StringNorm("foo", nil).String(), "StringNorm(<ERROR>)")
+	})
+
+	t.Run("HasPrefixNorm", func(t *testing.T) {
+		checkOK(t, "HELLO  World!", td.HasPrefixNorm("hello w", td.CollapseSpace, td.FoldCase))
+		checkOK(t, html, td.HasPrefixNorm("<p> Hello", td.CollapseSpace))
+		checkOK(t, "foobar", td.HasPrefixNorm("foo"))
+
+		checkError(t, "foobar", td.HasPrefixNorm("FOOZ", td.FoldCase),
+			expectedError{
+				Message:  mustBe("has not prefix"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe(`"foobar"` + "\nnormalized to:\n" + `"FOOBAR"`),
+				Expected: mustBe(`HasPrefixNorm("FOOZ")`),
+			})
+	})
+
+	t.Run("HasSuffixNorm", func(t *testing.T) {
+		checkOK(t, "Hello  WORLD!\n", td.HasSuffixNorm("o world!", td.CollapseSpace, td.FoldCase))
+		checkOK(t, errors.New("foobar"), td.HasSuffixNorm("bar"))
+
+		checkError(t, "foobar", td.HasSuffixNorm("foo"),
+			expectedError{
+				Message:  mustBe("has not suffix"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe(`"foobar"`),
+				Expected: mustBe(`HasSuffixNorm("foo")`),
+			})
+	})
+
+	t.Run("ContainsNorm", func(t *testing.T) {
+		checkOK(t, html, td.ContainsNorm("hello world", td.CollapseSpace, td.FoldCase))
+		checkOK(t, []byte(html), td.ContainsNorm("Hello World", td.CollapseSpace))
+		checkOK(t, "foobar", td.ContainsNorm(""))
+
+		checkError(t, html, td.ContainsNorm("hello world", td.CollapseSpace),
+			expectedError{
+				Message: mustBe("does not contain"),
+				Path:    mustBe("DATA"),
+				Got: mustBe("`<p>\n  Hello\n  World!\n</p>\n`" +
+					"\nnormalized to:\n" + `"<p> Hello World! </p>"`),
+				Expected: mustBe(`ContainsNorm("hello world")`),
+			})
+
+		checkError(t, 42, td.ContainsNorm("4"),
+			expectedError{
+				Message:  mustBe("bad type"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("int"),
+				Expected: mustBe("string (convertible) OR []byte (convertible) OR fmt.Stringer OR error"),
+			})
+	})
+}
+
+func TestStringNormTypeBehind(t *testing.T) {
+	equalTypes(t, td.StringFold("foo"), nil)
+	equalTypes(t, td.StringNorm("foo"), nil)
+	equalTypes(t, td.HasPrefixNorm("foo"), nil)
+	equalTypes(t, td.HasSuffixNorm("foo"), nil)
+	equalTypes(t, td.ContainsNorm("foo"), nil)
+}
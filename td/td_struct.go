@@ -761,9 +761,11 @@ func (s *tdStruct) Match(ctx ctxerr.Context, got reflect.Value) (err *ctxerr.Err
 	}
 
 	ignoreUnexported := ctx.IgnoreUnexported || ctx.Hooks.IgnoreUnexported(got.Type())
+	ignoredFields := ctx.Hooks.IgnoredFields(got.Type())
 
+	ctx.Parent = got
 	for _, fieldInfo := range s.expectedFields {
-		if ignoreUnexported && fieldInfo.unexported {
+		if (ignoreUnexported && fieldInfo.unexported) || ignoredFields[fieldInfo.name] {
 			continue
 		}
 		err = deepValueEqual(ctx.AddField(fieldInfo.name),
@@ -0,0 +1,302 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/flat"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type seqKind uint8
+
+const (
+	subSeq seqKind = iota
+	containsSeq
+	prefixSeq
+	suffixSeq
+)
+
+type tdSeq struct {
+	baseOKNil
+	kind          seqKind
+	expectedItems []reflect.Value
+}
+
+var _ TestDeep = &tdSeq{}
+
+func newSeq(kind seqKind, expectedItems []any) *tdSeq {
+	return &tdSeq{
+		baseOKNil:     newBaseOKNil(4),
+		kind:          kind,
+		expectedItems: flat.Values(expectedItems),
+	}
+}
+
+// summary(Subsequence): checks that an array or a slice contains
+// some items in the same relative order
+// input(Subsequence): array,slice,ptr(ptr on array/slice)
+
+// Subsequence operator checks that an array or a slice (or a pointer
+// on array/slice) contains all expectedItems in the same relative
+// order, possibly with other items between them.
+//
+// Each expected item can be any value including a [TestDeep]
+// operator.
+//
+//	events := []string{"start", "login", "read", "write", "logout", "stop"}
+//	td.Cmp(t, events, td.Subsequence("login", "write", "logout"))  // succeeds
+//	td.Cmp(t, events, td.Subsequence("login", td.HasPrefix("wr"))) // succeeds
+//	td.Cmp(t, events, td.Subsequence("logout", "login"))           // fails
+//
+// In case of failure, the longest matched prefix of expectedItems is
+// reported along with the missing items.
+//
+// To flatten a non-[]any slice/array, use [Flatten] function
+// and so avoid boring and inefficient copies:
+//
+//	expected := []string{"login", "logout"}
+//	td.Cmp(t, events, td.Subsequence(td.Flatten(expected))) // succeeds
+//
+// TypeBehind method can return a non-nil [reflect.Type] if all items
+// known non-interface types are equal, or if only interface types
+// are found (mostly issued from [Isa]) and they are equal.
+//
+// See also [ContainsSeq], [SliceHasPrefix], [SliceHasSuffix] and
+// [SuperBagOf].
+func Subsequence(expectedItems ...any) TestDeep {
+	return newSeq(subSeq, expectedItems)
+}
+
+// summary(ContainsSeq): checks that an array or a slice contains
+// some contiguous items
+// input(ContainsSeq): array,slice,ptr(ptr on array/slice)
+
+// ContainsSeq operator checks that an array or a slice (or a pointer
+// on array/slice) contains all expectedItems contiguously and in the
+// same order.
+//
+// Each expected item can be any value including a [TestDeep]
+// operator.
+//
+//	events := []string{"start", "login", "read", "write", "logout", "stop"}
+//	td.Cmp(t, events, td.ContainsSeq("read", "write"))             // succeeds
+//	td.Cmp(t, events, td.ContainsSeq(td.HasPrefix("log"), "read")) // succeeds
+//	td.Cmp(t, events, td.ContainsSeq("login", "write"))            // fails
+//
+// In case of failure, the longest matched prefix of expectedItems is
+// reported along with the index in got where it starts.
+//
+// To flatten a non-[]any slice/array, use [Flatten] function
+// and so avoid boring and inefficient copies:
+//
+//	expected := []string{"read", "write"}
+//	td.Cmp(t, events, td.ContainsSeq(td.Flatten(expected))) // succeeds
+//
+// TypeBehind method can return a non-nil [reflect.Type] if all items
+// known non-interface types are equal, or if only interface types
+// are found (mostly issued from [Isa]) and they are equal.
+//
+// See also [Contains], [SliceHasPrefix], [SliceHasSuffix] and
+// [Subsequence].
+func ContainsSeq(expectedItems ...any) TestDeep {
+	return newSeq(containsSeq, expectedItems)
+}
+
+// summary(SliceHasPrefix): checks that an array or a slice starts
+// with some items
+// input(SliceHasPrefix): array,slice,ptr(ptr on array/slice)
+
+// SliceHasPrefix operator checks that an array or a slice (or a
+// pointer on array/slice) starts with expectedItems, in the same
+// order.
+//
+// Each expected item can be any value including a [TestDeep]
+// operator.
+//
+//	events := []string{"start", "login", "read", "logout", "stop"}
+//	td.Cmp(t, events, td.SliceHasPrefix("start", "login"))               // succeeds
+//	td.Cmp(t, events, td.SliceHasPrefix("start", td.Re(`^log(in|out)`))) // succeeds
+//	td.Cmp(t, events, td.SliceHasPrefix("login"))                        // fails
+//
+// In case of failure, the longest matched prefix of expectedItems is
+// reported along with the missing items.
+//
+// To flatten a non-[]any slice/array, use [Flatten] function
+// and so avoid boring and inefficient copies:
+//
+//	expected := []string{"start", "login"}
+//	td.Cmp(t, events, td.SliceHasPrefix(td.Flatten(expected))) // succeeds
+//
+// TypeBehind method can return a non-nil [reflect.Type] if all items
+// known non-interface types are equal, or if only interface types
+// are found (mostly issued from [Isa]) and they are equal.
+//
+// See also [ContainsSeq], [HasPrefix], [SliceHasSuffix] and
+// [Subsequence].
+func SliceHasPrefix(expectedItems ...any) TestDeep {
+	return newSeq(prefixSeq, expectedItems)
+}
+
+// summary(SliceHasSuffix): checks that an array or a slice ends
+// with some items
+// input(SliceHasSuffix): array,slice,ptr(ptr on array/slice)
+
+// SliceHasSuffix operator checks that an array or a slice (or a
+// pointer on array/slice) ends with expectedItems, in the same
+// order.
+//
+// Each expected item can be any value including a [TestDeep]
+// operator.
+//
+//	events := []string{"start", "login", "read", "logout", "stop"}
+//	td.Cmp(t, events, td.SliceHasSuffix("logout", "stop"))            // succeeds
+//	td.Cmp(t, events, td.SliceHasSuffix(td.HasPrefix("log"), "stop")) // succeeds
+//	td.Cmp(t, events, td.SliceHasSuffix("logout"))                    // fails
+//
+// In case of failure, the longest matched prefix of expectedItems is
+// reported along with the missing items.
+//
+// To flatten a non-[]any slice/array, use [Flatten] function
+// and so avoid boring and inefficient copies:
+//
+//	expected := []string{"logout", "stop"}
+//	td.Cmp(t, events, td.SliceHasSuffix(td.Flatten(expected))) // succeeds
+//
+// TypeBehind method can return a non-nil [reflect.Type] if all items
+// known non-interface types are equal, or if only interface types
+// are found (mostly issued from [Isa]) and they are equal.
+//
+// See also [ContainsSeq], [HasSuffix], [SliceHasPrefix] and
+// [Subsequence].
+func SliceHasSuffix(expectedItems ...any) TestDeep {
+	return newSeq(suffixSeq, expectedItems)
+}
+
+// matchFrom returns the number of expectedItems matching
+// contiguously got items starting at got index start.
+func (s *tdSeq) matchFrom(ctx ctxerr.Context, got reflect.Value, start int) int {
+	n := 0
+	for n < len(s.expectedItems) && start+n < got.Len() &&
+		deepValueEqualFinalOK(ctx, got.Index(start+n), s.expectedItems[n]) {
+		n++
+	}
+	return n
+}
+
+func (s *tdSeq) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	switch got.Kind() {
+	case reflect.Ptr:
+		gotElem := got.Elem()
+		if !gotElem.IsValid() {
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return ctx.CollectError(ctxerr.NilPointer(got, "non-nil *slice OR *array"))
+		}
+
+		if gotElem.Kind() != reflect.Array && gotElem.Kind() != reflect.Slice {
+			break
+		}
+		got = gotElem
+		fallthrough
+
+	case reflect.Array, reflect.Slice:
+		var (
+			gotLen  = got.Len()
+			matched int
+			start   = -1
+			message string
+		)
+
+		switch s.kind {
+		case subSeq:
+			for idx := 0; idx < gotLen && matched < len(s.expectedItems); idx++ {
+				if deepValueEqualFinalOK(ctx, got.Index(idx), s.expectedItems[matched]) {
+					matched++
+				}
+			}
+			message = "subsequence not found"
+
+		case containsSeq:
+			for idx := 0; idx < gotLen && matched < len(s.expectedItems); idx++ {
+				if n := s.matchFrom(ctx, got, idx); n > matched {
+					matched, start = n, idx
+				}
+			}
+			message = "sequence not found"
+
+		case prefixSeq:
+			matched = s.matchFrom(ctx, got, 0)
+			message = "has not prefix"
+
+		case suffixSeq:
+			if gotLen >= len(s.expectedItems) {
+				matched = s.matchFrom(ctx, got, gotLen-len(s.expectedItems))
+			}
+			message = "has not suffix"
+		}
+
+		if matched == len(s.expectedItems) {
+			return nil
+		}
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+
+		var summary ctxerr.ErrorSummaryItems
+		if matched > 0 {
+			item := ctxerr.ErrorSummaryItem{
+				Label: "Matched " + plural(matched, "item"),
+				Value: util.ToString(s.expectedItems[:matched]),
+			}
+			if start >= 0 {
+				item.Explanation = fmt.Sprintf("starting at got index %d", start)
+			}
+			summary = append(summary, item)
+		}
+
+		item := ctxerr.ErrorSummaryItem{
+			Label: "Missing " + plural(len(s.expectedItems)-matched, "item"),
+			Value: util.ToString(s.expectedItems[matched:]),
+		}
+		if (s.kind == prefixSeq || s.kind == suffixSeq) &&
+			gotLen < len(s.expectedItems) {
+			item.Explanation = fmt.Sprintf("got has only %s, %d expected",
+				plural(gotLen, "item"), len(s.expectedItems))
+		}
+		summary = append(summary, item)
+
+		return ctx.CollectError(&ctxerr.Error{
+			Message: message,
+			Summary: summary,
+		})
+	}
+
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(ctxerr.BadKind(got, "slice OR array OR *slice OR *array"))
+}
+
+func (s *tdSeq) String() string {
+	var b strings.Builder
+	b.WriteString(s.GetLocation().Func)
+	return util.SliceToString(&b, s.expectedItems).String()
+}
+
+func (s *tdSeq) TypeBehind() reflect.Type {
+	typ := uniqTypeBehindSlice(s.expectedItems)
+	if typ == nil {
+		return nil
+	}
+	return reflect.SliceOf(typ)
+}
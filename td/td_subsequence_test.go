@@ -0,0 +1,247 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestSubsequence(t *testing.T) {
+	events := []string{"start", "login", "read", "write", "logout", "stop"}
+
+	checkOK(t, events, td.Subsequence())
+	checkOK(t, events, td.Subsequence("start"))
+	checkOK(t, events, td.Subsequence("login", "write", "logout"))
+	checkOK(t, events, td.Subsequence("start", "stop"))
+	checkOK(t, events, td.Subsequence(td.HasPrefix("log"), td.HasPrefix("log")))
+	checkOK(t, &events, td.Subsequence(td.Flatten(events)))
+	checkOK(t, [3]int{1, 2, 3}, td.Subsequence(1, 3))
+	checkOK(t, []any{1, "a", 2}, td.Subsequence(1, 2))
+
+	checkError(t, events, td.Subsequence("login", "write", "read", "stop"),
+		expectedError{
+			Message: mustBe("subsequence not found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Matched 2 items: ("login",
+                  "write")
+Missing 2 items: ("read",
+                  "stop")`),
+		})
+
+	checkError(t, events, td.Subsequence("logout", "login"),
+		expectedError{
+			Message: mustBe("subsequence not found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Matched 1 item: ("logout")
+Missing 1 item: ("login")`),
+		})
+
+	checkError(t, events, td.Subsequence("foo"),
+		expectedError{
+			Message: mustBe("subsequence not found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 1 item: ("foo")`),
+		})
+
+	checkError(t, []string{}, td.Subsequence("foo"),
+		expectedError{
+			Message: mustBe("subsequence not found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 1 item: ("foo")`),
+		})
+
+	//
+	// Bad types
+	checkError(t, 42, td.Subsequence(42),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	checkError(t, nil, td.Subsequence(42),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	checkError(t, (*[]int)(nil), td.Subsequence(42),
+		expectedError{
+			Message:  mustBe("nil pointer"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil *slice (*[]int type)"),
+			Expected: mustBe("non-nil *slice OR *array"),
+		})
+
+	num := 42
+	checkError(t, &num, td.Subsequence(42),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("*int"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.Subsequence().String(), "Subsequence()")
+	test.EqualStr(t, td.Subsequence(1, 2).String(), "Subsequence(1,\n            2)")
+}
+
+func TestContainsSeq(t *testing.T) {
+	events := []string{"start", "login", "read", "write", "logout", "stop"}
+
+	checkOK(t, events, td.ContainsSeq())
+	checkOK(t, events, td.ContainsSeq("read", "write"))
+	checkOK(t, events, td.ContainsSeq("logout", "stop"))
+	checkOK(t, events, td.ContainsSeq(td.HasPrefix("log"), "read"))
+	checkOK(t, events, td.ContainsSeq(td.Flatten(events)))
+	checkOK(t, &[4]int{1, 1, 2, 3}, td.ContainsSeq(1, 2, 3))
+
+	checkError(t, events, td.ContainsSeq("login", "read", "logout"),
+		expectedError{
+			Message: mustBe("sequence not found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Matched 2 items: ("login",
+                  "read")
+starting at got index 1
+ Missing 1 item: ("logout")`),
+		})
+
+	checkError(t, []int{1, 2, 1, 2, 3, 5}, td.ContainsSeq(1, 2, 3, 4),
+		expectedError{
+			Message: mustBe("sequence not found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Matched 3 items: (1,
+                  2,
+                  3)
+starting at got index 2
+ Missing 1 item: (4)`),
+		})
+
+	checkError(t, events, td.ContainsSeq("foo", "bar"),
+		expectedError{
+			Message: mustBe("sequence not found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 2 items: ("foo",
+                  "bar")`),
+		})
+
+	checkError(t, 42, td.ContainsSeq(42),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	test.EqualStr(t, td.ContainsSeq(1, 2).String(), "ContainsSeq(1,\n            2)")
+}
+
+func TestSliceHasPrefix(t *testing.T) {
+	events := []string{"start", "login", "read", "logout", "stop"}
+
+	checkOK(t, events, td.SliceHasPrefix())
+	checkOK(t, events, td.SliceHasPrefix("start"))
+	checkOK(t, events, td.SliceHasPrefix("start", td.Re(`^log(in|out)`)))
+	checkOK(t, events, td.SliceHasPrefix(td.Flatten(events)))
+
+	checkError(t, events, td.SliceHasPrefix("start", "login", "write"),
+		expectedError{
+			Message: mustBe("has not prefix"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Matched 2 items: ("start",
+                  "login")
+ Missing 1 item: ("write")`),
+		})
+
+	checkError(t, events, td.SliceHasPrefix("login"),
+		expectedError{
+			Message: mustBe("has not prefix"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 1 item: ("login")`),
+		})
+
+	checkError(t, []int{1, 2}, td.SliceHasPrefix(1, 2, 3),
+		expectedError{
+			Message: mustBe("has not prefix"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Matched 2 items: (1,
+                  2)
+ Missing 1 item: (3)
+got has only 2 items, 3 expected`),
+		})
+
+	checkError(t, []int{}, td.SliceHasPrefix(1, 2),
+		expectedError{
+			Message: mustBe("has not prefix"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 2 items: (1,
+                  2)
+got has only 0 items, 2 expected`),
+		})
+
+	checkError(t, 42, td.SliceHasPrefix(42),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	test.EqualStr(t, td.SliceHasPrefix(1).String(), "SliceHasPrefix(1)")
+}
+
+func TestSliceHasSuffix(t *testing.T) {
+	events := []string{"start", "login", "read", "logout", "stop"}
+
+	checkOK(t, events, td.SliceHasSuffix())
+	checkOK(t, events, td.SliceHasSuffix("stop"))
+	checkOK(t, events, td.SliceHasSuffix(td.HasPrefix("log"), "stop"))
+	checkOK(t, &events, td.SliceHasSuffix(td.Flatten(events)))
+
+	checkError(t, events, td.SliceHasSuffix("logout", "start"),
+		expectedError{
+			Message: mustBe("has not suffix"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Matched 1 item: ("logout")
+Missing 1 item: ("start")`),
+		})
+
+	checkError(t, []int{1, 2}, td.SliceHasSuffix(0, 1, 2),
+		expectedError{
+			Message: mustBe("has not suffix"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing 3 items: (0,
+                  1,
+                  2)
+got has only 2 items, 3 expected`),
+		})
+
+	checkError(t, 42, td.SliceHasSuffix(42),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	test.EqualStr(t, td.SliceHasSuffix(1).String(), "SliceHasSuffix(1)")
+}
+
+func TestSubsequenceTypeBehind(t *testing.T) {
+	equalTypes(t, td.Subsequence(6, 5), ([]int)(nil))
+	equalTypes(t, td.ContainsSeq(6, 5), ([]int)(nil))
+	equalTypes(t, td.SliceHasPrefix("a"), ([]string)(nil))
+	equalTypes(t, td.SliceHasSuffix(6, "5"), nil)
+}
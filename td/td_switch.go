@@ -0,0 +1,225 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+)
+
+type switchCaseKind uint8
+
+const (
+	typeSwitchCase switchCaseKind = iota
+	nilSwitchCase
+	operatorSwitchCase
+	defaultSwitchCase
+)
+
+// SwitchCase is a case of [Switch] operator. It is returned by [Case]
+// and [Default] functions.
+type SwitchCase struct {
+	kind           switchCaseKind
+	typ            reflect.Type // typeSwitchCase only
+	checkImplement bool         // typeSwitchCase only
+	operator       TestDeep     // operatorSwitchCase only
+	expected       reflect.Value
+}
+
+// Case returns a [SwitchCase] to be used by [Switch] operator. This
+// case is selected when selector matches got, then got is compared
+// against expectedValue.
+//
+// selector can be:
+//   - a TestDeep operator, then the case is selected if got matches
+//     it, as in td.Case(td.Gt(100), td.Between(100, 200));
+//   - nil, then the case is selected if got is nil (typically a nil
+//     interface);
+//   - any other value, then the case is selected if got has the same
+//     type. As for [Isa], a pointer on an interface, like
+//     (*fmt.Stringer)(nil), selects the case if got implements this
+//     interface.
+func Case(selector, expectedValue any) SwitchCase {
+	c := SwitchCase{
+		expected: reflect.ValueOf(expectedValue),
+	}
+
+	switch sel := selector.(type) {
+	case nil:
+		c.kind = nilSwitchCase
+	case TestDeep:
+		c.kind = operatorSwitchCase
+		c.operator = sel
+	default:
+		c.kind = typeSwitchCase
+		c.typ = reflect.TypeOf(selector)
+		c.checkImplement = c.typ.Kind() == reflect.Ptr &&
+			c.typ.Elem().Kind() == reflect.Interface
+	}
+	return c
+}
+
+// Default returns a [SwitchCase] to be used as the last case of
+// [Switch] operator. This case is selected when no other case is, then
+// got is compared against expectedValue.
+func Default(expectedValue any) SwitchCase {
+	return SwitchCase{
+		kind:     defaultSwitchCase,
+		expected: reflect.ValueOf(expectedValue),
+	}
+}
+
+// selects returns true if c has to be selected for got.
+func (c *SwitchCase) selects(ctx ctxerr.Context, got reflect.Value) bool {
+	switch c.kind {
+	case typeSwitchCase:
+		if !got.IsValid() {
+			return false
+		}
+		return got.Type() == c.typ ||
+			(c.checkImplement && got.Type().Implements(c.typ.Elem()))
+	case nilSwitchCase:
+		return !got.IsValid()
+	case operatorSwitchCase:
+		return deepValueEqualFinalOK(ctx, got, reflect.ValueOf(c.operator))
+	default: // defaultSwitchCase
+		return true
+	}
+}
+
+// label returns the path level added when c, the idx-th case of
+// Switch, is selected.
+func (c *SwitchCase) label(idx int) string {
+	if c.kind == defaultSwitchCase {
+		return "<Default>"
+	}
+	var sel string
+	switch c.kind {
+	case typeSwitchCase:
+		sel = c.typ.String()
+	case nilSwitchCase:
+		sel = "nil"
+	default: // operatorSwitchCase
+		sel = c.operator.GetLocation().Func
+	}
+	return "<Case#" + strconv.Itoa(idx+1) + "(" + sel + ")>"
+}
+
+func (c *SwitchCase) String() string {
+	switch c.kind {
+	case typeSwitchCase:
+		return "Case(" + c.typ.String() + ")"
+	case nilSwitchCase:
+		return "Case(nil)"
+	case operatorSwitchCase:
+		return "Case(" + c.operator.String() + ")"
+	default: // defaultSwitchCase
+		return "Default"
+	}
+}
+
+type tdSwitch struct {
+	baseOKNil
+	cases []SwitchCase
+}
+
+var _ TestDeep = &tdSwitch{}
+
+// summary(Switch): selects the expected value depending on data type
+// or on a predicate operator
+// input(Switch): all
+
+// Switch operator selects the first case matching got, then compares
+// got against the expected value of this case. Each case is built
+// using [Case] function, selecting it depending on got type or on a
+// predicate operator. [Default] function can be used as the last case
+// to be selected when no other case is.
+//
+// It is typically used when got is an interface:
+//
+//	var node Node // interface implemented by *Ident and *Call
+//	td.Cmp(t, node, td.Switch(
+//	  td.Case((*Ident)(nil), td.Struct(&Ident{Name: "foo"})),
+//	  td.Case((*Call)(nil), td.Struct(&Call{}, td.StructFields{
+//	    "Args": td.Len(2),
+//	  })),
+//	))
+//
+// but predicate operators can be used too:
+//
+//	td.Cmp(t, got, td.Switch(
+//	  td.Case(td.Lt(0), td.Gte(-10)),
+//	  td.Case(td.Between(0, 100), td.Not(50)),
+//	  td.Default(td.Lte(1000)),
+//	))
+//
+// Contrary to [Any] of [Isa]s, only the selected case is reported in
+// case of failure. The path of the error contains the selected case,
+// as in DATA<Case#2(*Call)>.Args. If no case is selected, Switch
+// fails.
+//
+// [Default] can only be the last case, else Switch is erroneous.
+//
+// See also [Any], [Isa] and [Or].
+func Switch(cases ...SwitchCase) TestDeep {
+	s := tdSwitch{
+		baseOKNil: newBaseOKNil(3),
+		cases:     cases,
+	}
+
+	for i, c := range cases {
+		if c.kind == defaultSwitchCase && i != len(cases)-1 {
+			s.err = ctxerr.OpBad("Switch",
+				"Default can only be the last case, but found as case #%d of %d",
+				i+1, len(cases))
+			break
+		}
+	}
+	return &s
+}
+
+func (s *tdSwitch) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if s.err != nil {
+		return ctx.CollectError(s.err)
+	}
+
+	for idx := range s.cases {
+		c := &s.cases[idx]
+		if c.selects(ctx, got) {
+			return deepValueEqual(ctx.AddCustomLevel(c.label(idx)), got, c.expected)
+		}
+	}
+
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message:  "no case selected",
+		Got:      got,
+		Expected: s,
+	})
+}
+
+func (s *tdSwitch) String() string {
+	if s.err != nil {
+		return s.stringError()
+	}
+
+	var b strings.Builder
+	b.WriteString("Switch(")
+	for idx := range s.cases {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(s.cases[idx].String())
+	}
+	b.WriteByte(')')
+	return b.String()
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+type switchNode interface{ node() }
+
+type switchIdent struct{ Name string }
+
+func (*switchIdent) node() {}
+
+type switchCall struct {
+	Func string
+	Args []switchNode
+}
+
+func (*switchCall) node() {}
+
+func TestSwitch(t *testing.T) {
+	nodeSwitch := td.Switch(
+		td.Case((*switchIdent)(nil), td.Struct(&switchIdent{Name: "foo"})),
+		td.Case((*switchCall)(nil), td.Struct(&switchCall{Func: "bar"}, td.StructFields{
+			"Args": td.Len(1),
+		})),
+		td.Case(nil, td.Nil()),
+	)
+
+	t.Run("type", func(t *testing.T) {
+		checkOK(t, &switchIdent{Name: "foo"}, nodeSwitch)
+		checkOK(t, &switchCall{Func: "bar", Args: []switchNode{nil}}, nodeSwitch)
+		checkOK(t, nil, nodeSwitch)
+
+		// got is an interface
+		type Tree struct{ Root switchNode }
+		checkOK(t, Tree{Root: &switchIdent{Name: "foo"}},
+			td.Struct(Tree{}, td.StructFields{"Root": nodeSwitch}))
+		checkOK(t, Tree{}, td.Struct(Tree{}, td.StructFields{"Root": nodeSwitch}))
+
+		checkError(t, &switchIdent{Name: "zip"}, nodeSwitch,
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA<Case#1(*td_test.switchIdent)>.Name"),
+				Got:      mustBe(`"zip"`),
+				Expected: mustBe(`"foo"`),
+			})
+
+		checkError(t, &switchCall{Func: "bar"}, nodeSwitch,
+			expectedError{
+				Message:  mustBe("bad length"),
+				Path:     mustBe("DATA<Case#2(*td_test.switchCall)>.Args"),
+				Got:      mustBe("0"),
+				Expected: mustBe("1"),
+			})
+
+		checkError(t, Tree{Root: &switchIdent{Name: "zip"}},
+			td.Struct(Tree{}, td.StructFields{"Root": nodeSwitch}),
+			expectedError{
+				Message: mustBe("values differ"),
+				Path:    mustBe("DATA.Root<Case#1(*td_test.switchIdent)>.Name"),
+			})
+
+		checkError(t, switchIdent{Name: "foo"}, nodeSwitch,
+			expectedError{
+				Message:  mustBe("no case selected"),
+				Path:     mustBe("DATA"),
+				Got:      mustContain(`(td_test.switchIdent) {`),
+				Expected: mustBe("Switch(Case(*td_test.switchIdent), Case(*td_test.switchCall), Case(nil))"),
+			})
+
+		// Interface implementation
+		checkOK(t, errors.New("boom"),
+			td.Switch(td.Case((*fmt.Stringer)(nil), "never"),
+				td.Case((*error)(nil), td.String("boom"))))
+	})
+
+	t.Run("operator", func(t *testing.T) {
+		s := td.Switch(
+			td.Case(td.Lt(0), td.Gte(-10)),
+			td.Case(td.Between(0, 100), td.Not(50)),
+			td.Default(td.Lte(1000)),
+		)
+		checkOK(t, -5, s)
+		checkOK(t, 12, s)
+		checkOK(t, 1000, s)
+
+		checkError(t, -20, s,
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA<Case#1(Lt)>"),
+				Got:      mustBe("-20"),
+				Expected: mustBe("≥ -10"),
+			})
+
+		checkError(t, 50, s,
+			expectedError{
+				Message: mustBe("comparing with Not"),
+				Path:    mustBe("DATA<Case#2(Between)>"),
+			})
+
+		checkError(t, 1001, s,
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA<Default>"),
+				Got:      mustBe("1001"),
+				Expected: mustBe("≤ 1000"),
+			})
+
+		checkError(t, "foo", td.Switch(td.Case(td.Len(2), "fo")),
+			expectedError{
+				Message:  mustBe("no case selected"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe(`"foo"`),
+				Expected: mustBe("Switch(Case(len=2))"),
+			})
+	})
+
+	t.Run("no cases", func(t *testing.T) {
+		checkError(t, 12, td.Switch(),
+			expectedError{
+				Message:  mustBe("no case selected"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("12"),
+				Expected: mustBe("Switch()"),
+			})
+
+		checkOK(t, 12, td.Switch(td.Default(12)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, "never tested",
+			td.Switch(td.Default(1), td.Case(0, 2)),
+			expectedError{
+				Message: mustBe("bad usage of Switch operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("Default can only be the last case, but found as case #1 of 2"),
+			})
+
+		// Erroneous op
+		test.EqualStr(t, td.Switch(td.Default(1), td.Default(2)).String(), "Switch(<ERROR>)")
+	})
+
+	test.EqualStr(t,
+		td.Switch(td.Case(0, 1), td.Case(td.Gt(3), 4), td.Default(5)).String(),
+		"Switch(Case(int), Case(> 3), Default)")
+}
+
+func TestSwitchTypeBehind(t *testing.T) {
+	equalTypes(t, td.Switch(td.Case(0, 1)), nil)
+
+	// Erroneous op
+	equalTypes(t, td.Switch(td.Default(1), td.Default(2)), nil)
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/types"
+	"github.com/maxatome/go-testdeep/internal/util"
+)
+
+type tdUnique struct {
+	baseOKNil
+	keyFn   reflect.Value // invalid for Unique
+	argType reflect.Type
+	keyStr  string
+}
+
+var _ TestDeep = &tdUnique{}
+
+// summary(Unique): checks that an array or a slice does not contain
+// duplicate items
+// input(Unique): array,slice,ptr(ptr on array/slice)
+
+// Unique operator checks that an array or a slice (or a pointer on
+// array/slice) does not contain duplicate items. Two items are
+// duplicates if they are equal as [Cmp] would consider them.
+//
+//	td.Cmp(t, []int{1, 2, 3}, td.Unique()) // succeeds
+//
+// In case of failure, each group of duplicate items is reported with
+// the indexes of its items:
+//
+//	td.Cmp(t, []int{1, 2, 1, 2}, td.Unique()) // fails
+//	// DATA: duplicate items found
+//	//   indexes 0, 2: 1
+//	//   indexes 1, 3: 2
+//
+// See also [UniqueBy] and [Set].
+func Unique() TestDeep {
+	return &tdUnique{
+		baseOKNil: newBaseOKNil(3),
+	}
+}
+
+// summary(UniqueBy): checks that an array or a slice does not contain
+// items sharing the same key
+// input(UniqueBy): array,slice,ptr(ptr on array/slice)
+
+// UniqueBy operator checks that an array or a slice (or a pointer on
+// array/slice) does not contain two items having the same key. Two
+// keys are the same if they are equal as [Cmp] would consider them.
+//
+// The key of each item is computed using keyFn, which can be:
+//   - a string, then it is a fields-path as [Smuggle] operator
+//     accepts, like "ID" or "User.Email";
+//   - a function taking one parameter the items are convertible to,
+//     and returning only one value: the key.
+//
+// For example:
+//
+//	type User struct {
+//	  ID    int
+//	  Email string
+//	}
+//	users := []User{{1, "bob@example.com"}, {2, "bob@example.com"}}
+//	td.Cmp(t, users, td.UniqueBy("ID")) // succeeds
+//	td.Cmp(t, users, td.UniqueBy(func(u User) string {
+//	  return strings.ToLower(u.Email)
+//	})) // fails
+//
+// In case of failure, each group of items sharing the same key is
+// reported with the indexes of its items:
+//
+//	td.Cmp(t, users, td.UniqueBy("Email")) // fails
+//	// DATA: duplicate keys found
+//	//   indexes 0, 1: "bob@example.com"
+//
+// See also [Unique] and [Smuggle].
+func UniqueBy(keyFn any) TestDeep {
+	u := tdUnique{
+		baseOKNil: newBaseOKNil(3),
+	}
+
+	const usage = "(FIELDS_PATH|FUNC)"
+
+	switch fn := keyFn.(type) {
+	case string:
+		vfn, err := getFieldsPathFn(fn)
+		if err != nil {
+			u.err = ctxerr.OpBad("UniqueBy", "UniqueBy%s: %s", usage, err)
+			return &u
+		}
+		u.keyFn = vfn
+		u.argType = types.Interface
+		u.keyStr = strconv.Quote(fn)
+
+	default:
+		vfn := reflect.ValueOf(keyFn)
+		if vfn.Kind() != reflect.Func {
+			u.err = ctxerr.OpBadUsage("UniqueBy", usage, keyFn, 1, true)
+			return &u
+		}
+		fnType := vfn.Type()
+		if fnType.IsVariadic() || fnType.NumIn() != 1 || fnType.NumOut() != 1 {
+			u.err = ctxerr.OpBad("UniqueBy",
+				"UniqueBy%s: FUNC must take only one non-variadic argument and return only one value", usage)
+			return &u
+		}
+		if vfn.IsNil() {
+			u.err = ctxerr.OpBad("UniqueBy", "UniqueBy(FUNC): FUNC cannot be a nil function")
+			return &u
+		}
+		u.keyFn = vfn
+		u.argType = fnType.In(0)
+		u.keyStr = fnType.String()
+	}
+	return &u
+}
+
+// key returns the key of item. If it cannot be computed, the
+// returned key is invalid and the error can be returned as is.
+func (u *tdUnique) key(ctx ctxerr.Context, item reflect.Value) (reflect.Value, *ctxerr.Error) {
+	if !u.keyFn.IsValid() {
+		return item, nil
+	}
+
+	if !types.IsConvertible(item, u.argType) {
+		if ctx.BooleanError {
+			return reflect.Value{}, ctxerr.BooleanError
+		}
+		return reflect.Value{}, ctx.CollectError(&ctxerr.Error{
+			Message:  "incompatible parameter type",
+			Got:      types.RawString(item.Type().String()),
+			Expected: types.RawString(u.argType.String()),
+		})
+	}
+
+	if !item.CanInterface() {
+		if ctx.BooleanError {
+			return reflect.Value{}, ctxerr.BooleanError
+		}
+		return reflect.Value{}, ctx.CollectError(&ctxerr.Error{
+			Message: "cannot compute key of unexported field",
+			Summary: ctxerr.NewSummary("work on surrounding struct instead"),
+		})
+	}
+
+	ret := u.keyFn.Call([]reflect.Value{item.Convert(u.argType)})
+	if len(ret) == 1 {
+		return ret[0], nil
+	}
+
+	// fields-path function: (smuggleValue, error)
+	if err, _ := ret[1].Interface().(error); err != nil {
+		if ctx.BooleanError {
+			return reflect.Value{}, ctxerr.BooleanError
+		}
+		return reflect.Value{}, ctx.CollectError(&ctxerr.Error{
+			Message: "cannot compute key",
+			Summary: ctxerr.NewSummaryReason(item, err.Error()),
+		})
+	}
+	return ret[0].Interface().(smuggleValue).Value, nil
+}
+
+func (u *tdUnique) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if u.err != nil {
+		return ctx.CollectError(u.err)
+	}
+
+	if rErr := grepResolvePtr(ctx, &got); rErr != nil {
+		return rErr
+	}
+
+	switch got.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return grepBadKind(ctx, got)
+	}
+
+	gotLen := got.Len()
+	keys := make([]reflect.Value, gotLen)
+	for idx := 0; idx < gotLen; idx++ {
+		key, err := u.key(ctx.AddArrayIndex(idx), got.Index(idx))
+		if !key.IsValid() {
+			return err
+		}
+		keys[idx] = key
+	}
+
+	var (
+		summary ctxerr.ErrorSummaryItems
+		grouped = make([]bool, gotLen)
+	)
+	for idx := 0; idx < gotLen; idx++ {
+		if grouped[idx] {
+			continue
+		}
+
+		var dups []int
+		for other := idx + 1; other < gotLen; other++ {
+			if !grouped[other] &&
+				deepValueEqualFinalOK(ctx, keys[other], keys[idx]) {
+				grouped[other] = true
+				dups = append(dups, other)
+			}
+		}
+		if dups == nil {
+			continue
+		}
+		if ctx.BooleanError {
+			return ctxerr.BooleanError
+		}
+
+		var label strings.Builder
+		label.WriteString("indexes ")
+		label.WriteString(strconv.Itoa(idx))
+		for _, other := range dups {
+			label.WriteString(", ")
+			label.WriteString(strconv.Itoa(other))
+		}
+		summary = append(summary, ctxerr.ErrorSummaryItem{
+			Label: label.String(),
+			Value: util.ToString(keys[idx]),
+		})
+	}
+
+	if summary == nil {
+		return nil
+	}
+
+	message := "duplicate items found"
+	if u.keyFn.IsValid() {
+		message = "duplicate keys found"
+	}
+	return ctx.CollectError(&ctxerr.Error{
+		Message: message,
+		Summary: summary,
+	})
+}
+
+func (u *tdUnique) String() string {
+	if u.err != nil {
+		return u.stringError()
+	}
+	if u.keyFn.IsValid() {
+		return fmt.Sprintf("UniqueBy(%s)", u.keyStr)
+	}
+	return "Unique()"
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestUnique(t *testing.T) {
+	checkOK(t, []int{}, td.Unique())
+	checkOK(t, []int(nil), td.Unique())
+	checkOK(t, []int{1, 2, 3}, td.Unique())
+	checkOK(t, [3]string{"a", "b", "c"}, td.Unique())
+	checkOK(t, &[]int{1, 2, 3}, td.Unique())
+	checkOK(t, []any{1, int64(1), "1"}, td.Unique())
+	checkOK(t, []map[string]int{{"a": 1}, {"a": 2}}, td.Unique())
+
+	checkError(t, []int{1, 2, 1, 3, 2, 1}, td.Unique(),
+		expectedError{
+			Message: mustBe("duplicate items found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`indexes 0, 2, 5: 1
+   indexes 1, 4: 2`),
+		})
+
+	// Deep equality, not identity
+	checkError(t, []map[string]int{{"a": 1}, {"b": 2}, {"a": 1}}, td.Unique(),
+		expectedError{
+			Message: mustBe("duplicate items found"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`indexes 0, 2: (map[string]int) (len=1) {
+               (string) (len=1) "a": (int) 1
+              }`),
+		})
+
+	a, b := 12, 12
+	checkError(t, []*int{&a, &b}, td.Unique(),
+		expectedError{
+			Message: mustBe("duplicate items found"),
+			Path:    mustBe("DATA"),
+			Summary: mustMatch(`^indexes 0, 1: \(\*int\)\(.*\)\(12\)\z`),
+		})
+
+	//
+	// Bad types
+	checkError(t, 42, td.Unique(),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	checkError(t, nil, td.Unique(),
+		expectedError{
+			Message:  mustBe("bad kind"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("slice OR array OR *slice OR *array"),
+		})
+
+	checkError(t, (*[]int)(nil), td.Unique(),
+		expectedError{
+			Message:  mustBe("nil pointer"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil *slice (*[]int type)"),
+			Expected: mustBe("non-nil *slice OR *array"),
+		})
+
+	test.EqualStr(t, td.Unique().String(), "Unique()")
+}
+
+func TestUniqueBy(t *testing.T) {
+	type Profile struct {
+		Email string
+	}
+	type User struct {
+		ID      int
+		Profile *Profile
+	}
+
+	users := []User{
+		{ID: 1, Profile: &Profile{Email: "bob@example.com"}},
+		{ID: 2, Profile: &Profile{Email: "alice@example.com"}},
+		{ID: 3, Profile: &Profile{Email: "Bob@example.com"}},
+	}
+
+	t.Run("fields-path", func(t *testing.T) {
+		checkOK(t, users, td.UniqueBy("ID"))
+		checkOK(t, users, td.UniqueBy("Profile.Email"))
+		checkOK(t, &users, td.UniqueBy("Profile"))
+		checkOK(t, []any{users[0], &users[1]}, td.UniqueBy("ID"))
+
+		checkError(t, users, td.UniqueBy("Profile.Email[0]"),
+			expectedError{
+				Message: mustBe("cannot compute key"),
+				Path:    mustBe("DATA[0]"),
+				Summary: mustContain(`field "Profile.Email" is a string, but a map, array or slice is expected`),
+			})
+
+		dupUsers := []User{users[0], users[1], users[2], {ID: 1, Profile: users[1].Profile}}
+		checkError(t, dupUsers, td.UniqueBy("ID"),
+			expectedError{
+				Message: mustBe("duplicate keys found"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`indexes 0, 3: 1`),
+			})
+		checkError(t, dupUsers, td.UniqueBy("Profile.Email"),
+			expectedError{
+				Message: mustBe("duplicate keys found"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`indexes 1, 3: "alice@example.com"`),
+			})
+
+		checkError(t, []User{{ID: 1}}, td.UniqueBy("Profile.Email"),
+			expectedError{
+				Message: mustBe("cannot compute key"),
+				Path:    mustBe("DATA[0]"),
+				Summary: mustContain(`field "Profile" is nil`),
+			})
+
+		checkError(t, []int{1, 2}, td.UniqueBy("ID"),
+			expectedError{
+				Message: mustBe("cannot compute key"),
+				Path:    mustBe("DATA[0]"),
+				Summary: mustContain("it is a int and should be a struct"),
+			})
+	})
+
+	t.Run("function", func(t *testing.T) {
+		lowerEmail := func(u User) string {
+			return strings.ToLower(u.Profile.Email)
+		}
+
+		checkOK(t, users[:2], td.UniqueBy(lowerEmail))
+		checkOK(t, []int{1, 2, 3}, td.UniqueBy(func(n int) int { return n }))
+		checkOK(t, []int{1, 2, 3}, td.UniqueBy(func(n int64) int64 { return n }))
+
+		checkError(t, users, td.UniqueBy(lowerEmail),
+			expectedError{
+				Message: mustBe("duplicate keys found"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`indexes 0, 2: "bob@example.com"`),
+			})
+
+		checkError(t, []int{1, 2, 3, 4}, td.UniqueBy(func(n int) bool { return n%2 == 0 }),
+			expectedError{
+				Message: mustBe("duplicate keys found"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`indexes 0, 2: false
+indexes 1, 3: true`),
+			})
+
+		checkError(t, []string{"a"}, td.UniqueBy(func(n int) int { return n }),
+			expectedError{
+				Message:  mustBe("incompatible parameter type"),
+				Path:     mustBe("DATA[0]"),
+				Got:      mustBe("string"),
+				Expected: mustBe("int"),
+			})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		checkError(t, "never tested",
+			td.UniqueBy(42),
+			expectedError{
+				Message: mustBe("bad usage of UniqueBy operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: UniqueBy(FIELDS_PATH|FUNC), but received int as 1st parameter"),
+			})
+
+		checkError(t, "never tested",
+			td.UniqueBy(nil),
+			expectedError{
+				Message: mustBe("bad usage of UniqueBy operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("usage: UniqueBy(FIELDS_PATH|FUNC), but received nil as 1st parameter"),
+			})
+
+		checkError(t, "never tested",
+			td.UniqueBy("Foo..Bar"),
+			expectedError{
+				Message: mustBe("bad usage of UniqueBy operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe(`UniqueBy(FIELDS_PATH|FUNC): unexpected '.' after '.' in FIELD_PATH "Foo..Bar"`),
+			})
+
+		checkError(t, "never tested",
+			td.UniqueBy(func(a, b int) int { return a }),
+			expectedError{
+				Message: mustBe("bad usage of UniqueBy operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("UniqueBy(FIELDS_PATH|FUNC): FUNC must take only one non-variadic argument and return only one value"),
+			})
+
+		checkError(t, "never tested",
+			td.UniqueBy((func(int) int)(nil)),
+			expectedError{
+				Message: mustBe("bad usage of UniqueBy operator"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("UniqueBy(FUNC): FUNC cannot be a nil function"),
+			})
+
+		checkError(t, 42, td.UniqueBy("ID"),
+			expectedError{
+				Message:  mustBe("bad kind"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("int"),
+				Expected: mustBe("slice OR array OR *slice OR *array"),
+			})
+	})
+
+	//
+	// String
+	test.EqualStr(t, td.UniqueBy("Profile.Email").String(), `UniqueBy("Profile.Email")`)
+	test.EqualStr(t, td.UniqueBy(func(n int) int { return n }).String(), "UniqueBy(func(int) int)")
+
+	// Erroneous op
+	test.EqualStr(t, td.UniqueBy(42).String(), "UniqueBy(<ERROR>)")
+}
+
+func TestUniqueTypeBehind(t *testing.T) {
+	equalTypes(t, td.Unique(), nil)
+	equalTypes(t, td.UniqueBy("ID"), nil)
+}
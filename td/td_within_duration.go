@@ -0,0 +1,241 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/ctxerr"
+	"github.com/maxatome/go-testdeep/internal/types"
+)
+
+type tdWithinDuration struct {
+	tdExpectedType
+	expectedTime time.Time
+	delta        time.Duration
+	now          func() time.Time // only set by Recent
+}
+
+var _ TestDeep = &tdWithinDuration{}
+
+func newWithinDuration(delta time.Duration) *tdWithinDuration {
+	t := tdWithinDuration{
+		tdExpectedType: tdExpectedType{
+			base: newBase(4),
+		},
+		delta: delta,
+	}
+	if delta < 0 {
+		t.err = ctxerr.OpBad(t.location.Func,
+			"duration cannot be negative (%s)", delta)
+	}
+	return &t
+}
+
+func (t *tdWithinDuration) setExpectedTime(expectedTime any) {
+	vval := reflect.ValueOf(expectedTime)
+	if !vval.IsValid() {
+		t.err = ctxerr.OpBad(t.location.Func,
+			"1st parameter must be time.Time or convertible to time.Time, but not nil")
+		return
+	}
+
+	t.expectedType = vval.Type()
+	if t.expectedType == types.Time {
+		t.expectedTime = expectedTime.(time.Time).Round(0)
+		return
+	}
+	if !t.expectedType.ConvertibleTo(types.Time) {
+		t.err = ctxerr.OpBad(t.location.Func,
+			"1st parameter must be time.Time or convertible to time.Time, but not %T",
+			expectedTime)
+		return
+	}
+	t.expectedTime = vval.Convert(types.Time).Interface().(time.Time).Round(0)
+}
+
+// summary(WithinDuration): checks a time.Time (or assignable) is
+// within a duration of an expected time
+// input(WithinDuration): struct(time.Time)
+
+// WithinDuration operator checks that data is a [time.Time] (or
+// assignable) value which is not more than delta away, before or
+// after, from expectedTime.
+//
+// During comparison, location and monotonic clock reading do not
+// matter: a time instant in two different locations is the same
+// time instant.
+//
+//	created := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+//	t0 := time.Date(2023, time.March, 9, 1, 2, 0, 0, time.UTC)
+//
+//	td.Cmp(t, created, td.WithinDuration(t0, 5*time.Second)) // succeeds
+//	td.Cmp(t, created, td.WithinDuration(t0, time.Second))   // fails
+//
+// delta cannot be negative.
+//
+// TypeBehind method returns the [reflect.Type] of expectedTime.
+//
+// See also [Recent], [SameInstant] and [TruncTime].
+func WithinDuration(expectedTime any, delta time.Duration) TestDeep {
+	t := newWithinDuration(delta)
+	if t.err == nil {
+		t.setExpectedTime(expectedTime)
+	}
+	return t
+}
+
+// summary(SameInstant): checks a time.Time (or assignable) is the
+// same time instant as an expected one
+// input(SameInstant): struct(time.Time)
+
+// SameInstant operator checks that data is a [time.Time] (or
+// assignable) value representing the same time instant as
+// expectedTime, using [time.Time.Equal] behind the scenes.
+//
+// Location and monotonic clock reading are ignored: a time instant
+// in two different locations is the same time instant.
+//
+//	got := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+//	paris := time.FixedZone("Paris", 3600)
+//
+//	td.Cmp(t, got, td.SameInstant(got.In(paris)))   // succeeds
+//	td.Cmp(t, got, td.SameInstant(time.Unix(0, 0))) // fails
+//
+// It is the same as [WithinDuration] with a zero delta.
+//
+// TypeBehind method returns the [reflect.Type] of expectedTime.
+//
+// See also [Recent], [TruncTime] and [WithinDuration].
+func SameInstant(expectedTime any) TestDeep {
+	t := newWithinDuration(0)
+	t.setExpectedTime(expectedTime)
+	return t
+}
+
+// summary(Recent): checks a time.Time (or assignable) is within a
+// duration of the current time
+// input(Recent): struct(time.Time)
+
+// Recent operator checks that data is a [time.Time] (or convertible
+// to [time.Time]) value which is not more than delta away, before or
+// after, from the current time. The current time is retrieved using
+// [time.Now] each time the operator is used, typically when [Cmp] is
+// called, not when Recent is called.
+//
+//	td.Cmp(t, user.CreatedAt, td.Recent(time.Second))
+//
+// If now is passed it should be only one item. It is called in place
+// of [time.Now] to get the current time, allowing to inject a fake
+// clock:
+//
+//	clock := func() time.Time {
+//	  return time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+//	}
+//	created := time.Date(2023, time.March, 9, 1, 2, 0, 0, time.UTC)
+//	td.Cmp(t, created, td.Recent(5*time.Second, clock)) // succeeds
+//
+// If now is missing or nil, it defaults to [time.Now].
+//
+// Location and monotonic clock reading are ignored during the
+// comparison. delta cannot be negative.
+//
+// TypeBehind method returns the [reflect.Type] of [time.Time].
+//
+// See also [SameInstant], [TruncTime] and [WithinDuration].
+func Recent(delta time.Duration, now ...func() time.Time) TestDeep {
+	t := newWithinDuration(delta)
+	t.expectedType = types.Time
+	t.now = time.Now
+
+	switch len(now) {
+	case 0:
+	case 1:
+		if now[0] != nil {
+			t.now = now[0]
+		}
+	default:
+		t.err = ctxerr.OpTooManyParams(t.location.Func, "(DURATION[, NOW_FUNC])")
+	}
+	return t
+}
+
+func (t *tdWithinDuration) Match(ctx ctxerr.Context, got reflect.Value) *ctxerr.Error {
+	if t.err != nil {
+		return ctx.CollectError(t.err)
+	}
+
+	expectedTime := t.expectedTime
+	if t.now != nil {
+		// Recent accepts any type convertible to time.Time
+		if !got.Type().ConvertibleTo(types.Time) {
+			if ctx.BooleanError {
+				return ctxerr.BooleanError
+			}
+			return ctx.CollectError(t.errorTypeMismatch(got.Type()))
+		}
+		expectedTime = t.now().Round(0)
+	} else if err := t.checkType(ctx, got); err != nil {
+		return ctx.CollectError(err)
+	}
+
+	gotTime, err := getTime(ctx, got, got.Type() != types.Time)
+	if err != nil {
+		return ctx.CollectError(err)
+	}
+	gotTime = gotTime.Round(0)
+
+	diff := gotTime.Sub(expectedTime)
+	if diff <= t.delta && diff >= -t.delta {
+		return nil
+	}
+
+	// Fail
+	if ctx.BooleanError {
+		return ctxerr.BooleanError
+	}
+
+	gotStr := gotTime.String()
+	if got.Type() != types.Time && got.Type().Implements(types.FmtStringer) {
+		gotStr = got.Interface().(fmt.Stringer).String()
+	}
+	if t.delta > 0 || t.now != nil {
+		gotStr += fmt.Sprintf("\n(%s away)", diff)
+	}
+
+	return ctx.CollectError(&ctxerr.Error{
+		Message:  "values differ",
+		Got:      types.RawString(gotStr),
+		Expected: types.RawString(t.expectedStr(expectedTime)),
+	})
+}
+
+func (t *tdWithinDuration) expectedStr(expectedTime time.Time) string {
+	var s string
+	if t.now == nil && t.expectedType.Implements(types.FmtStringer) {
+		s = reflect.ValueOf(expectedTime).Convert(t.expectedType).
+			Interface().(fmt.Stringer).String()
+	} else {
+		s = expectedTime.String()
+	}
+	if t.delta > 0 || t.now != nil {
+		s += " ± " + t.delta.String()
+	}
+	return s
+}
+
+func (t *tdWithinDuration) String() string {
+	if t.err != nil {
+		return t.stringError()
+	}
+	if t.now != nil {
+		return "now ± " + t.delta.String()
+	}
+	return t.expectedStr(t.expectedTime)
+}
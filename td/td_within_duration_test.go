@@ -0,0 +1,197 @@
+// Copyright (c) 2023, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package td_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/internal/test"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestWithinDuration(t *testing.T) {
+	t0 := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+	paris := time.FixedZone("Paris", 3600)
+
+	checkOK(t, t0, td.WithinDuration(t0, 0))
+	checkOK(t, t0, td.WithinDuration(t0.In(paris), 0))
+	checkOK(t, t0.Add(time.Second), td.WithinDuration(t0, time.Second))
+	checkOK(t, t0.Add(-time.Second), td.WithinDuration(t0, time.Second))
+	checkOK(t, MyTime(t0), td.WithinDuration(MyTime(t0.Add(time.Second)), time.Second))
+
+	// Monotonic clock reading does not matter
+	now := time.Now()
+	checkOK(t, now, td.WithinDuration(now.Round(0), 0))
+	checkOK(t, now.Round(0), td.WithinDuration(now, 0))
+
+	checkError(t, t0.Add(2*time.Second), td.WithinDuration(t0, time.Second),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("2023-03-09 01:02:05 +0000 UTC\n(2s away)"),
+			Expected: mustBe("2023-03-09 01:02:03 +0000 UTC ± 1s"),
+		})
+
+	checkError(t, MyTimeStr(t0.Add(-2*time.Second)),
+		td.WithinDuration(MyTimeStr(t0), time.Second),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("<<2023-03-09T01:02:01Z>>\n(-2s away)"),
+			Expected: mustBe("<<2023-03-09T01:02:03Z>> ± 1s"),
+		})
+
+	checkError(t, MyTime(t0), td.WithinDuration(t0, time.Second),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("td_test.MyTime"),
+			Expected: mustBe("time.Time"),
+		})
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		td.WithinDuration("test", time.Second),
+		expectedError{
+			Message: mustBe("bad usage of WithinDuration operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("1st parameter must be time.Time or convertible to time.Time, but not string"),
+		})
+
+	checkError(t, "never tested",
+		td.WithinDuration(nil, time.Second),
+		expectedError{
+			Message: mustBe("bad usage of WithinDuration operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("1st parameter must be time.Time or convertible to time.Time, but not nil"),
+		})
+
+	checkError(t, "never tested",
+		td.WithinDuration(t0, -time.Second),
+		expectedError{
+			Message: mustBe("bad usage of WithinDuration operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("duration cannot be negative (-1s)"),
+		})
+
+	//
+	// String
+	test.EqualStr(t, td.WithinDuration(t0, time.Minute).String(),
+		"2023-03-09 01:02:03 +0000 UTC ± 1m0s")
+	test.EqualStr(t, td.WithinDuration(MyTimeStr(t0), time.Minute).String(),
+		"<<2023-03-09T01:02:03Z>> ± 1m0s")
+
+	// Erroneous op
+	test.EqualStr(t, td.WithinDuration("test", 0).String(), "WithinDuration(<ERROR>)")
+}
+
+func TestSameInstant(t *testing.T) {
+	t0 := time.Date(2023, time.March, 9, 1, 2, 3, 4, time.UTC)
+	paris := time.FixedZone("Paris", 3600)
+
+	checkOK(t, t0, td.SameInstant(t0))
+	checkOK(t, t0.In(paris), td.SameInstant(t0))
+	checkOK(t, MyTime(t0), td.SameInstant(MyTime(t0.In(paris))))
+
+	now := time.Now()
+	checkOK(t, now, td.SameInstant(now.Round(0)))
+
+	checkError(t, t0, td.SameInstant(t0.Add(time.Nanosecond)),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("2023-03-09 01:02:03.000000004 +0000 UTC"),
+			Expected: mustBe("2023-03-09 01:02:03.000000005 +0000 UTC"),
+		})
+
+	checkError(t, "never tested",
+		td.SameInstant(42),
+		expectedError{
+			Message: mustBe("bad usage of SameInstant operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("1st parameter must be time.Time or convertible to time.Time, but not int"),
+		})
+
+	test.EqualStr(t, td.SameInstant(t0).String(),
+		"2023-03-09 01:02:03.000000004 +0000 UTC")
+
+	// Erroneous op
+	test.EqualStr(t, td.SameInstant(42).String(), "SameInstant(<ERROR>)")
+}
+
+func TestRecent(t *testing.T) {
+	checkOK(t, time.Now(), td.Recent(time.Minute))
+	checkOK(t, time.Now().Add(-30*time.Second), td.Recent(time.Minute, nil))
+	checkOK(t, MyTime(time.Now()), td.Recent(time.Minute))
+
+	t0 := time.Date(2023, time.March, 9, 1, 2, 3, 0, time.UTC)
+	clock := func() time.Time { return t0 }
+
+	checkOK(t, t0.Add(-5*time.Second), td.Recent(5*time.Second, clock))
+	checkOK(t, t0.Add(5*time.Second), td.Recent(5*time.Second, clock))
+
+	checkError(t, t0.Add(-time.Hour), td.Recent(time.Minute, clock),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("2023-03-09 00:02:03 +0000 UTC\n(-1h0m0s away)"),
+			Expected: mustBe("2023-03-09 01:02:03 +0000 UTC ± 1m0s"),
+		})
+
+	checkError(t, MyTimeStr(t0.Add(-time.Hour)), td.Recent(time.Minute, clock),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("<<2023-03-09T00:02:03Z>>\n(-1h0m0s away)"),
+			Expected: mustBe("2023-03-09 01:02:03 +0000 UTC ± 1m0s"),
+		})
+
+	checkError(t, "2023-03-09", td.Recent(time.Minute),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("string"),
+			Expected: mustBe("time.Time"),
+		})
+
+	//
+	// Bad usage
+	checkError(t, "never tested",
+		td.Recent(time.Minute, clock, clock),
+		expectedError{
+			Message: mustBe("bad usage of Recent operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("usage: Recent(DURATION[, NOW_FUNC]), too many parameters"),
+		})
+
+	checkError(t, "never tested",
+		td.Recent(-time.Minute),
+		expectedError{
+			Message: mustBe("bad usage of Recent operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("duration cannot be negative (-1m0s)"),
+		})
+
+	test.EqualStr(t, td.Recent(time.Minute).String(), "now ± 1m0s")
+
+	// Erroneous op
+	test.EqualStr(t, td.Recent(-time.Minute).String(), "Recent(<ERROR>)")
+}
+
+func TestWithinDurationTypeBehind(t *testing.T) {
+	equalTypes(t, td.WithinDuration(time.Time{}, time.Second), time.Time{})
+	equalTypes(t, td.WithinDuration(MyTime{}, time.Second), MyTime{})
+	equalTypes(t, td.SameInstant(MyTime{}), MyTime{})
+	equalTypes(t, td.Recent(time.Second), time.Time{})
+
+	// Erroneous op
+	equalTypes(t, td.WithinDuration("test", time.Second), nil)
+	equalTypes(t, td.SameInstant(nil), nil)
+	equalTypes(t, td.Recent(-time.Second), nil)
+}
@@ -51,17 +51,19 @@ func TestZero(t *testing.T) {
 		})
 	checkError(t, map[string]int{}, td.Zero(),
 		expectedError{
-			Message:  mustBe("nil map"),
-			Path:     mustBe("DATA"),
-			Got:      mustBe("not nil"),
-			Expected: mustBe("nil"),
+			Message: mustBe("nil map"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`     got: not nil
+expected: nil
+both are empty, NilEqualsEmpty would consider them equal`),
 		})
 	checkError(t, []int{}, td.Zero(),
 		expectedError{
-			Message:  mustBe("nil slice"),
-			Path:     mustBe("DATA"),
-			Got:      mustBe("not nil"),
-			Expected: mustBe("nil"),
+			Message: mustBe("nil slice"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`     got: not nil
+expected: nil
+both are empty, NilEqualsEmpty would consider them equal`),
 		})
 	checkError(t, [3]int{0, 12}, td.Zero(),
 		expectedError{
@@ -8,6 +8,7 @@ package td
 
 import (
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/maxatome/go-testdeep/internal/ctxerr"
@@ -32,3 +33,12 @@ func getTime(ctx ctxerr.Context, got reflect.Value, mustConvert bool) (time.Time
 	}
 	return gotIf.(time.Time), nil
 }
+
+// plural returns n followed by word, suffixed by "s" if n != 1.
+func plural(n int, word string) string {
+	s := strconv.Itoa(n) + " " + word
+	if n != 1 {
+		s += "s"
+	}
+	return s
+}
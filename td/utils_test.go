@@ -77,3 +77,9 @@ func TestGetTime(t *testing.T) {
 		}
 	}
 }
+
+func TestPlural(t *testing.T) {
+	test.EqualStr(t, plural(0, "item"), "0 items")
+	test.EqualStr(t, plural(1, "item"), "1 item")
+	test.EqualStr(t, plural(2, "item"), "2 items")
+}
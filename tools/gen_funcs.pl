@@ -98,15 +98,20 @@ my $ARGS_COMMENT_MD = doc2md($args_comment_src);
 # These functions are variadics, but with only one possible param. In
 # this case, discard the variadic property and use a default value for
 # this optional parameter.
-my %IGNORE_VARIADIC = (Between   => 'td.BoundsInIn',
-                       N         => 0,
-                       Re        => 'nil',
-                       Recv      => 0,
-                       TruncTime => 0,
+my %IGNORE_VARIADIC = (Between    => 'td.BoundsInIn',
+                       Image      => 'td.ImageOptions{}',
+                       N          => 0,
+                       Re         => 'nil',
+                       Recv       => 0,
+                       RecvAll    => 0,
+                       RecvN      => 0,
+                       RecvWithin => 'td.Ignore()',
+                       Recent     => 'nil',
+                       TruncTime  => 0,
                        # These operators accept several StructFields,
                        # but we want only one here
-                       Struct    => 'nil',
-                       SStruct   => 'nil');
+                       Struct     => 'nil',
+                       SStruct    => 'nil');
 
 # Smuggler operators (automatically filled)
 my %SMUGGLER_OPERATORS;
@@ -116,7 +121,7 @@ my %RENAME_METHOD = (Lax     => 'CmpLax',
                      ErrorIs => 'CmpErrorIs');
 
 # These operators do not have *T method nor Cmp shortcut
-my %ONLY_OPERATORS = map { $_ => 1 } qw(Catch Delay Ignore Tag);
+my %ONLY_OPERATORS = map { $_ => 1 } qw(Catch Delay Ignore Ref Tag);
 
 my @INPUT_LABELS = qw(nil bool str int float cplx
                       array slice map struct ptr if chan func);
@@ -528,9 +533,15 @@ foreach my $func (@sorted_funcs)
                       $repl .= ', ';
                       if ($args->[$i]{variadic})
                       {
-                          if (defined $params[$i])
+                          if ($i == $#params and $params[$i] =~ /^(.+)\.\.\.\z/s)
                           {
-                              $repl .= '[]' . $args->[$i]{type} . '{'
+                              $repl .= $1;
+                          }
+                          elsif (defined $params[$i])
+                          {
+                              # td exported types need to be qualified
+                              (my $type = $args->[$i]{type}) =~ s/^(?=[A-Z])/td./;
+                              $repl .= '[]' . $type . '{'
                                      . join(', ', @params[$i .. $#params])
                                      . '}';
                           }
@@ -592,6 +603,7 @@ foreach my $go_file (do { opendir(my $dh, $DIR);
 
         next if ($func eq '(t *T) CmpDeeply'
                  or $func eq 'CmpDeeply'
+                 or $func eq '(t *T) Batch' # args... names the batch
                  or $func =~ /^\(t \*T\) (?:Log|Error|Fatal)Trace\z/);
 
         if ($params =~ /\Qargs ...any)\E\z/